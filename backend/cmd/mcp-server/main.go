@@ -56,9 +56,18 @@ func main() {
 	accountRepo := postgres.NewAccountRepository(pool)
 	transactionRepo := postgres.NewTransactionRepository(pool)
 	commodityRepo := postgres.NewCommodityRepository(pool)
+	priceRepo := postgres.NewPriceRepository(pool)
+	lotRepo := postgres.NewLotRepository(pool)
+	budgetRepo := postgres.NewBudgetRepository(pool)
+	sxRepo := postgres.NewScheduledTransactionRepository(pool)
 
 	// Initialize services
-	analyticsService := service.NewAnalyticsService(accountRepo, transactionRepo)
+	analyticsService := service.NewAnalyticsService(accountRepo, transactionRepo, commodityRepo, priceRepo, lotRepo, budgetRepo, sxRepo)
+	transactionService := service.NewTransactionService(transactionRepo, accountRepo, commodityRepo)
+	accountService := service.NewAccountService(accountRepo, commodityRepo)
+	priceService := service.NewPriceService(priceRepo, commodityRepo)
+	budgetService := service.NewBudgetService(budgetRepo, accountRepo, transactionRepo)
+	sxService := service.NewScheduledTransactionService(sxRepo, accountRepo, transactionService)
 
 	// Create MCP server
 	mcpServer := mcp.NewMCPServer(
@@ -66,6 +75,11 @@ func main() {
 		transactionRepo,
 		commodityRepo,
 		analyticsService,
+		transactionService,
+		accountService,
+		priceService,
+		budgetService,
+		sxService,
 	)
 
 	logger.Info("MCP server initialized successfully")
@@ -67,27 +67,59 @@ func main() {
 	userRepo := postgres.NewUserRepository(pool)
 	transactionRepo := postgres.NewTransactionRepository(pool)
 	commodityRepo := postgres.NewCommodityRepository(pool)
+	priceRepo := postgres.NewPriceRepository(pool)
+	lotRepo := postgres.NewLotRepository(pool)
+	budgetRepo := postgres.NewBudgetRepository(pool)
+	sxRepo := postgres.NewScheduledTransactionRepository(pool)
+	reconcileRepo := postgres.NewReconciliationRepository(pool)
+	importRepo := postgres.NewImportRepository(pool)
+	csvProfileRepo := postgres.NewCSVProfileRepository(pool)
+	importRuleRepo := postgres.NewImportRuleRepository(pool)
 
 	// Initialize services
 	authService := service.NewAuthService(userRepo, jwtManager)
-	analyticsService := service.NewAnalyticsService(accountRepo, transactionRepo)
+	analyticsService := service.NewAnalyticsService(accountRepo, transactionRepo, commodityRepo, priceRepo, lotRepo, budgetRepo, sxRepo)
+	transactionService := service.NewTransactionService(transactionRepo, accountRepo, commodityRepo)
+	accountService := service.NewAccountService(accountRepo, commodityRepo)
+	priceService := service.NewPriceService(priceRepo, commodityRepo)
+	budgetService := service.NewBudgetService(budgetRepo, accountRepo, transactionRepo)
+	sxService := service.NewScheduledTransactionService(sxRepo, accountRepo, transactionService)
+	reconciliationService := service.NewReconciliationService(reconcileRepo, accountRepo)
+	importService := service.NewImportService(importRepo, csvProfileRepo, importRuleRepo, accountRepo, accountService, transactionService)
+
+	// Optionally auto-create due scheduled transactions in the background
+	if cfg.Scheduler.Enabled {
+		sxRunner := service.NewScheduledTransactionRunner(sxService, cfg.Scheduler.Interval)
+		go sxRunner.Start(ctx)
+		defer sxRunner.Stop()
+	}
 
 	// Initialize handlers
-	accountHandler := handler.NewAccountHandler(accountRepo, commodityRepo)
+	accountHandler := handler.NewAccountHandler(accountRepo, commodityRepo, lotRepo, accountService)
 	authHandler := handler.NewAuthHandler(authService)
-	transactionHandler := handler.NewTransactionHandler(transactionRepo)
+	transactionHandler := handler.NewTransactionHandler(transactionRepo, transactionService)
 	analyticsHandler := handler.NewAnalyticsHandler(analyticsService)
 	commodityHandler := handler.NewCommodityHandler(commodityRepo)
+	priceHandler := handler.NewPriceHandler(priceService)
+	budgetHandler := handler.NewBudgetHandler(budgetService, analyticsService)
+	sxHandler := handler.NewScheduledTransactionHandler(sxService)
+	reconciliationHandler := handler.NewReconciliationHandler(reconciliationService)
+	importHandler := handler.NewImportHandler(importService)
 
 	// Setup router
 	router := httpRouter.Router(&httpRouter.RouterConfig{
-		AccountHandler:     accountHandler,
-		AuthHandler:        authHandler,
-		TransactionHandler: transactionHandler,
-		AnalyticsHandler:   analyticsHandler,
-		CommodityHandler:   commodityHandler,
-		JWTManager:         jwtManager,
-		AllowedOrigins:     cfg.CORS.AllowedOrigins,
+		AccountHandler:              accountHandler,
+		AuthHandler:                 authHandler,
+		TransactionHandler:          transactionHandler,
+		AnalyticsHandler:            analyticsHandler,
+		CommodityHandler:            commodityHandler,
+		PriceHandler:                priceHandler,
+		BudgetHandler:               budgetHandler,
+		ScheduledTransactionHandler: sxHandler,
+		ReconciliationHandler:       reconciliationHandler,
+		ImportHandler:               importHandler,
+		JWTManager:                  jwtManager,
+		AllowedOrigins:              cfg.CORS.AllowedOrigins,
 	})
 
 	// Create HTTP server
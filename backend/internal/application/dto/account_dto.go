@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 // AccountResponse represents an account in API responses
 type AccountResponse struct {
 	GUID              string            `json:"guid"`
@@ -25,3 +27,95 @@ type AccountBalanceResponse struct {
 	BalanceNum   int64  `json:"balance_num"`
 	BalanceDenom int64  `json:"balance_denom"`
 }
+
+// CreateAccountRequest represents a request to create an account.
+// ParentGUID defaults to the book's root account, CommodityGUID to the parent's
+// commodity and CommoditySCU to the commodity's fraction.
+type CreateAccountRequest struct {
+	Name          string  `json:"name" binding:"required"`
+	Type          string  `json:"type" binding:"required"`
+	ParentGUID    string  `json:"parent_guid,omitempty"`
+	CommodityGUID string  `json:"commodity_guid,omitempty"`
+	CommoditySCU  int     `json:"commodity_scu,omitempty"`
+	Code          *string `json:"code,omitempty"`
+	Description   *string `json:"description,omitempty"`
+	Hidden        bool    `json:"hidden"`
+	Placeholder   bool    `json:"placeholder"`
+}
+
+// UpdateAccountRequest represents a partial update of an account; nil fields are left unchanged
+type UpdateAccountRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Code        *string `json:"code,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Hidden      *bool   `json:"hidden,omitempty"`
+	Placeholder *bool   `json:"placeholder,omitempty"`
+}
+
+// MoveAccountRequest represents a request to reparent an account
+type MoveAccountRequest struct {
+	ParentGUID string `json:"parent_guid" binding:"required"`
+}
+
+// MergeAccountsRequest represents a request to merge one account into another
+type MergeAccountsRequest struct {
+	TargetGUID string `json:"target_guid" binding:"required"`
+	DryRun     bool   `json:"dry_run"`
+	HideSource bool   `json:"hide_source"`
+}
+
+// MergeAccountsResponse reports what a merge moved, or would move in a dry run
+type MergeAccountsResponse struct {
+	SourceGUID        string `json:"source_guid"`
+	SourceName        string `json:"source_name"`
+	TargetGUID        string `json:"target_guid"`
+	TargetName        string `json:"target_name"`
+	SplitCount        int64  `json:"split_count"`
+	TransactionCount  int64  `json:"transaction_count"`
+	ChildAccountCount int    `json:"child_account_count"`
+	SourceAction      string `json:"source_action"`
+	DryRun            bool   `json:"dry_run"`
+}
+
+// LotResponse represents a lot in API responses
+type LotResponse struct {
+	GUID        string     `json:"guid"`
+	AccountGUID string     `json:"account_guid"`
+	Title       *string    `json:"title,omitempty"`
+	Notes       *string    `json:"notes,omitempty"`
+	IsClosed    bool       `json:"is_closed"`
+	Quantity    string     `json:"quantity"`
+	SplitCount  int        `json:"split_count"`
+	OpenedDate  *time.Time `json:"opened_date,omitempty"`
+}
+
+// AccountRegisterRow represents one split of an account register
+type AccountRegisterRow struct {
+	SplitGUID          string     `json:"split_guid"`
+	TransactionGUID    string     `json:"transaction_guid"`
+	PostDate           time.Time  `json:"post_date"`
+	Num                string     `json:"num,omitempty"`
+	Description        string     `json:"description,omitempty"`
+	Memo               string     `json:"memo,omitempty"`
+	Action             string     `json:"action,omitempty"`
+	CounterAccountGUID string     `json:"counter_account_guid,omitempty"`
+	CounterAccount     string     `json:"counter_account"`
+	Amount             string     `json:"amount"`
+	Value              string     `json:"value"`
+	ReconcileState     string     `json:"reconcile_state"`
+	ReconcileDate      *time.Time `json:"reconcile_date,omitempty"`
+	Balance            string     `json:"balance"`
+}
+
+// AccountRegisterResponse represents a page of an account register. Pass
+// NextCursor as the cursor parameter to fetch the following page.
+type AccountRegisterResponse struct {
+	AccountGUID       string               `json:"account_guid"`
+	AccountName       string               `json:"account_name"`
+	CommodityMnemonic string               `json:"commodity_mnemonic,omitempty"`
+	OpeningBalance    string               `json:"opening_balance"`
+	ClosingBalance    string               `json:"closing_balance"`
+	Rows              []AccountRegisterRow `json:"rows"`
+	NextCursor        string               `json:"next_cursor,omitempty"`
+	HasMore           bool                 `json:"has_more"`
+}
@@ -45,11 +45,20 @@ type TrendResponse struct {
 	Data        []TrendDataPoint `json:"data"`
 }
 
-// NetWorthItem represents an asset or liability balance
+// NetWorthItem represents an asset or liability balance. Balance is the
+// converted amount and is omitted when no price is available.
 type NetWorthItem struct {
-	AccountName string `json:"account_name"`
-	AccountType string `json:"account_type"`
-	Balance     string `json:"balance"`
+	AccountGUID   string `json:"account_guid"`
+	AccountName   string `json:"account_name"`
+	AccountType   string `json:"account_type"`
+	Commodity     string `json:"commodity"`
+	NativeBalance string `json:"native_balance"`
+	Rate          string `json:"rate,omitempty"`
+	RateMethod    string `json:"rate_method,omitempty"`
+	RateVia       string `json:"rate_via,omitempty"`
+	RateDate      string `json:"rate_date,omitempty"`
+	Balance       string `json:"balance,omitempty"`
+	PriceMissing  bool   `json:"price_missing"`
 }
 
 // NetWorthResponse represents net worth analytics
@@ -60,4 +69,361 @@ type NetWorthResponse struct {
 	TotalLiabilities string         `json:"total_liabilities"`
 	NetWorth         string         `json:"net_worth"`
 	CurrencyMnemonic string         `json:"currency_mnemonic,omitempty"`
+	AsOf             string         `json:"as_of"`
+	MissingPrices    int            `json:"missing_prices"`
+}
+
+// HoldingItem represents a security position held in a STOCK or MUTUAL account
+type HoldingItem struct {
+	AccountGUID    string `json:"account_guid"`
+	AccountName    string `json:"account_name"`
+	AccountType    string `json:"account_type"`
+	Commodity      string `json:"commodity"`
+	Shares         string `json:"shares"`
+	CostBasis      string `json:"cost_basis"`
+	LatestPrice    string `json:"latest_price,omitempty"`
+	PriceDate      string `json:"price_date,omitempty"`
+	PriceMethod    string `json:"price_method,omitempty"`
+	MarketValue    string `json:"market_value,omitempty"`
+	UnrealizedGain string `json:"unrealized_gain,omitempty"`
+	PriceMissing   bool   `json:"price_missing"`
+	CostIncomplete bool   `json:"cost_incomplete"`
+}
+
+// HoldingRollup represents the holdings below a parent account
+type HoldingRollup struct {
+	AccountGUID    string `json:"account_guid"`
+	AccountName    string `json:"account_name"`
+	Holdings       int    `json:"holdings"`
+	CostBasis      string `json:"cost_basis"`
+	MarketValue    string `json:"market_value"`
+	UnrealizedGain string `json:"unrealized_gain"`
+	Incomplete     bool   `json:"incomplete"`
+}
+
+// HoldingsResponse represents the investment holdings report
+type HoldingsResponse struct {
+	Holdings            []HoldingItem   `json:"holdings"`
+	Rollups             []HoldingRollup `json:"rollups"`
+	TotalCostBasis      string          `json:"total_cost_basis"`
+	TotalMarketValue    string          `json:"total_market_value"`
+	TotalUnrealizedGain string          `json:"total_unrealized_gain"`
+	CurrencyMnemonic    string          `json:"currency_mnemonic"`
+	AsOf                string          `json:"as_of"`
+}
+
+// RealizedGainItem represents the part of a sale matched against one lot
+type RealizedGainItem struct {
+	AccountGUID  string  `json:"account_guid"`
+	AccountName  string  `json:"account_name"`
+	Commodity    string  `json:"commodity"`
+	LotGUID      *string `json:"lot_guid,omitempty"`
+	LotTitle     string  `json:"lot_title,omitempty"`
+	SaleTxGUID   string  `json:"sale_tx_guid"`
+	AcquiredDate string  `json:"acquired_date,omitempty"`
+	SoldDate     string  `json:"sold_date"`
+	Shares       string  `json:"shares"`
+	Proceeds     string  `json:"proceeds"`
+	CostBasis    string  `json:"cost_basis"`
+	Gain         string  `json:"gain"`
+	Term         string  `json:"term"`
+	Unmatched    bool    `json:"unmatched,omitempty"`
+}
+
+// CapitalGainsTotal represents the totals for one holding period
+type CapitalGainsTotal struct {
+	Proceeds  string `json:"proceeds"`
+	CostBasis string `json:"cost_basis"`
+	Gain      string `json:"gain"`
+}
+
+// CapitalGainsResponse represents realized capital gains for a tax year
+type CapitalGainsResponse struct {
+	Year             int                `json:"year"`
+	Method           string             `json:"method"`
+	CurrencyMnemonic string             `json:"currency_mnemonic"`
+	ShortTerm        []RealizedGainItem `json:"short_term"`
+	LongTerm         []RealizedGainItem `json:"long_term"`
+	ShortTermTotal   CapitalGainsTotal  `json:"short_term_total"`
+	LongTermTotal    CapitalGainsTotal  `json:"long_term_total"`
+	TotalGain        string             `json:"total_gain"`
+	Incomplete       bool               `json:"incomplete"`
+}
+
+// ForecastAccount summarizes the projection of one account. Balances are
+// signed the GnuCash way: money held is positive, money owed negative.
+type ForecastAccount struct {
+	AccountGUID            string `json:"account_guid"`
+	AccountName            string `json:"account_name"`
+	AccountType            string `json:"account_type"`
+	Commodity              string `json:"commodity"`
+	Monitored              bool   `json:"monitored"`
+	StartingBalance        string `json:"starting_balance"`
+	EndingBalance          string `json:"ending_balance"`
+	LowestBalance          string `json:"lowest_balance"`
+	LowestDate             string `json:"lowest_date"`
+	ProjectedDailySpending string `json:"projected_daily_spending,omitempty"`
+}
+
+// ForecastEvent is a known future posting to a forecast account. Date is
+// the posting's own date, which is earlier than the forecast day for
+// overdue scheduled occurrences.
+type ForecastEvent struct {
+	Date                     string `json:"date"`
+	Source                   string `json:"source"`
+	ScheduledTransactionGUID string `json:"scheduled_transaction_guid,omitempty"`
+	TransactionGUID          string `json:"transaction_guid,omitempty"`
+	Description              string `json:"description,omitempty"`
+	AccountGUID              string `json:"account_guid"`
+	Amount                   string `json:"amount"`
+}
+
+// ForecastDay holds the projected end-of-day balances by account GUID
+type ForecastDay struct {
+	Date            string            `json:"date"`
+	Balances        map[string]string `json:"balances"`
+	Events          []ForecastEvent   `json:"events,omitempty"`
+	BelowThreshold  []string          `json:"below_threshold,omitempty"`
+	OverCreditLimit []string          `json:"over_credit_limit,omitempty"`
+}
+
+// ForecastAlert marks the date a monitored account drops below the threshold
+// or, for a credit account, starts owing more than the credit limit
+type ForecastAlert struct {
+	Date        string `json:"date"`
+	Reason      string `json:"reason"`
+	AccountGUID string `json:"account_guid"`
+	AccountName string `json:"account_name"`
+	Balance     string `json:"balance"`
+}
+
+// ForecastResponse represents a day-by-day balance projection
+type ForecastResponse struct {
+	StartDate     string            `json:"start_date"`
+	EndDate       string            `json:"end_date"`
+	NumDays       int               `json:"num_days"`
+	Threshold     string            `json:"threshold"`
+	CreditLimit   string            `json:"credit_limit,omitempty"`
+	HistoryMonths int               `json:"history_months,omitempty"`
+	Accounts      []ForecastAccount `json:"accounts"`
+	Days          []ForecastDay     `json:"days"`
+	Alerts        []ForecastAlert   `json:"alerts"`
+	Warnings      []string          `json:"warnings,omitempty"`
+}
+
+// BalanceSheetNode is an account of the balance sheet with its descendants.
+// NativeBalance is the account's own balance in its commodity, Balance the
+// same converted into the report currency, and SubtreeBalance adds the
+// converted balances of every descendant. Balances are positive in the
+// section's normal direction.
+type BalanceSheetNode struct {
+	AccountGUID    string             `json:"account_guid"`
+	AccountName    string             `json:"account_name"`
+	AccountType    string             `json:"account_type"`
+	Code           string             `json:"code,omitempty"`
+	Commodity      string             `json:"commodity"`
+	Hidden         bool               `json:"hidden,omitempty"`
+	Placeholder    bool               `json:"placeholder,omitempty"`
+	NativeBalance  string             `json:"native_balance"`
+	Balance        string             `json:"balance"`
+	SubtreeBalance string             `json:"subtree_balance"`
+	PriceMissing   bool               `json:"price_missing"`
+	Children       []BalanceSheetNode `json:"children,omitempty"`
+}
+
+// BalanceSheetSection is one side of the balance sheet as an account tree
+type BalanceSheetSection struct {
+	Accounts []BalanceSheetNode `json:"accounts"`
+	Total    string             `json:"total"`
+}
+
+// BalanceSheetResponse represents a balance sheet as of a date. TotalEquity
+// adds the retained earnings (income minus expense to date) and the
+// unrealized gains of investment holdings (market value at as_of prices less
+// cost basis) to the equity accounts. Imbalance is what is left between
+// assets and liabilities plus equity, for example from balances without a
+// price; it is included in TotalLiabilitiesAndEquity.
+type BalanceSheetResponse struct {
+	AsOf                      string              `json:"as_of"`
+	CurrencyMnemonic          string              `json:"currency_mnemonic,omitempty"`
+	Assets                    BalanceSheetSection `json:"assets"`
+	Liabilities               BalanceSheetSection `json:"liabilities"`
+	Equity                    BalanceSheetSection `json:"equity"`
+	RetainedEarnings          string              `json:"retained_earnings"`
+	UnrealizedGains           string              `json:"unrealized_gains"`
+	TotalEquity               string              `json:"total_equity"`
+	Imbalance                 string              `json:"imbalance"`
+	TotalLiabilitiesAndEquity string              `json:"total_liabilities_and_equity"`
+	MissingPrices             int                 `json:"missing_prices"`
+}
+
+// IncomeStatementPeriod is one column of the income statement
+type IncomeStatementPeriod struct {
+	Label     string `json:"label"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+// IncomeStatementNode is an income or expense account with its descendants.
+// Amounts hold the account's own signed amount per period and Subtotals add
+// every descendant; income is positive when earned and expense when spent,
+// so refunds reduce them.
+type IncomeStatementNode struct {
+	AccountGUID   string                `json:"account_guid"`
+	AccountName   string                `json:"account_name"`
+	AccountType   string                `json:"account_type"`
+	Code          string                `json:"code,omitempty"`
+	Hidden        bool                  `json:"hidden,omitempty"`
+	Placeholder   bool                  `json:"placeholder,omitempty"`
+	Amounts       []string              `json:"amounts"`
+	Total         string                `json:"total"`
+	Subtotals     []string              `json:"subtotals"`
+	SubtotalTotal string                `json:"subtotal_total"`
+	PriceMissing  bool                  `json:"price_missing"`
+	Children      []IncomeStatementNode `json:"children,omitempty"`
+}
+
+// IncomeStatementSection is the income or expense tree with its totals per period
+type IncomeStatementSection struct {
+	Accounts []IncomeStatementNode `json:"accounts"`
+	Totals   []string              `json:"totals"`
+	Total    string                `json:"total"`
+}
+
+// IncomeStatementResponse represents a profit and loss report. Each amount
+// list has one entry per period.
+type IncomeStatementResponse struct {
+	StartDate        string                  `json:"start_date"`
+	EndDate          string                  `json:"end_date"`
+	Period           string                  `json:"period"`
+	CurrencyMnemonic string                  `json:"currency_mnemonic,omitempty"`
+	Periods          []IncomeStatementPeriod `json:"periods"`
+	Income           IncomeStatementSection  `json:"income"`
+	Expense          IncomeStatementSection  `json:"expense"`
+	NetIncome        []string                `json:"net_income"`
+	TotalNetIncome   string                  `json:"total_net_income"`
+	MissingPrices    int                     `json:"missing_prices"`
+}
+
+// TrialBalanceRow holds an account's debit and credit totals in one
+// transaction currency. The net of the two is shown in BalanceDebit or
+// BalanceCredit. Amounts are exact, not rounded.
+type TrialBalanceRow struct {
+	AccountGUID   string `json:"account_guid"`
+	AccountName   string `json:"account_name"`
+	AccountType   string `json:"account_type"`
+	Currency      string `json:"currency"`
+	Debit         string `json:"debit"`
+	Credit        string `json:"credit"`
+	BalanceDebit  string `json:"balance_debit,omitempty"`
+	BalanceCredit string `json:"balance_credit,omitempty"`
+	SplitCount    int    `json:"split_count"`
+}
+
+// TrialBalanceTotal holds the totals of one transaction currency. Balanced
+// reports whether total debits equal total credits.
+type TrialBalanceTotal struct {
+	Currency      string `json:"currency"`
+	Debit         string `json:"debit"`
+	Credit        string `json:"credit"`
+	BalanceDebit  string `json:"balance_debit"`
+	BalanceCredit string `json:"balance_credit"`
+	Balanced      bool   `json:"balanced"`
+}
+
+// TrialBalanceResponse represents a trial balance for a period. Without a
+// start date it covers all transactions up to the end date.
+type TrialBalanceResponse struct {
+	StartDate string              `json:"start_date,omitempty"`
+	EndDate   string              `json:"end_date"`
+	Rows      []TrialBalanceRow   `json:"rows"`
+	Totals    []TrialBalanceTotal `json:"totals"`
+	Balanced  bool                `json:"balanced"`
+}
+
+// JournalSplit is one line of a general journal entry. Debit and Credit are
+// the split value in the transaction currency; Quantity is given when the
+// account's commodity differs.
+type JournalSplit struct {
+	AccountGUID    string `json:"account_guid"`
+	AccountName    string `json:"account_name"`
+	Memo           string `json:"memo,omitempty"`
+	Action         string `json:"action,omitempty"`
+	Debit          string `json:"debit,omitempty"`
+	Credit         string `json:"credit,omitempty"`
+	Quantity       string `json:"quantity,omitempty"`
+	ReconcileState string `json:"reconcile_state"`
+}
+
+// JournalEntry is a transaction of the general journal
+type JournalEntry struct {
+	TransactionGUID string         `json:"transaction_guid"`
+	PostDate        string         `json:"post_date"`
+	Num             string         `json:"num,omitempty"`
+	Description     string         `json:"description,omitempty"`
+	Currency        string         `json:"currency"`
+	Voided          bool           `json:"voided,omitempty"`
+	Splits          []JournalSplit `json:"splits"`
+	Debit           string         `json:"debit"`
+	Credit          string         `json:"credit"`
+}
+
+// GeneralJournalResponse represents a page of the general journal in date order
+type GeneralJournalResponse struct {
+	StartDate string         `json:"start_date,omitempty"`
+	EndDate   string         `json:"end_date,omitempty"`
+	Entries   []JournalEntry `json:"entries"`
+	Total     int64          `json:"total"`
+	Limit     int            `json:"limit"`
+	Offset    int            `json:"offset"`
+}
+
+// CashFlowLine is the cash that moved against one counter-account, positive
+// when cash came in
+type CashFlowLine struct {
+	AccountGUID string `json:"account_guid"`
+	AccountName string `json:"account_name"`
+	AccountType string `json:"account_type"`
+	Amount      string `json:"amount"`
+}
+
+// CashFlowSection holds the counter-accounts classified as operating,
+// investing or financing with their inflows, outflows and net
+type CashFlowSection struct {
+	Lines    []CashFlowLine `json:"lines"`
+	Inflows  string         `json:"inflows"`
+	Outflows string         `json:"outflows"`
+	Net      string         `json:"net"`
+}
+
+// CashFlowAccount is a BANK or CASH account's opening and closing balance in
+// its own commodity
+type CashFlowAccount struct {
+	AccountGUID    string `json:"account_guid"`
+	AccountName    string `json:"account_name"`
+	AccountType    string `json:"account_type"`
+	Commodity      string `json:"commodity,omitempty"`
+	OpeningBalance string `json:"opening_balance"`
+	ClosingBalance string `json:"closing_balance"`
+	PriceMissing   bool   `json:"price_missing,omitempty"`
+}
+
+// CashFlowStatementResponse represents a statement of cash flows. Opening
+// cash plus the three sections and the exchange rate effect gives the net
+// change, and Reconciled reports whether that matches the closing cash
+// summed independently from the ledger.
+type CashFlowStatementResponse struct {
+	StartDate          string            `json:"start_date"`
+	EndDate            string            `json:"end_date"`
+	CurrencyMnemonic   string            `json:"currency_mnemonic,omitempty"`
+	CashAccounts       []CashFlowAccount `json:"cash_accounts"`
+	OpeningCash        string            `json:"opening_cash"`
+	Operating          CashFlowSection   `json:"operating"`
+	Investing          CashFlowSection   `json:"investing"`
+	Financing          CashFlowSection   `json:"financing"`
+	ExchangeRateEffect string            `json:"exchange_rate_effect"`
+	NetChange          string            `json:"net_change"`
+	ClosingCash        string            `json:"closing_cash"`
+	Reconciled         bool              `json:"reconciled"`
+	MissingPrices      int               `json:"missing_prices"`
 }
@@ -0,0 +1,121 @@
+package dto
+
+// RecurrenceResponse represents a GnuCash recurrence in API responses
+type RecurrenceResponse struct {
+	Mult          int    `json:"mult"`
+	PeriodType    string `json:"period_type"`
+	PeriodStart   string `json:"period_start"`
+	WeekendAdjust string `json:"weekend_adjust"`
+}
+
+// BudgetResponse represents a budget in API responses
+type BudgetResponse struct {
+	GUID        string              `json:"guid"`
+	Name        string              `json:"name"`
+	Description *string             `json:"description,omitempty"`
+	NumPeriods  int                 `json:"num_periods"`
+	Recurrence  *RecurrenceResponse `json:"recurrence,omitempty"`
+	StartDate   string              `json:"start_date,omitempty"`
+	EndDate     string              `json:"end_date,omitempty"`
+}
+
+// BudgetPeriodResponse represents the date range of one budget period
+type BudgetPeriodResponse struct {
+	Period    int    `json:"period"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+// BudgetReportCell represents budgeted and actual amounts for one period
+type BudgetReportCell struct {
+	Period     int    `json:"period"`
+	Budgeted   string `json:"budgeted"`
+	Actual     string `json:"actual"`
+	Variance   string `json:"variance"`
+	OverBudget bool   `json:"over_budget"`
+}
+
+// BudgetReportRow represents an account in a budget report. Amounts include
+// all descendant accounts and are positive in the account's natural direction.
+type BudgetReportRow struct {
+	AccountGUID string             `json:"account_guid"`
+	AccountName string             `json:"account_name"`
+	AccountType string             `json:"account_type"`
+	ParentGUID  *string            `json:"parent_guid,omitempty"`
+	HasBudget   bool               `json:"has_budget"`
+	Periods     []BudgetReportCell `json:"periods"`
+	Budgeted    string             `json:"budgeted"`
+	Actual      string             `json:"actual"`
+	Variance    string             `json:"variance"`
+	OverBudget  bool               `json:"over_budget"`
+}
+
+// BudgetReportResponse represents a budget-vs-actual report
+type BudgetReportResponse struct {
+	Budget        BudgetResponse         `json:"budget"`
+	Periods       []BudgetPeriodResponse `json:"periods"`
+	CurrentPeriod *int                   `json:"current_period,omitempty"`
+	Accounts      []BudgetReportRow      `json:"accounts"`
+}
+
+// RecurrenceRequest represents a budget period layout: every Mult periods of
+// PeriodType (month, week, day, year, end of month, nth weekday, last weekday)
+// starting on PeriodStart
+type RecurrenceRequest struct {
+	Mult        int    `json:"mult"`
+	PeriodType  string `json:"period_type"`
+	PeriodStart string `json:"period_start"`
+}
+
+// CreateBudgetRequest represents a request to create a budget. Without a
+// recurrence the budget is monthly starting on January 1st of the current year.
+type CreateBudgetRequest struct {
+	Name        string             `json:"name" binding:"required"`
+	Description *string            `json:"description,omitempty"`
+	NumPeriods  int                `json:"num_periods"`
+	Recurrence  *RecurrenceRequest `json:"recurrence,omitempty"`
+}
+
+// CreateBudgetFromActualsRequest represents a request to create a budget
+// whose amounts are the actuals of the same periods one year earlier
+type CreateBudgetFromActualsRequest struct {
+	CreateBudgetRequest
+	AccountTypes []string `json:"account_types,omitempty"`
+}
+
+// UpdateBudgetRequest represents a partial update of a budget; nil fields are left unchanged
+type UpdateBudgetRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	NumPeriods  *int    `json:"num_periods,omitempty"`
+}
+
+// BudgetAmountRequest sets one account's amount for one period. Amounts are
+// positive in the account's natural direction; a null amount clears the entry.
+type BudgetAmountRequest struct {
+	AccountGUID string  `json:"account_guid" binding:"required"`
+	Period      int     `json:"period"`
+	Amount      *string `json:"amount"`
+}
+
+// SetBudgetAmountsRequest represents a batch of budget amount changes
+type SetBudgetAmountsRequest struct {
+	Amounts []BudgetAmountRequest `json:"amounts" binding:"required,min=1,dive"`
+}
+
+// FillBudgetAmountsRequest sets the same amount for an account over a range
+// of periods (all periods by default)
+type FillBudgetAmountsRequest struct {
+	AccountGUID string `json:"account_guid" binding:"required"`
+	Amount      string `json:"amount" binding:"required"`
+	StartPeriod *int   `json:"start_period,omitempty"`
+	EndPeriod   *int   `json:"end_period,omitempty"`
+}
+
+// BudgetAmountResponse represents a budget amount in API responses
+type BudgetAmountResponse struct {
+	AccountGUID string `json:"account_guid"`
+	AccountName string `json:"account_name"`
+	Period      int    `json:"period"`
+	Amount      string `json:"amount"`
+}
@@ -0,0 +1,266 @@
+package dto
+
+import "time"
+
+// ImportPreviewRequest holds the form fields sent with a statement file.
+// CounterAccountGUID is proposed for every entry; StatementAccountID picks
+// one statement from a file that holds several.
+type ImportPreviewRequest struct {
+	AccountGUID        string `form:"account_guid" binding:"required"`
+	CounterAccountGUID string `form:"counter_account_guid"`
+	StatementAccountID string `form:"statement_account_id"`
+}
+
+// QIFPreviewRequest holds the form fields sent with a QIF file.
+// StatementAccountID picks the !Account block to import; DateFormat is mdy
+// (Quicken's default) or dmy. CategoryMap is a JSON object from QIF category
+// to account GUID. With CreateMissing, categories that match no account are
+// proposed as new EXPENSE or INCOME accounts.
+type QIFPreviewRequest struct {
+	AccountGUID        string `form:"account_guid" binding:"required"`
+	CounterAccountGUID string `form:"counter_account_guid"`
+	StatementAccountID string `form:"statement_account_id"`
+	DateFormat         string `form:"date_format"`
+	CategoryMap        string `form:"category_map"`
+	CreateMissing      bool   `form:"create_missing"`
+}
+
+// ImportEntryResponse is one statement line proposed as a transaction: two
+// splits against CounterAccountGUID (or the proposed CounterNewAccount), or
+// the imported split plus Splits. Amount is signed for the imported account
+// (positive for money in); a duplicate names the existing transaction it
+// matches. CounterpartyIBAN, when the bank reports it, picks the counter
+// account of earlier imports from the same party; a matching import rule
+// takes precedence and is named in MatchedRuleID and MatchedRuleName.
+// OnlineID is the bank's identifier of the line or, without one, a synthetic
+// id to send back on commit. BankDescription keeps the statement text when a
+// rule rewrote Description, so later imports can still recognise the line.
+type ImportEntryResponse struct {
+	Line                   int                   `json:"line"`
+	Status                 string                `json:"status"`
+	OnlineID               string                `json:"online_id,omitempty"`
+	PostDate               string                `json:"post_date"`
+	ValueDate              string                `json:"value_date,omitempty"`
+	Amount                 string                `json:"amount"`
+	Description            string                `json:"description"`
+	BankDescription        string                `json:"bank_description,omitempty"`
+	Memo                   string                `json:"memo,omitempty"`
+	Num                    string                `json:"num,omitempty"`
+	Type                   string                `json:"type,omitempty"`
+	CounterpartyIBAN       string                `json:"counterparty_iban,omitempty"`
+	Category               string                `json:"category,omitempty"`
+	CounterAccountGUID     string                `json:"counter_account_guid,omitempty"`
+	CounterNewAccount      string                `json:"counter_new_account,omitempty"`
+	Splits                 []ImportSplitResponse `json:"splits,omitempty"`
+	MatchedTransactionGUID string                `json:"matched_transaction_guid,omitempty"`
+	MatchedRuleID          *int64                `json:"matched_rule_id,omitempty"`
+	MatchedRuleName        string                `json:"matched_rule_name,omitempty"`
+}
+
+// ImportSplitResponse is one category leg of an entry. Amount is signed like
+// the entry's; Quantity is the change in shares of a security leg.
+type ImportSplitResponse struct {
+	Category    string `json:"category"`
+	Transfer    bool   `json:"transfer,omitempty"`
+	AccountGUID string `json:"account_guid,omitempty"`
+	NewAccount  string `json:"new_account,omitempty"`
+	Amount      string `json:"amount"`
+	Quantity    string `json:"quantity,omitempty"`
+	Memo        string `json:"memo,omitempty"`
+}
+
+// ImportNewAccount is an account to create for an import, named by its full
+// path such as Expenses:Food:Groceries. Missing parents are created with the
+// same type.
+type ImportNewAccount struct {
+	FullName string `json:"full_name" binding:"required"`
+	Type     string `json:"type" binding:"required"`
+}
+
+// ImportPreviewResponse lists what committing a statement would create
+type ImportPreviewResponse struct {
+	Format             string                `json:"format"`
+	AccountGUID        string                `json:"account_guid"`
+	AccountName        string                `json:"account_name"`
+	Currency           string                `json:"currency,omitempty"`
+	StatementAccountID string                `json:"statement_account_id,omitempty"`
+	StartDate          string                `json:"start_date,omitempty"`
+	EndDate            string                `json:"end_date,omitempty"`
+	LedgerBalance      string                `json:"ledger_balance,omitempty"`
+	NewCount           int                   `json:"new_count"`
+	DuplicateCount     int                   `json:"duplicate_count"`
+	Entries            []ImportEntryResponse `json:"entries"`
+	NewAccounts        []ImportNewAccount    `json:"new_accounts,omitempty"`
+	Errors             []ImportRowError      `json:"errors,omitempty"`
+	Warnings           []string              `json:"warnings,omitempty"`
+}
+
+// ImportRowError reports a file row that could not be read
+type ImportRowError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// ImportCommitEntry is one reviewed entry to create. An entry with Splits
+// books them against the imported split; otherwise the whole amount goes to
+// CounterAccountGUID, CounterNewAccount or the request's counter account.
+// BankDescription is the preview's statement text of a rewritten entry.
+type ImportCommitEntry struct {
+	OnlineID           string              `json:"online_id,omitempty"`
+	PostDate           string              `json:"post_date" binding:"required"`
+	Amount             string              `json:"amount" binding:"required"`
+	Description        string              `json:"description"`
+	BankDescription    string              `json:"bank_description,omitempty"`
+	Memo               string              `json:"memo,omitempty"`
+	Num                string              `json:"num,omitempty"`
+	CounterpartyIBAN   string              `json:"counterparty_iban,omitempty"`
+	CounterAccountGUID string              `json:"counter_account_guid,omitempty"`
+	CounterNewAccount  string              `json:"counter_new_account,omitempty"`
+	Splits             []ImportCommitSplit `json:"splits,omitempty" binding:"dive"`
+}
+
+// ImportCommitSplit is one category leg of a committed entry, booked against
+// an existing account or one of the request's new accounts
+type ImportCommitSplit struct {
+	AccountGUID string `json:"account_guid,omitempty"`
+	NewAccount  string `json:"new_account,omitempty"`
+	Amount      string `json:"amount" binding:"required"`
+	Quantity    string `json:"quantity,omitempty"`
+	Memo        string `json:"memo,omitempty"`
+}
+
+// ImportCommitRequest represents a request to create reviewed import entries.
+// NewAccounts are created, in the imported account's commodity, before the
+// entries that use them.
+type ImportCommitRequest struct {
+	AccountGUID        string              `json:"account_guid" binding:"required"`
+	CounterAccountGUID string              `json:"counter_account_guid,omitempty"`
+	NewAccounts        []ImportNewAccount  `json:"new_accounts,omitempty" binding:"dive"`
+	Entries            []ImportCommitEntry `json:"entries" binding:"required,min=1,dive"`
+}
+
+// ImportedEntryResponse links a committed entry to the transaction created for it
+type ImportedEntryResponse struct {
+	Line            int    `json:"line"`
+	OnlineID        string `json:"online_id,omitempty"`
+	TransactionGUID string `json:"transaction_guid"`
+}
+
+// ImportCommitResponse reports the transactions created. Entries whose
+// online id was already imported are skipped.
+type ImportCommitResponse struct {
+	Created []ImportedEntryResponse `json:"created"`
+	Skipped []string                `json:"skipped_online_ids"`
+}
+
+// CSVPreviewRequest holds the form fields sent with a CSV file. AccountGUID
+// and CounterAccountGUID override the profile's.
+type CSVPreviewRequest struct {
+	ProfileID          int64  `form:"profile_id" binding:"required"`
+	AccountGUID        string `form:"account_guid"`
+	CounterAccountGUID string `form:"counter_account_guid"`
+}
+
+// CSVProfileRequest represents a request to create or replace a CSV mapping
+// profile. Columns are header names, or 1-based column numbers when
+// has_header is false. DateFormat uses YYYY, YY, MMM, MM, M, DD and D.
+type CSVProfileRequest struct {
+	Name               string  `json:"name" binding:"required"`
+	AccountGUID        string  `json:"account_guid" binding:"required"`
+	CounterAccountGUID *string `json:"counter_account_guid,omitempty"`
+	Delimiter          string  `json:"delimiter,omitempty"`
+	HasHeader          *bool   `json:"has_header,omitempty"`
+	SkipRows           int     `json:"skip_rows,omitempty"`
+	DateColumn         string  `json:"date_column" binding:"required"`
+	DateFormat         string  `json:"date_format,omitempty"`
+	AmountColumn       string  `json:"amount_column,omitempty"`
+	DebitColumn        string  `json:"debit_column,omitempty"`
+	CreditColumn       string  `json:"credit_column,omitempty"`
+	SignConvention     string  `json:"sign_convention,omitempty"`
+	DecimalSeparator   string  `json:"decimal_separator,omitempty"`
+	DescriptionColumn  string  `json:"description_column,omitempty"`
+	MemoColumn         string  `json:"memo_column,omitempty"`
+}
+
+// CSVProfileResponse represents a CSV mapping profile in API responses
+type CSVProfileResponse struct {
+	ID                 int64     `json:"id"`
+	Name               string    `json:"name"`
+	AccountGUID        string    `json:"account_guid"`
+	CounterAccountGUID *string   `json:"counter_account_guid,omitempty"`
+	Delimiter          string    `json:"delimiter"`
+	HasHeader          bool      `json:"has_header"`
+	SkipRows           int       `json:"skip_rows"`
+	DateColumn         string    `json:"date_column"`
+	DateFormat         string    `json:"date_format"`
+	AmountColumn       string    `json:"amount_column,omitempty"`
+	DebitColumn        string    `json:"debit_column,omitempty"`
+	CreditColumn       string    `json:"credit_column,omitempty"`
+	SignConvention     string    `json:"sign_convention"`
+	DecimalSeparator   string    `json:"decimal_separator"`
+	DescriptionColumn  string    `json:"description_column,omitempty"`
+	MemoColumn         string    `json:"memo_column,omitempty"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// ImportRuleRequest represents a request to create or replace an import rule.
+// Patterns are case-insensitive regular expressions; amounts are signed like
+// statement lines (negative for money out). Counterparty is an IBAN or a
+// counterparty name. At least one condition and one action must be set.
+type ImportRuleRequest struct {
+	Name                   string  `json:"name" binding:"required"`
+	Priority               int     `json:"priority"`
+	Enabled                *bool   `json:"enabled,omitempty"`
+	DescriptionPattern     string  `json:"description_pattern,omitempty"`
+	MemoPattern            string  `json:"memo_pattern,omitempty"`
+	MinAmount              *string `json:"min_amount,omitempty"`
+	MaxAmount              *string `json:"max_amount,omitempty"`
+	SourceAccountGUID      *string `json:"source_account_guid,omitempty"`
+	Counterparty           string  `json:"counterparty,omitempty"`
+	DestinationAccountGUID *string `json:"destination_account_guid,omitempty"`
+	PayeeRewrite           *string `json:"payee_rewrite,omitempty"`
+	Memo                   *string `json:"memo,omitempty"`
+}
+
+// ImportRuleResponse represents an import rule in API responses
+type ImportRuleResponse struct {
+	ID                     int64     `json:"id"`
+	Name                   string    `json:"name"`
+	Priority               int       `json:"priority"`
+	Enabled                bool      `json:"enabled"`
+	DescriptionPattern     string    `json:"description_pattern,omitempty"`
+	MemoPattern            string    `json:"memo_pattern,omitempty"`
+	MinAmount              *string   `json:"min_amount,omitempty"`
+	MaxAmount              *string   `json:"max_amount,omitempty"`
+	SourceAccountGUID      *string   `json:"source_account_guid,omitempty"`
+	Counterparty           string    `json:"counterparty,omitempty"`
+	DestinationAccountGUID *string   `json:"destination_account_guid,omitempty"`
+	PayeeRewrite           *string   `json:"payee_rewrite,omitempty"`
+	Memo                   *string   `json:"memo,omitempty"`
+	CreatedAt              time.Time `json:"created_at"`
+	UpdatedAt              time.Time `json:"updated_at"`
+}
+
+// RuleSuggestionRequest holds the query parameters for learning rules from
+// history. AccountGUID limits the history to one source account; Months
+// defaults to 12 and MinCount to 3.
+type RuleSuggestionRequest struct {
+	AccountGUID string `form:"account_guid"`
+	Months      int    `form:"months" binding:"omitempty,min=1,max=120"`
+	MinCount    int    `form:"min_count" binding:"omitempty,min=2"`
+}
+
+// RuleSuggestionResponse is a rule proposed from past transactions whose
+// descriptions start with the same words. MatchCount of TotalCount such
+// transactions were booked against the destination account.
+type RuleSuggestionResponse struct {
+	Name                   string   `json:"name"`
+	DescriptionPattern     string   `json:"description_pattern"`
+	SourceAccountGUID      *string  `json:"source_account_guid,omitempty"`
+	DestinationAccountGUID string   `json:"destination_account_guid"`
+	DestinationAccountName string   `json:"destination_account_name"`
+	MatchCount             int      `json:"match_count"`
+	TotalCount             int      `json:"total_count"`
+	Examples               []string `json:"examples"`
+}
@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+// PriceResponse represents a price database entry in API responses
+type PriceResponse struct {
+	GUID          string    `json:"guid"`
+	CommodityGUID string    `json:"commodity_guid"`
+	Commodity     string    `json:"commodity"`
+	CurrencyGUID  string    `json:"currency_guid"`
+	Currency      string    `json:"currency"`
+	Date          time.Time `json:"date"`
+	Source        string    `json:"source"`
+	Type          string    `json:"type"`
+	Value         string    `json:"value"`
+	ValueNum      int64     `json:"value_num"`
+	ValueDenom    int64     `json:"value_denom"`
+}
+
+// PriceListResponse represents a price history
+type PriceListResponse struct {
+	Prices []PriceResponse `json:"prices"`
+	Count  int             `json:"count"`
+}
+
+// CreatePriceRequest represents a request to add a price to the price database.
+// Commodity and Currency accept a GUID or a mnemonic such as AAPL or USD.
+type CreatePriceRequest struct {
+	Commodity string `json:"commodity" binding:"required"`
+	Currency  string `json:"currency" binding:"required"`
+	Date      string `json:"date,omitempty"`
+	Value     string `json:"value" binding:"required"`
+	Source    string `json:"source,omitempty"`
+	Type      string `json:"type,omitempty"`
+}
@@ -0,0 +1,60 @@
+package dto
+
+// ReconcileInfoResponse represents an account's reconciliation state.
+// Balances are positive in the account's natural direction.
+type ReconcileInfoResponse struct {
+	AccountGUID       string                   `json:"account_guid"`
+	AccountName       string                   `json:"account_name"`
+	LastReconcileDate string                   `json:"last_reconcile_date,omitempty"`
+	ReconciledBalance string                   `json:"reconciled_balance"`
+	InProgress        *ReconcileStatementBrief `json:"in_progress,omitempty"`
+}
+
+// ReconcileStatementBrief identifies the statement of a reconciliation in progress
+type ReconcileStatementBrief struct {
+	StatementDate string `json:"statement_date"`
+	EndingBalance string `json:"ending_balance"`
+}
+
+// StartReconciliationRequest represents a request to reconcile an account
+// against a statement. EndingBalance is positive in the account's natural
+// direction (e.g. the amount owed on a credit card statement).
+type StartReconciliationRequest struct {
+	StatementDate string `json:"statement_date" binding:"required"`
+	EndingBalance string `json:"ending_balance" binding:"required"`
+}
+
+// ToggleReconcileSplitsRequest represents a request to mark splits as cleared
+// (or back to new when Cleared is false)
+type ToggleReconcileSplitsRequest struct {
+	SplitGUIDs []string `json:"split_guids" binding:"required,min=1"`
+	Cleared    bool     `json:"cleared"`
+}
+
+// ReconcileSplitResponse represents an unreconciled split in a reconciliation.
+// RunningClearedBalance is the cleared balance after this split.
+type ReconcileSplitResponse struct {
+	SplitGUID             string  `json:"split_guid"`
+	TxGUID                string  `json:"tx_guid"`
+	PostDate              string  `json:"post_date"`
+	Num                   *string `json:"num,omitempty"`
+	Description           *string `json:"description,omitempty"`
+	Memo                  *string `json:"memo,omitempty"`
+	Amount                string  `json:"amount"`
+	Cleared               bool    `json:"cleared"`
+	RunningClearedBalance string  `json:"running_cleared_balance"`
+}
+
+// ReconcileSessionResponse represents a reconciliation in progress. The
+// session can be finished once Difference (ending minus cleared balance) is zero.
+type ReconcileSessionResponse struct {
+	AccountGUID     string                   `json:"account_guid"`
+	AccountName     string                   `json:"account_name"`
+	StatementDate   string                   `json:"statement_date"`
+	EndingBalance   string                   `json:"ending_balance"`
+	StartingBalance string                   `json:"starting_balance"`
+	ClearedBalance  string                   `json:"cleared_balance"`
+	Difference      string                   `json:"difference"`
+	Balanced        bool                     `json:"balanced"`
+	Splits          []ReconcileSplitResponse `json:"splits"`
+}
@@ -0,0 +1,91 @@
+package dto
+
+// ScheduledSplitResponse represents one split of a scheduled transaction's
+// template. Value is the evaluated amount (positive for debits, negative for
+// credits); Error explains why a formula could not be evaluated.
+type ScheduledSplitResponse struct {
+	AccountGUID   string  `json:"account_guid"`
+	AccountName   string  `json:"account_name,omitempty"`
+	Memo          *string `json:"memo,omitempty"`
+	Action        *string `json:"action,omitempty"`
+	DebitFormula  string  `json:"debit_formula,omitempty"`
+	CreditFormula string  `json:"credit_formula,omitempty"`
+	Value         string  `json:"value,omitempty"`
+	Error         string  `json:"error,omitempty"`
+}
+
+// ScheduledTemplateResponse represents a template transaction copied on every occurrence
+type ScheduledTemplateResponse struct {
+	GUID         string                   `json:"guid"`
+	CurrencyGUID string                   `json:"currency_guid"`
+	Num          *string                  `json:"num,omitempty"`
+	Description  *string                  `json:"description,omitempty"`
+	Splits       []ScheduledSplitResponse `json:"splits"`
+}
+
+// ScheduledTransactionResponse represents a scheduled transaction in API responses.
+// RemainingOccurrences is only meaningful when NumOccurrences is non-zero.
+type ScheduledTransactionResponse struct {
+	GUID                 string                      `json:"guid"`
+	Name                 *string                     `json:"name,omitempty"`
+	Enabled              bool                        `json:"enabled"`
+	StartDate            string                      `json:"start_date,omitempty"`
+	EndDate              string                      `json:"end_date,omitempty"`
+	LastOccurrence       string                      `json:"last_occurrence,omitempty"`
+	NextOccurrence       string                      `json:"next_occurrence,omitempty"`
+	NumOccurrences       int                         `json:"num_occurrences"`
+	RemainingOccurrences int                         `json:"remaining_occurrences"`
+	InstanceCount        int                         `json:"instance_count"`
+	AutoCreate           bool                        `json:"auto_create"`
+	AutoNotify           bool                        `json:"auto_notify"`
+	AdvanceCreateDays    int                         `json:"advance_create_days"`
+	AdvanceNotifyDays    int                         `json:"advance_notify_days"`
+	Recurrences          []RecurrenceResponse        `json:"recurrences"`
+	Templates            []ScheduledTemplateResponse `json:"templates"`
+}
+
+// ScheduledOccurrenceResponse represents one upcoming occurrence of a
+// scheduled transaction. Due occurrences would be posted by post-due today.
+type ScheduledOccurrenceResponse struct {
+	ScheduledTransactionGUID string `json:"scheduled_transaction_guid"`
+	Name                     string `json:"name"`
+	Date                     string `json:"date"`
+	Due                      bool   `json:"due"`
+	AutoCreate               bool   `json:"auto_create"`
+}
+
+// UpcomingScheduledResponse lists occurrences up to a date, oldest first
+type UpcomingScheduledResponse struct {
+	Until       string                        `json:"until"`
+	Occurrences []ScheduledOccurrenceResponse `json:"occurrences"`
+}
+
+// PostDueScheduledRequest represents a request to post due occurrences.
+// AsOf defaults to today.
+type PostDueScheduledRequest struct {
+	AsOf string `json:"as_of,omitempty"`
+}
+
+// PostedInstanceResponse represents the transactions created for one occurrence
+type PostedInstanceResponse struct {
+	ScheduledTransactionGUID string   `json:"scheduled_transaction_guid"`
+	Name                     string   `json:"name"`
+	Date                     string   `json:"date"`
+	TransactionGUIDs         []string `json:"transaction_guids"`
+}
+
+// FailedInstanceResponse represents an occurrence that could not be posted.
+// Later occurrences of the same scheduled transaction are not attempted.
+type FailedInstanceResponse struct {
+	ScheduledTransactionGUID string `json:"scheduled_transaction_guid"`
+	Name                     string `json:"name"`
+	Date                     string `json:"date"`
+	Error                    string `json:"error"`
+}
+
+// PostDueScheduledResponse reports the outcome of posting due occurrences
+type PostDueScheduledResponse struct {
+	AsOf   string                   `json:"as_of"`
+	Posted []PostedInstanceResponse `json:"posted"`
+	Failed []FailedInstanceResponse `json:"failed"`
+}
@@ -7,6 +7,7 @@ type SplitResponse struct {
 	GUID           string         `json:"guid"`
 	TxGUID         string         `json:"tx_guid"`
 	AccountGUID    string         `json:"account_guid"`
+	LotGUID        *string        `json:"lot_guid,omitempty"`
 	Memo           *string        `json:"memo,omitempty"`
 	Action         *string        `json:"action,omitempty"`
 	ReconcileState string         `json:"reconcile_state"`
@@ -36,6 +37,11 @@ type TransactionResponse struct {
 	EnterDate        time.Time       `json:"enter_date"`
 	Description      *string         `json:"description,omitempty"`
 	Splits           []SplitResponse `json:"splits"`
+	Version          string          `json:"version"`
+	Voided           bool            `json:"voided"`
+	VoidReason       *string         `json:"void_reason,omitempty"`
+	ReversedBy       *string         `json:"reversed_by,omitempty"`
+	ReversalOf       *string         `json:"reversal_of,omitempty"`
 }
 
 // TransactionListResponse represents a paginated list of transactions
@@ -45,3 +51,85 @@ type TransactionListResponse struct {
 	Limit        int                   `json:"limit"`
 	Offset       int                   `json:"offset"`
 }
+
+// SplitRequest represents one split of a transaction being written.
+// Value is in the transaction currency and is positive for debits, negative for credits.
+// Quantity is in the account's commodity and defaults to Value when the account
+// is denominated in the transaction currency. GUID is only used on updates to
+// keep an existing split (and its reconcile state).
+type SplitRequest struct {
+	GUID        string  `json:"guid,omitempty"`
+	AccountGUID string  `json:"account_guid" binding:"required"`
+	Value       string  `json:"value" binding:"required"`
+	Quantity    string  `json:"quantity,omitempty"`
+	Memo        *string `json:"memo,omitempty"`
+	Action      *string `json:"action,omitempty"`
+}
+
+// CreateTransactionRequest represents a request to create a transaction
+type CreateTransactionRequest struct {
+	CurrencyGUID string         `json:"currency_guid,omitempty"`
+	Num          *string        `json:"num,omitempty"`
+	PostDate     string         `json:"post_date" binding:"required"`
+	Description  *string        `json:"description,omitempty"`
+	Splits       []SplitRequest `json:"splits" binding:"required,min=2,dive"`
+}
+
+// UpdateTransactionRequest represents a request to replace a transaction.
+// Version may be given here or in the If-Match header.
+type UpdateTransactionRequest struct {
+	CreateTransactionRequest
+	Version string `json:"version,omitempty"`
+}
+
+// VoidTransactionRequest represents a request to void a transaction
+type VoidTransactionRequest struct {
+	Reason  string `json:"reason" binding:"required"`
+	Version string `json:"version,omitempty"`
+}
+
+// ReverseTransactionRequest represents a request to reverse a transaction
+type ReverseTransactionRequest struct {
+	PostDate string `json:"post_date,omitempty"`
+}
+
+// BulkRecategorizeRequest selects the splits of one account with the same
+// filters as the transaction list and moves them to another account.
+// Amounts bound the absolute split amount in the source account.
+type BulkRecategorizeRequest struct {
+	AccountGUID       string `json:"account_guid" binding:"required"`
+	TargetAccountGUID string `json:"target_account_guid" binding:"required"`
+	Description       string `json:"description,omitempty"`
+	StartDate         string `json:"start_date,omitempty"`
+	EndDate           string `json:"end_date,omitempty"`
+	MinAmount         string `json:"min_amount,omitempty"`
+	MaxAmount         string `json:"max_amount,omitempty"`
+	IncludeReconciled bool   `json:"include_reconciled"`
+	DryRun            bool   `json:"dry_run"`
+}
+
+// RecategorizedTransaction is a transaction whose splits a bulk
+// re-categorization moves
+type RecategorizedTransaction struct {
+	GUID        string    `json:"guid"`
+	PostDate    time.Time `json:"post_date"`
+	Description *string   `json:"description,omitempty"`
+	SplitGUIDs  []string  `json:"split_guids"`
+	Amount      string    `json:"amount"`
+}
+
+// BulkRecategorizeResponse reports what a bulk re-categorization moved, or
+// would move in a dry run
+type BulkRecategorizeResponse struct {
+	SourceGUID        string                     `json:"source_guid"`
+	SourceName        string                     `json:"source_name"`
+	TargetGUID        string                     `json:"target_guid"`
+	TargetName        string                     `json:"target_name"`
+	SplitCount        int                        `json:"split_count"`
+	TransactionCount  int                        `json:"transaction_count"`
+	Total             string                     `json:"total"`
+	SkippedReconciled int                        `json:"skipped_reconciled"`
+	SkippedVoided     int                        `json:"skipped_voided"`
+	DryRun            bool                       `json:"dry_run"`
+	Transactions      []RecategorizedTransaction `json:"transactions"`
+}
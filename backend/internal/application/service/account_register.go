@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
+)
+
+// Register page sizes
+const (
+	defaultRegisterLimit = 100
+	maxRegisterLimit     = 1000
+)
+
+// splitTransactionLabel is shown as the counter-account of a transaction with
+// more than one other split, as in GnuCash's basic ledger
+const splitTransactionLabel = "-- Split Transaction --"
+
+// GetRegister returns a page of an account's register: its splits oldest
+// first with the counter-account, the amount in the account's commodity and
+// the running balance. Pages follow the opaque cursor of the previous page,
+// and each page's opening balance is summed from the splits before it, so
+// balances stay correct when earlier pages change between requests.
+func (s *AccountService) GetRegister(ctx context.Context, guid, cursor string, limit int) (*dto.AccountRegisterResponse, error) {
+	switch {
+	case limit <= 0:
+		limit = defaultRegisterLimit
+	case limit > maxRegisterLimit:
+		return nil, validationError("limit must not exceed %d", maxRegisterLimit)
+	}
+
+	var after *entity.RegisterPosition
+	if cursor != "" {
+		position, err := decodeRegisterCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		after = &position
+	}
+
+	accounts, err := s.accountRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+	var account *entity.Account
+	for _, acc := range accounts {
+		if acc.GUID == guid {
+			account = acc
+		}
+	}
+	if account == nil {
+		return nil, repository.ErrNotFound
+	}
+	fullNames := accountFullNames(accounts)
+
+	splits, err := s.accountRepo.FindRegister(ctx, guid, after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load register: %w", err)
+	}
+
+	var balance gnucash.RationalSum
+	if after != nil {
+		num, denom, err := s.accountRepo.GetBalanceBefore(ctx, guid, *after)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate opening balance: %w", err)
+		}
+		balance.Add(num, denom)
+	}
+
+	response := &dto.AccountRegisterResponse{
+		AccountGUID:       account.GUID,
+		AccountName:       fullNames[account.GUID],
+		CommodityMnemonic: account.CommodityMnemonic,
+		OpeningBalance:    balance.String(),
+		Rows:              []dto.AccountRegisterRow{},
+	}
+	if len(splits) > limit {
+		splits = splits[:limit]
+		response.HasMore = true
+	}
+
+	for _, split := range splits {
+		balance.Add(split.Split.QuantityNum, split.Split.QuantityDenom)
+		row := dto.AccountRegisterRow{
+			SplitGUID:       split.Split.GUID,
+			TransactionGUID: split.Split.TxGUID,
+			PostDate:        split.PostDate,
+			Num:             entity.StringOrEmpty(split.Num),
+			Description:     entity.StringOrEmpty(split.Description),
+			Memo:            entity.StringOrEmpty(split.Split.Memo),
+			Action:          entity.StringOrEmpty(split.Split.Action),
+			Amount:          gnucash.FormatExact(split.Split.QuantityNum, split.Split.QuantityDenom),
+			Value:           gnucash.FormatExact(split.Split.ValueNum, split.Split.ValueDenom),
+			ReconcileState:  split.Split.ReconcileState,
+			ReconcileDate:   split.Split.ReconcileDate,
+			Balance:         balance.String(),
+		}
+		switch {
+		case split.OtherSplitCount > 1:
+			row.CounterAccount = splitTransactionLabel
+		case split.OtherAccountGUID != nil:
+			row.CounterAccountGUID = *split.OtherAccountGUID
+			row.CounterAccount = fullNames[*split.OtherAccountGUID]
+		}
+		response.Rows = append(response.Rows, row)
+	}
+	response.ClosingBalance = balance.String()
+
+	if response.HasMore {
+		last := splits[len(splits)-1]
+		response.NextCursor = encodeRegisterCursor(entity.RegisterPosition{
+			PostDate:  last.PostDate,
+			EnterDate: last.EnterDate,
+			SplitGUID: last.Split.GUID,
+		})
+	}
+
+	return response, nil
+}
+
+// encodeRegisterCursor packs a register position into an opaque URL-safe token
+func encodeRegisterCursor(position entity.RegisterPosition) string {
+	raw := strings.Join([]string{
+		position.PostDate.UTC().Format(time.RFC3339Nano),
+		position.EnterDate.UTC().Format(time.RFC3339Nano),
+		position.SplitGUID,
+	}, "|")
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeRegisterCursor unpacks a token made by encodeRegisterCursor
+func decodeRegisterCursor(cursor string) (entity.RegisterPosition, error) {
+	invalid := validationError("invalid cursor")
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return entity.RegisterPosition{}, invalid
+	}
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 3 || parts[2] == "" {
+		return entity.RegisterPosition{}, invalid
+	}
+	postDate, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return entity.RegisterPosition{}, invalid
+	}
+	enterDate, err := time.Parse(time.RFC3339Nano, parts[1])
+	if err != nil {
+		return entity.RegisterPosition{}, invalid
+	}
+
+	return entity.RegisterPosition{PostDate: postDate, EnterDate: enterDate, SplitGUID: parts[2]}, nil
+}
@@ -0,0 +1,430 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
+)
+
+// accountSeparator is the character GnuCash uses to join account names into paths
+const accountSeparator = ":"
+
+// AccountService handles account management business logic
+type AccountService struct {
+	accountRepo   repository.AccountRepository
+	commodityRepo repository.CommodityRepository
+}
+
+// NewAccountService creates a new account service
+func NewAccountService(accountRepo repository.AccountRepository, commodityRepo repository.CommodityRepository) *AccountService {
+	return &AccountService{
+		accountRepo:   accountRepo,
+		commodityRepo: commodityRepo,
+	}
+}
+
+// CreateAccount validates and stores a new account under an existing parent
+func (s *AccountService) CreateAccount(ctx context.Context, req *dto.CreateAccountRequest) (*entity.Account, error) {
+	accountType := entity.AccountType(strings.ToUpper(req.Type))
+	if !accountType.IsValid() {
+		return nil, validationError("unknown account type %q", req.Type)
+	}
+	if accountType == entity.AccountTypeRoot {
+		return nil, validationError("the book already has a ROOT account")
+	}
+	if err := validateAccountName(req.Name); err != nil {
+		return nil, err
+	}
+
+	tree, err := s.loadTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parentGUID := req.ParentGUID
+	if parentGUID == "" {
+		parentGUID = tree.rootGUID
+	}
+	parent, err := tree.placementParent(parentGUID, accountType)
+	if err != nil {
+		return nil, err
+	}
+	if tree.hasChildNamed(parentGUID, req.Name, "") {
+		return nil, validationError("%s already has a child account named %q", parent.Name, req.Name)
+	}
+
+	commodityGUID := req.CommodityGUID
+	if commodityGUID == "" && parent.CommodityGUID != nil {
+		commodityGUID = *parent.CommodityGUID
+	}
+	if commodityGUID == "" {
+		return nil, validationError("commodity_guid is required")
+	}
+	commodity, err := s.commodityRepo.FindByGUID(ctx, commodityGUID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, validationError("commodity %s not found", commodityGUID)
+		}
+		return nil, fmt.Errorf("failed to load commodity: %w", err)
+	}
+
+	scu := req.CommoditySCU
+	if scu == 0 {
+		scu = commodity.Fraction
+	}
+	if scu <= 0 {
+		return nil, validationError("commodity_scu must be positive")
+	}
+
+	account := &entity.Account{
+		GUID:          gnucash.NewGUID(),
+		Name:          req.Name,
+		AccountType:   accountType,
+		CommodityGUID: &commodity.GUID,
+		CommoditySCU:  scu,
+		NonStdSCU:     scu != commodity.Fraction,
+		ParentGUID:    &parentGUID,
+		Code:          req.Code,
+		Description:   req.Description,
+		Hidden:        req.Hidden,
+		Placeholder:   req.Placeholder,
+	}
+
+	if err := s.accountRepo.Create(ctx, account); err != nil {
+		return nil, fmt.Errorf("failed to create account: %w", err)
+	}
+
+	return s.accountRepo.FindByGUID(ctx, account.GUID)
+}
+
+// UpdateAccount renames an account or changes its code, description, hidden or placeholder flags
+func (s *AccountService) UpdateAccount(ctx context.Context, guid string, req *dto.UpdateAccountRequest) (*entity.Account, error) {
+	tree, err := s.loadTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	account, ok := tree.accounts[guid]
+	if !ok || !tree.inBook(guid) {
+		return nil, repository.ErrNotFound
+	}
+	if guid == tree.rootGUID {
+		return nil, validationError("the root account cannot be modified")
+	}
+
+	if req.Name != nil && *req.Name != account.Name {
+		if err := validateAccountName(*req.Name); err != nil {
+			return nil, err
+		}
+		if account.ParentGUID != nil && tree.hasChildNamed(*account.ParentGUID, *req.Name, guid) {
+			return nil, validationError("a sibling account named %q already exists", *req.Name)
+		}
+		account.Name = *req.Name
+	}
+	if req.Code != nil {
+		account.Code = req.Code
+	}
+	if req.Description != nil {
+		account.Description = req.Description
+	}
+	if req.Hidden != nil {
+		account.Hidden = *req.Hidden
+	}
+	if req.Placeholder != nil {
+		account.Placeholder = *req.Placeholder
+	}
+
+	if err := s.accountRepo.Update(ctx, account); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update account: %w", err)
+	}
+
+	return s.accountRepo.FindByGUID(ctx, guid)
+}
+
+// MoveAccount reparents an account, keeping the tree acyclic and type-compatible
+func (s *AccountService) MoveAccount(ctx context.Context, guid string, parentGUID string) (*entity.Account, error) {
+	tree, err := s.loadTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	account, ok := tree.accounts[guid]
+	if !ok || !tree.inBook(guid) {
+		return nil, repository.ErrNotFound
+	}
+	if guid == tree.rootGUID {
+		return nil, validationError("the root account cannot be moved")
+	}
+	if tree.isDescendant(parentGUID, guid) {
+		return nil, validationError("an account cannot be moved under itself or one of its descendants")
+	}
+
+	parent, err := tree.placementParent(parentGUID, account.AccountType)
+	if err != nil {
+		return nil, err
+	}
+	if tree.hasChildNamed(parentGUID, account.Name, guid) {
+		return nil, validationError("%s already has a child account named %q", parent.Name, account.Name)
+	}
+
+	account.ParentGUID = &parentGUID
+	if err := s.accountRepo.Update(ctx, account); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to move account: %w", err)
+	}
+
+	return s.accountRepo.FindByGUID(ctx, guid)
+}
+
+// DeleteAccount removes a leaf account. Accounts that still hold splits can
+// only be deleted when reassignTo names an account to move them to.
+func (s *AccountService) DeleteAccount(ctx context.Context, guid string, reassignTo string) error {
+	tree, err := s.loadTree(ctx)
+	if err != nil {
+		return err
+	}
+
+	account, ok := tree.accounts[guid]
+	if !ok || !tree.inBook(guid) {
+		return repository.ErrNotFound
+	}
+	if guid == tree.rootGUID {
+		return validationError("the root account cannot be deleted")
+	}
+	if len(tree.children[guid]) > 0 {
+		return validationError("account %s has child accounts; move or delete them first", account.Name)
+	}
+
+	splitCount, err := s.accountRepo.CountSplits(ctx, guid)
+	if err != nil {
+		return err
+	}
+
+	var target *string
+	if splitCount > 0 {
+		if reassignTo == "" {
+			return validationError("account %s has %d splits; provide an account to reassign them to", account.Name, splitCount)
+		}
+		if err := tree.validateSplitDestination(account, reassignTo); err != nil {
+			return err
+		}
+		target = &reassignTo
+	}
+
+	if err := s.accountRepo.Delete(ctx, guid, target); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to delete account: %w", err)
+	}
+
+	return nil
+}
+
+// MergeAccounts moves every split and child account from sourceGUID into the
+// target account, then deletes the source (or hides it when HideSource is set).
+// With DryRun nothing is written and the response reports what would move.
+func (s *AccountService) MergeAccounts(ctx context.Context, sourceGUID string, req *dto.MergeAccountsRequest) (*dto.MergeAccountsResponse, error) {
+	tree, err := s.loadTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	source, ok := tree.accounts[sourceGUID]
+	if !ok || !tree.inBook(sourceGUID) {
+		return nil, repository.ErrNotFound
+	}
+	if sourceGUID == tree.rootGUID {
+		return nil, validationError("the root account cannot be merged")
+	}
+	target, ok := tree.accounts[req.TargetGUID]
+	if !ok || !tree.inBook(req.TargetGUID) {
+		return nil, validationError("target account %s not found", req.TargetGUID)
+	}
+	if tree.isDescendant(target.GUID, source.GUID) {
+		return nil, validationError("target account must not be the source or one of its descendants")
+	}
+	if source.CommodityGUID == nil || target.CommodityGUID == nil || *source.CommodityGUID != *target.CommodityGUID {
+		return nil, validationError("accounts %s and %s use different commodities", source.Name, target.Name)
+	}
+
+	children := tree.children[sourceGUID]
+	for _, child := range children {
+		if !child.AccountType.CanHaveParent(target.AccountType) {
+			return nil, validationError("child account %s (%s) cannot be placed under a %s account", child.Name, child.AccountType, target.AccountType)
+		}
+		if tree.hasChildNamed(target.GUID, child.Name, "") {
+			return nil, validationError("%s already has a child account named %q", target.Name, child.Name)
+		}
+	}
+
+	splitCount, err := s.accountRepo.CountSplits(ctx, sourceGUID)
+	if err != nil {
+		return nil, err
+	}
+	if splitCount > 0 {
+		if err := tree.validateSplitDestination(source, target.GUID); err != nil {
+			return nil, err
+		}
+	}
+	txCount, err := s.accountRepo.CountTransactions(ctx, sourceGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.MergeAccountsResponse{
+		SourceGUID:        source.GUID,
+		SourceName:        source.Name,
+		TargetGUID:        target.GUID,
+		TargetName:        target.Name,
+		SplitCount:        splitCount,
+		TransactionCount:  txCount,
+		ChildAccountCount: len(children),
+		SourceAction:      "deleted",
+		DryRun:            req.DryRun,
+	}
+	if req.HideSource {
+		response.SourceAction = "hidden"
+	}
+
+	if req.DryRun {
+		return response, nil
+	}
+
+	if err := s.accountRepo.Merge(ctx, sourceGUID, target.GUID, req.HideSource); err != nil {
+		return nil, fmt.Errorf("failed to merge accounts: %w", err)
+	}
+
+	return response, nil
+}
+
+// loadTree loads every account of the book into an accountTree
+func (s *AccountService) loadTree(ctx context.Context) (*accountTree, error) {
+	rootGUID, err := s.accountRepo.FindRootGUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find root account: %w", err)
+	}
+
+	accounts, err := s.accountRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+
+	return newAccountTree(rootGUID, accounts), nil
+}
+
+// validateAccountName checks the naming rules GnuCash enforces for account names
+func validateAccountName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return validationError("account name must not be empty")
+	}
+	if strings.Contains(name, accountSeparator) {
+		return validationError("account name must not contain %q", accountSeparator)
+	}
+	return nil
+}
+
+// accountTree is an in-memory index of the book's accounts used to check
+// structural invariants before writing
+type accountTree struct {
+	rootGUID string
+	accounts map[string]*entity.Account
+	children map[string][]*entity.Account
+}
+
+// newAccountTree indexes accounts by GUID and by parent
+func newAccountTree(rootGUID string, accounts []*entity.Account) *accountTree {
+	tree := &accountTree{
+		rootGUID: rootGUID,
+		accounts: make(map[string]*entity.Account, len(accounts)),
+		children: make(map[string][]*entity.Account),
+	}
+	for _, acc := range accounts {
+		tree.accounts[acc.GUID] = acc
+		if acc.ParentGUID != nil {
+			tree.children[*acc.ParentGUID] = append(tree.children[*acc.ParentGUID], acc)
+		}
+	}
+	return tree
+}
+
+// inBook reports whether the account is the book root or one of its descendants,
+// which excludes the scheduled-transaction template tree
+func (t *accountTree) inBook(guid string) bool {
+	return t.isDescendant(guid, t.rootGUID)
+}
+
+// isDescendant reports whether guid equals ancestorGUID or lies beneath it
+func (t *accountTree) isDescendant(guid, ancestorGUID string) bool {
+	for steps := 0; steps <= len(t.accounts); steps++ {
+		if guid == ancestorGUID {
+			return true
+		}
+		acc, ok := t.accounts[guid]
+		if !ok || acc.ParentGUID == nil {
+			return false
+		}
+		guid = *acc.ParentGUID
+	}
+	return false
+}
+
+// sortedChildren returns the children of an account ordered by name
+func (t *accountTree) sortedChildren(parentGUID string) []*entity.Account {
+	children := append([]*entity.Account(nil), t.children[parentGUID]...)
+	sort.Slice(children, func(i, j int) bool { return children[i].Name < children[j].Name })
+	return children
+}
+
+// hasChildNamed reports whether parentGUID has a child with the given name,
+// ignoring the account identified by exceptGUID
+func (t *accountTree) hasChildNamed(parentGUID, name, exceptGUID string) bool {
+	for _, child := range t.children[parentGUID] {
+		if child.GUID != exceptGUID && child.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// placementParent returns the parent account if an account of the given type may be placed under it
+func (t *accountTree) placementParent(parentGUID string, accountType entity.AccountType) (*entity.Account, error) {
+	parent, ok := t.accounts[parentGUID]
+	if !ok || !t.inBook(parentGUID) {
+		return nil, validationError("parent account %s not found", parentGUID)
+	}
+	if !accountType.CanHaveParent(parent.AccountType) {
+		return nil, validationError("a %s account cannot be placed under a %s account", accountType, parent.AccountType)
+	}
+	return parent, nil
+}
+
+// validateSplitDestination checks that splits from source may be moved to targetGUID
+func (t *accountTree) validateSplitDestination(source *entity.Account, targetGUID string) error {
+	target, ok := t.accounts[targetGUID]
+	if !ok || !t.inBook(targetGUID) {
+		return validationError("destination account %s not found", targetGUID)
+	}
+	if target.GUID == source.GUID {
+		return validationError("destination account must differ from the source account")
+	}
+	if target.AccountType == entity.AccountTypeRoot || target.Placeholder {
+		return validationError("destination account %s does not accept postings", target.Name)
+	}
+	if source.CommodityGUID == nil || target.CommodityGUID == nil || *source.CommodityGUID != *target.CommodityGUID {
+		return validationError("accounts %s and %s use different commodities", source.Name, target.Name)
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
+)
+
+// balanceSheetSection lists the account types reported in a section and
+// whether they are positive when debited
+type balanceSheetSection struct {
+	types map[entity.AccountType]bool
+	debit bool
+}
+
+var (
+	assetSection = balanceSheetSection{
+		types: map[entity.AccountType]bool{
+			entity.AccountTypeBank: true, entity.AccountTypeCash: true, entity.AccountTypeAsset: true,
+			entity.AccountTypeStock: true, entity.AccountTypeMutual: true, entity.AccountTypeReceivable: true,
+			entity.AccountTypeCurrency: true,
+		},
+		debit: true,
+	}
+	liabilitySection = balanceSheetSection{
+		types: map[entity.AccountType]bool{
+			entity.AccountTypeLiability: true, entity.AccountTypeCredit: true, entity.AccountTypePayable: true,
+		},
+	}
+	// GnuCash reports trading accounts with equity
+	equitySection = balanceSheetSection{
+		types: map[entity.AccountType]bool{
+			entity.AccountTypeEquity: true, entity.AccountTypeTrading: true,
+		},
+	}
+)
+
+// GetBalanceSheet reports assets, liabilities and equity as of a date as
+// account trees, converting balances into the report currency (the ROOT
+// account's currency if currencyRef is empty). An account whose type belongs
+// to another section still appears when it has descendants of this one.
+// Hidden accounts and accounts whose subtree balance is zero are left out
+// unless requested; their balances still count towards their parents.
+// Unrealized gains are the market value less the average cost basis of the
+// STOCK and MUTUAL holdings; whatever else keeps the sheet from balancing,
+// such as balances without a price, is reported as the imbalance.
+func (s *AnalyticsService) GetBalanceSheet(ctx context.Context, currencyRef string, asOf time.Time, includeHidden, includeZero bool) (*dto.BalanceSheetResponse, error) {
+	currency, err := s.resolveReportCurrency(ctx, currencyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := endOfDay(asOf)
+	prices, err := s.priceRepo.FindAllAsOf(ctx, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load prices: %w", err)
+	}
+
+	rootGUID, err := s.accountRepo.FindRootGUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find root account: %w", err)
+	}
+	accounts, err := s.accountRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+
+	sums, err := s.transactionRepo.SumQuantityByAccount(ctx, nil, &cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum balances: %w", err)
+	}
+	balances := make(map[string]*repository.AccountAggregate, len(sums))
+	for _, agg := range sums {
+		balances[agg.AccountGUID] = agg
+	}
+
+	b := &balanceSheetBuilder{
+		tree:          newAccountTree(rootGUID, accounts),
+		balances:      balances,
+		currencyGUID:  currency.GUID,
+		rates:         newRateTable(prices),
+		includeHidden: includeHidden,
+		includeZero:   includeZero,
+	}
+
+	assets, totalAssets := b.section(assetSection)
+	liabilities, totalLiabilities := b.section(liabilitySection)
+	equity, totalEquityAccounts := b.section(equitySection)
+
+	// Income and expense to date become retained earnings, positive when
+	// income exceeds expense
+	retained := decimal.Zero
+	for _, acc := range accounts {
+		if (acc.AccountType == entity.AccountTypeIncome || acc.AccountType == entity.AccountTypeExpense) && b.tree.inBook(acc.GUID) {
+			_, converted, _ := b.balance(acc, false)
+			retained = retained.Add(converted)
+		}
+	}
+
+	unrealized, err := s.unrealizedGains(ctx, b, accounts, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	totalEquity := totalEquityAccounts.Add(retained).Add(unrealized)
+	imbalance := totalAssets.Sub(totalLiabilities).Sub(totalEquity)
+
+	return &dto.BalanceSheetResponse{
+		AsOf:                      asOf.Format("2006-01-02"),
+		CurrencyMnemonic:          currency.Mnemonic,
+		Assets:                    dto.BalanceSheetSection{Accounts: assets, Total: totalAssets.StringFixed(2)},
+		Liabilities:               dto.BalanceSheetSection{Accounts: liabilities, Total: totalLiabilities.StringFixed(2)},
+		Equity:                    dto.BalanceSheetSection{Accounts: equity, Total: totalEquityAccounts.StringFixed(2)},
+		RetainedEarnings:          retained.StringFixed(2),
+		UnrealizedGains:           unrealized.StringFixed(2),
+		TotalEquity:               totalEquity.StringFixed(2),
+		Imbalance:                 imbalance.StringFixed(2),
+		TotalLiabilitiesAndEquity: totalLiabilities.Add(totalEquity).Add(imbalance).StringFixed(2),
+		MissingPrices:             b.missingPrices,
+	}, nil
+}
+
+// unrealizedGains sums the market value less the average cost basis of the
+// book's STOCK and MUTUAL accounts. Holdings without a price, or with cost in
+// a currency that cannot be converted, are left out.
+func (s *AnalyticsService) unrealizedGains(ctx context.Context, b *balanceSheetBuilder, accounts []*entity.Account, cutoff time.Time) (decimal.Decimal, error) {
+	gains := decimal.Zero
+	for _, acc := range accounts {
+		if acc.AccountType != entity.AccountTypeStock && acc.AccountType != entity.AccountTypeMutual {
+			continue
+		}
+		if acc.CommodityGUID == nil || !b.tree.inBook(acc.GUID) {
+			continue
+		}
+		price, ok := b.rates.Rate(*acc.CommodityGUID, b.currencyGUID)
+		if !ok {
+			continue
+		}
+
+		shares, cost, complete, err := s.averageCostPosition(ctx, acc.GUID, cutoff, b.currencyGUID, b.rates)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		if !complete {
+			continue
+		}
+		gains = gains.Add(shares.Mul(price.Rate).Sub(cost))
+	}
+	return gains, nil
+}
+
+// balanceSheetBuilder turns per-account balances into section trees
+type balanceSheetBuilder struct {
+	tree          *accountTree
+	balances      map[string]*repository.AccountAggregate
+	currencyGUID  string
+	rates         *rateTable
+	includeHidden bool
+	includeZero   bool
+	missingPrices int
+}
+
+// section builds the trees below the root for one section and their total
+func (b *balanceSheetBuilder) section(section balanceSheetSection) ([]dto.BalanceSheetNode, decimal.Decimal) {
+	nodes := []dto.BalanceSheetNode{}
+	total := decimal.Zero
+	for _, acc := range b.tree.sortedChildren(b.tree.rootGUID) {
+		node, subtree, ok := b.node(acc, section)
+		if !ok {
+			continue
+		}
+		total = total.Add(subtree)
+		if b.visible(acc, &node, subtree) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes, total
+}
+
+// node builds the node of an account for a section. It reports false when
+// neither the account nor any descendant belongs to the section.
+func (b *balanceSheetBuilder) node(acc *entity.Account, section balanceSheetSection) (dto.BalanceSheetNode, decimal.Decimal, bool) {
+	node := dto.BalanceSheetNode{
+		AccountGUID: acc.GUID,
+		AccountName: acc.Name,
+		AccountType: string(acc.AccountType),
+		Code:        entity.StringOrEmpty(acc.Code),
+		Commodity:   acc.CommodityMnemonic,
+		Hidden:      acc.Hidden,
+		Placeholder: acc.Placeholder,
+	}
+
+	own := section.types[acc.AccountType]
+	native, subtree := decimal.Zero, decimal.Zero
+	if own {
+		var ok bool
+		native, subtree, ok = b.balance(acc, section.debit)
+		node.PriceMissing = !ok
+	}
+	node.NativeBalance = native.String()
+	node.Balance = subtree.StringFixed(2)
+
+	relevant := own
+	for _, child := range b.tree.sortedChildren(acc.GUID) {
+		childNode, childTotal, ok := b.node(child, section)
+		if !ok {
+			continue
+		}
+		relevant = true
+		subtree = subtree.Add(childTotal)
+		if b.visible(child, &childNode, childTotal) {
+			node.Children = append(node.Children, childNode)
+		}
+	}
+	node.SubtreeBalance = subtree.StringFixed(2)
+
+	return node, subtree, relevant
+}
+
+// balance returns an account's signed balance in its commodity and in the
+// report currency. It reports false, counting a missing price, when a
+// non-zero balance cannot be converted.
+func (b *balanceSheetBuilder) balance(acc *entity.Account, debit bool) (decimal.Decimal, decimal.Decimal, bool) {
+	sum, ok := b.balances[acc.GUID]
+	if !ok {
+		return decimal.Zero, decimal.Zero, true
+	}
+	native := gnucash.RationalToDecimal(gnucash.NormalizeSign(sum.TotalAmount, debit), sum.Denominator)
+	if native.IsZero() {
+		return native, decimal.Zero, true
+	}
+
+	if acc.CommodityGUID == nil {
+		b.missingPrices++
+		return native, decimal.Zero, false
+	}
+	rate, ok := b.rates.Rate(*acc.CommodityGUID, b.currencyGUID)
+	if !ok {
+		b.missingPrices++
+		return native, decimal.Zero, false
+	}
+	return native, native.Mul(rate.Rate), true
+}
+
+// visible reports whether a node is shown under the requested options
+func (b *balanceSheetBuilder) visible(acc *entity.Account, node *dto.BalanceSheetNode, subtree decimal.Decimal) bool {
+	if acc.Hidden && !b.includeHidden {
+		return false
+	}
+	return b.includeZero || len(node.Children) > 0 || node.PriceMissing || !subtree.Round(2).IsZero()
+}
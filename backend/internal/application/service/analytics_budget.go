@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
+)
+
+// GetBudgetReport compares budgeted and actual amounts per account for each
+// period of a budget, or for a single period if one is given. Parent accounts
+// roll up their descendants, and variance is budgeted minus actual as in the
+// GnuCash budget report.
+func (s *AnalyticsService) GetBudgetReport(ctx context.Context, budgetGUID string, period *int) (*dto.BudgetReportResponse, error) {
+	budget, err := s.budgetRepo.FindByGUID(ctx, budgetGUID)
+	if err != nil {
+		return nil, err
+	}
+	if budget.Recurrence == nil {
+		return nil, validationError("budget %s has no recurrence", budget.Name)
+	}
+
+	var periods []int
+	if period != nil {
+		if *period < 0 || *period >= budget.NumPeriods {
+			return nil, validationError("period must be between 0 and %d", budget.NumPeriods-1)
+		}
+		periods = []int{*period}
+	} else {
+		for i := 0; i < budget.NumPeriods; i++ {
+			periods = append(periods, i)
+		}
+	}
+
+	amounts, err := s.budgetRepo.FindAmounts(ctx, budgetGUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget amounts: %w", err)
+	}
+
+	accounts, err := s.accountRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	byGUID := make(map[string]*entity.Account, len(accounts))
+	for _, acc := range accounts {
+		byGUID[acc.GUID] = acc
+	}
+	names := accountFullNames(accounts)
+
+	// rollUp adds an amount to an account and each of its ancestors below the root
+	rollUp := func(totals map[string]decimal.Decimal, accountGUID string, amount decimal.Decimal) {
+		for guid := &accountGUID; guid != nil; {
+			acc, ok := byGUID[*guid]
+			if !ok || acc.AccountType == entity.AccountTypeRoot {
+				return
+			}
+			totals[acc.GUID] = totals[acc.GUID].Add(amount)
+			guid = acc.ParentGUID
+		}
+	}
+
+	// Rows cover the budgeted accounts and their ancestors
+	budgetedAccounts := make(map[string]bool)
+	budgeted := make(map[int]map[string]decimal.Decimal)
+	for _, p := range periods {
+		budgeted[p] = make(map[string]decimal.Decimal)
+	}
+	for _, a := range amounts {
+		budgetedAccounts[a.AccountGUID] = true
+		if totals, ok := budgeted[a.PeriodNum]; ok {
+			rollUp(totals, a.AccountGUID, gnucash.RationalToDecimal(a.AmountNum, a.AmountDenom))
+		}
+	}
+	included := make(map[string]bool)
+	for guid := range budgetedAccounts {
+		for g := &guid; g != nil; {
+			acc, ok := byGUID[*g]
+			if !ok || acc.AccountType == entity.AccountTypeRoot {
+				break
+			}
+			included[acc.GUID] = true
+			g = acc.ParentGUID
+		}
+	}
+
+	actual := make(map[int]map[string]decimal.Decimal)
+	for _, p := range periods {
+		start := budget.PeriodStart(p)
+		end := endOfDay(budget.PeriodEnd(p))
+		sums, err := s.transactionRepo.SumQuantityByAccount(ctx, &start, &end)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get actuals: %w", err)
+		}
+		totals := make(map[string]decimal.Decimal)
+		for _, sum := range sums {
+			rollUp(totals, sum.AccountGUID, gnucash.RationalToDecimal(sum.TotalAmount, sum.Denominator))
+		}
+		actual[p] = totals
+	}
+
+	response := &dto.BudgetReportResponse{
+		Budget:   budgetToResponse(budget),
+		Periods:  make([]dto.BudgetPeriodResponse, 0, len(periods)),
+		Accounts: []dto.BudgetReportRow{},
+	}
+
+	today := time.Now()
+	for i := 0; i < budget.NumPeriods; i++ {
+		if !today.Before(budget.PeriodStart(i)) && !today.After(endOfDay(budget.PeriodEnd(i))) {
+			current := i
+			response.CurrentPeriod = &current
+			break
+		}
+	}
+	for _, p := range periods {
+		response.Periods = append(response.Periods, dto.BudgetPeriodResponse{
+			Period:    p,
+			StartDate: budget.PeriodStart(p).Format("2006-01-02"),
+			EndDate:   budget.PeriodEnd(p).Format("2006-01-02"),
+		})
+	}
+
+	for guid := range included {
+		acc := byGUID[guid]
+		row := dto.BudgetReportRow{
+			AccountGUID: acc.GUID,
+			AccountName: names[acc.GUID],
+			AccountType: string(acc.AccountType),
+			ParentGUID:  acc.ParentGUID,
+			HasBudget:   budgetedAccounts[acc.GUID],
+			Periods:     make([]dto.BudgetReportCell, 0, len(periods)),
+		}
+
+		totalBudgeted := decimal.Zero
+		totalActual := decimal.Zero
+		for _, p := range periods {
+			b := naturalSign(acc, budgeted[p][guid])
+			a := naturalSign(acc, actual[p][guid])
+			totalBudgeted = totalBudgeted.Add(b)
+			totalActual = totalActual.Add(a)
+
+			row.Periods = append(row.Periods, dto.BudgetReportCell{
+				Period:     p,
+				Budgeted:   b.StringFixed(2),
+				Actual:     a.StringFixed(2),
+				Variance:   b.Sub(a).StringFixed(2),
+				OverBudget: isOverBudget(acc, b, a),
+			})
+		}
+
+		row.Budgeted = totalBudgeted.StringFixed(2)
+		row.Actual = totalActual.StringFixed(2)
+		row.Variance = totalBudgeted.Sub(totalActual).StringFixed(2)
+		row.OverBudget = isOverBudget(acc, totalBudgeted, totalActual)
+		response.Accounts = append(response.Accounts, row)
+	}
+
+	sort.Slice(response.Accounts, func(i, j int) bool {
+		return response.Accounts[i].AccountName < response.Accounts[j].AccountName
+	})
+
+	return response, nil
+}
+
+// naturalSign flips amounts of credit-normal accounts so that increases in
+// the account's natural direction are positive
+func naturalSign(acc *entity.Account, amount decimal.Decimal) decimal.Decimal {
+	if acc.IsDebitAccount() {
+		return amount
+	}
+	return amount.Neg()
+}
+
+// isOverBudget reports whether spending exceeded the budget; it only applies
+// to expense accounts
+func isOverBudget(acc *entity.Account, budgeted, actual decimal.Decimal) bool {
+	return acc.AccountType == entity.AccountTypeExpense && actual.GreaterThan(budgeted)
+}
@@ -0,0 +1,354 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
+)
+
+// Cash-flow statement sections
+const (
+	CashFlowOperating = "operating"
+	CashFlowInvesting = "investing"
+	CashFlowFinancing = "financing"
+)
+
+// defaultCashFlowBuckets classifies counter-accounts by type when the request
+// does not. Credit cards are operating since paying them settles spending.
+var defaultCashFlowBuckets = map[entity.AccountType]string{
+	entity.AccountTypeIncome:     CashFlowOperating,
+	entity.AccountTypeExpense:    CashFlowOperating,
+	entity.AccountTypeReceivable: CashFlowOperating,
+	entity.AccountTypePayable:    CashFlowOperating,
+	entity.AccountTypeCredit:     CashFlowOperating,
+	entity.AccountTypeTrading:    CashFlowOperating,
+	entity.AccountTypeAsset:      CashFlowInvesting,
+	entity.AccountTypeStock:      CashFlowInvesting,
+	entity.AccountTypeMutual:     CashFlowInvesting,
+	entity.AccountTypeCurrency:   CashFlowInvesting,
+	entity.AccountTypeLiability:  CashFlowFinancing,
+	entity.AccountTypeEquity:     CashFlowFinancing,
+}
+
+// GetCashFlowStatement reports the change in BANK and CASH balances between
+// startDate and endDate and where it came from. Each transaction's cash
+// movement is shared among its other splits by value, and each counter-account
+// falls into the operating, investing or financing section: accountBuckets
+// (account GUID to section) applies to an account and its descendants, and
+// typeBuckets (account type to section) overrides the defaults by type.
+// Balances are converted into the report currency (the ROOT account's
+// currency if currencyRef is empty) at the prices of the opening and closing
+// dates, and movements at closing prices; the difference is reported as the
+// exchange rate effect.
+func (s *AnalyticsService) GetCashFlowStatement(ctx context.Context, startDate, endDate time.Time, currencyRef string, typeBuckets, accountBuckets map[string]string) (*dto.CashFlowStatementResponse, error) {
+	start, end := dateOnly(startDate), dateOnly(endDate)
+	if end.Before(start) {
+		return nil, validationError("end_date must not be before start_date")
+	}
+
+	currency, err := s.resolveReportCurrency(ctx, currencyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	rootGUID, err := s.accountRepo.FindRootGUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find root account: %w", err)
+	}
+	accounts, err := s.accountRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+	tree := newAccountTree(rootGUID, accounts)
+
+	classify, err := newCashFlowClassifier(tree, typeBuckets, accountBuckets)
+	if err != nil {
+		return nil, err
+	}
+
+	openingCutoff := endOfDay(start.AddDate(0, 0, -1))
+	closingCutoff := endOfDay(end)
+	openingPrices, err := s.priceRepo.FindAllAsOf(ctx, openingCutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load prices: %w", err)
+	}
+	closingPrices, err := s.priceRepo.FindAllAsOf(ctx, closingCutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load prices: %w", err)
+	}
+	openingSums, err := s.transactionRepo.SumQuantityByAccount(ctx, nil, &openingCutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum opening balances: %w", err)
+	}
+	closingSums, err := s.transactionRepo.SumQuantityByAccount(ctx, nil, &closingCutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum closing balances: %w", err)
+	}
+	transactions, err := s.transactionRepo.FindAll(ctx, &repository.TransactionFilter{
+		StartDate:        &start,
+		EndDate:          &closingCutoff,
+		OldestFirst:      true,
+		ExcludeTemplates: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transactions: %w", err)
+	}
+
+	b := &cashFlowBuilder{
+		tree:         tree,
+		fullNames:    accountFullNames(accounts),
+		classify:     classify,
+		currencyGUID: currency.GUID,
+		openingRates: newRateTable(openingPrices),
+		closingRates: newRateTable(closingPrices),
+		cashRates:    make(map[string]decimal.Decimal),
+		flows:        make(map[string]decimal.Decimal),
+	}
+
+	response := &dto.CashFlowStatementResponse{
+		StartDate:        start.Format("2006-01-02"),
+		EndDate:          end.Format("2006-01-02"),
+		CurrencyMnemonic: currency.Mnemonic,
+		CashAccounts:     []dto.CashFlowAccount{},
+	}
+	opening, closing, fxEffect := b.cashBalances(openingSums, closingSums, response)
+
+	for _, tx := range transactions {
+		fxEffect = fxEffect.Add(b.transaction(tx))
+	}
+
+	response.Operating = b.section(CashFlowOperating)
+	response.Investing = b.section(CashFlowInvesting)
+	response.Financing = b.section(CashFlowFinancing)
+
+	net := fxEffect
+	for _, flow := range b.flows {
+		net = net.Add(flow)
+	}
+	response.OpeningCash = opening.StringFixed(2)
+	response.ExchangeRateEffect = fxEffect.StringFixed(2)
+	response.NetChange = net.StringFixed(2)
+	response.ClosingCash = closing.StringFixed(2)
+	response.Reconciled = opening.Add(net).Round(2).Equal(closing.Round(2))
+	response.MissingPrices = b.missingPrices
+
+	return response, nil
+}
+
+// newCashFlowClassifier validates the section overrides and returns a
+// function giving the section of a counter-account: the override of the
+// account or its nearest overridden ancestor, else the one of its type
+func newCashFlowClassifier(tree *accountTree, typeBuckets, accountBuckets map[string]string) (func(acc *entity.Account) string, error) {
+	validBucket := func(bucket string) bool {
+		return bucket == CashFlowOperating || bucket == CashFlowInvesting || bucket == CashFlowFinancing
+	}
+
+	byType := make(map[entity.AccountType]string, len(defaultCashFlowBuckets))
+	for accountType, bucket := range defaultCashFlowBuckets {
+		byType[accountType] = bucket
+	}
+	for name, bucket := range typeBuckets {
+		accountType := entity.AccountType(name)
+		if !accountType.IsValid() || accountType == entity.AccountTypeRoot {
+			return nil, validationError("invalid account type %q", name)
+		}
+		if isCashAccountType(accountType) {
+			return nil, validationError("%s accounts hold the cash and cannot be classified", name)
+		}
+		if !validBucket(bucket) {
+			return nil, validationError("section for %s must be operating, investing or financing", name)
+		}
+		byType[accountType] = bucket
+	}
+	for guid, bucket := range accountBuckets {
+		acc, ok := tree.accounts[guid]
+		if !ok || !tree.inBook(guid) {
+			return nil, validationError("account %s not found", guid)
+		}
+		if isCashAccountType(acc.AccountType) {
+			return nil, validationError("account %s holds cash and cannot be classified", guid)
+		}
+		if !validBucket(bucket) {
+			return nil, validationError("section for account %s must be operating, investing or financing", guid)
+		}
+	}
+
+	return func(acc *entity.Account) string {
+		for current := acc; current != nil; {
+			if bucket, ok := accountBuckets[current.GUID]; ok {
+				return bucket
+			}
+			if current.ParentGUID == nil {
+				break
+			}
+			current = tree.accounts[*current.ParentGUID]
+		}
+		if bucket, ok := byType[acc.AccountType]; ok {
+			return bucket
+		}
+		return CashFlowOperating
+	}, nil
+}
+
+// isCashAccountType reports whether accounts of a type count as cash
+func isCashAccountType(accountType entity.AccountType) bool {
+	return accountType == entity.AccountTypeBank || accountType == entity.AccountTypeCash
+}
+
+// cashFlowBuilder accumulates a cash-flow statement
+type cashFlowBuilder struct {
+	tree          *accountTree
+	fullNames     map[string]string
+	classify      func(acc *entity.Account) string
+	currencyGUID  string
+	openingRates  *rateTable
+	closingRates  *rateTable
+	cashRates     map[string]decimal.Decimal
+	flows         map[string]decimal.Decimal
+	missingPrices int
+}
+
+// cashBalances lists the cash accounts with their native balances and
+// returns the opening and closing cash in the report currency together with
+// the effect of the change in prices on the opening balances. Hidden accounts
+// are listed only with a balance. Cash accounts whose commodity has no
+// closing price are left out of every total.
+func (b *cashFlowBuilder) cashBalances(openingSums, closingSums []*repository.AccountAggregate, response *dto.CashFlowStatementResponse) (decimal.Decimal, decimal.Decimal, decimal.Decimal) {
+	native := func(sums []*repository.AccountAggregate) map[string]decimal.Decimal {
+		balances := make(map[string]decimal.Decimal, len(sums))
+		for _, agg := range sums {
+			balances[agg.AccountGUID] = gnucash.RationalToDecimal(agg.TotalAmount, agg.Denominator)
+		}
+		return balances
+	}
+	openingNative, closingNative := native(openingSums), native(closingSums)
+
+	opening, closing, fxEffect := decimal.Zero, decimal.Zero, decimal.Zero
+	for guid, acc := range b.tree.accounts {
+		if !isCashAccountType(acc.AccountType) || !b.tree.inBook(guid) {
+			continue
+		}
+		openingBalance, closingBalance := openingNative[guid], closingNative[guid]
+		listed := !acc.Hidden || !openingBalance.IsZero() || !closingBalance.IsZero()
+
+		line := dto.CashFlowAccount{
+			AccountGUID:    guid,
+			AccountName:    b.fullNames[guid],
+			AccountType:    string(acc.AccountType),
+			Commodity:      acc.CommodityMnemonic,
+			OpeningBalance: openingBalance.String(),
+			ClosingBalance: closingBalance.String(),
+		}
+
+		closingRate, ok := b.rate(b.closingRates, acc)
+		if !ok {
+			line.PriceMissing = true
+			b.missingPrices++
+			response.CashAccounts = append(response.CashAccounts, line)
+			continue
+		}
+		if listed {
+			response.CashAccounts = append(response.CashAccounts, line)
+		}
+		b.cashRates[guid] = closingRate
+		openingRate, ok := b.rate(b.openingRates, acc)
+		if !ok {
+			openingRate = closingRate
+		}
+
+		opening = opening.Add(openingBalance.Mul(openingRate))
+		closing = closing.Add(closingBalance.Mul(closingRate))
+		fxEffect = fxEffect.Add(openingBalance.Mul(closingRate.Sub(openingRate)))
+	}
+
+	sort.Slice(response.CashAccounts, func(i, j int) bool {
+		return response.CashAccounts[i].AccountName < response.CashAccounts[j].AccountName
+	})
+	return opening, closing, fxEffect
+}
+
+// rate returns the rate from an account's commodity into the report currency
+func (b *cashFlowBuilder) rate(rates *rateTable, acc *entity.Account) (decimal.Decimal, bool) {
+	if acc.CommodityGUID == nil {
+		return decimal.Zero, false
+	}
+	rate, ok := rates.Rate(*acc.CommodityGUID, b.currencyGUID)
+	if !ok {
+		return decimal.Zero, false
+	}
+	return rate.Rate, true
+}
+
+// transaction shares a transaction's cash movement among its other splits in
+// proportion to their values. It returns the movement that cannot be shared,
+// such as the gain or loss of a transfer between cash accounts in different
+// currencies, to be reported as exchange rate effect.
+func (b *cashFlowBuilder) transaction(tx *entity.Transaction) decimal.Decimal {
+	movement := decimal.Zero
+	touchesCash := false
+	var others []*entity.Split
+	othersValue := decimal.Zero
+	for _, split := range tx.Splits {
+		acc, ok := b.tree.accounts[split.AccountGUID]
+		if !ok {
+			continue
+		}
+		if isCashAccountType(acc.AccountType) {
+			touchesCash = true
+			if rate, ok := b.cashRates[acc.GUID]; ok {
+				movement = movement.Add(gnucash.RationalToDecimal(split.QuantityNum, split.QuantityDenom).Mul(rate))
+			}
+			continue
+		}
+		others = append(others, split)
+		othersValue = othersValue.Add(gnucash.RationalToDecimal(split.ValueNum, split.ValueDenom))
+	}
+	if !touchesCash || movement.IsZero() {
+		return decimal.Zero
+	}
+	if othersValue.IsZero() {
+		return movement
+	}
+
+	for _, split := range others {
+		share := gnucash.RationalToDecimal(split.ValueNum, split.ValueDenom).Div(othersValue)
+		b.flows[split.AccountGUID] = b.flows[split.AccountGUID].Add(movement.Mul(share))
+	}
+	return decimal.Zero
+}
+
+// section lists the counter-accounts of one section by full name
+func (b *cashFlowBuilder) section(bucket string) dto.CashFlowSection {
+	section := dto.CashFlowSection{Lines: []dto.CashFlowLine{}}
+	inflows, outflows := decimal.Zero, decimal.Zero
+	for guid, flow := range b.flows {
+		acc := b.tree.accounts[guid]
+		if b.classify(acc) != bucket || flow.Round(2).IsZero() {
+			continue
+		}
+		if flow.IsPositive() {
+			inflows = inflows.Add(flow)
+		} else {
+			outflows = outflows.Add(flow)
+		}
+		section.Lines = append(section.Lines, dto.CashFlowLine{
+			AccountGUID: guid,
+			AccountName: b.fullNames[guid],
+			AccountType: string(acc.AccountType),
+			Amount:      flow.StringFixed(2),
+		})
+	}
+	sort.Slice(section.Lines, func(i, j int) bool { return section.Lines[i].AccountName < section.Lines[j].AccountName })
+
+	section.Inflows = inflows.StringFixed(2)
+	section.Outflows = outflows.StringFixed(2)
+	section.Net = inflows.Add(outflows).StringFixed(2)
+	return section
+}
@@ -0,0 +1,378 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
+)
+
+// Forecast defaults and limits
+const (
+	defaultForecastDays  = 30
+	maxForecastDays      = 366
+	defaultHistoryMonths = 3
+	maxHistoryMonths     = 24
+)
+
+// Sources of forecast events
+const (
+	ForecastSourceTransaction = "transaction"
+	ForecastSourceScheduled   = "scheduled"
+)
+
+// Reasons of forecast alerts
+const (
+	ForecastAlertBelowThreshold  = "below_threshold"
+	ForecastAlertOverCreditLimit = "over_credit_limit"
+)
+
+// forecastAccountTypes are the accounts whose balances are projected
+var forecastAccountTypes = []entity.AccountType{
+	entity.AccountTypeBank,
+	entity.AccountTypeCash,
+	entity.AccountTypeCredit,
+}
+
+// forecastAccount tracks one account through the projection
+type forecastAccount struct {
+	account    *entity.Account
+	name       string
+	monitored  bool
+	starting   decimal.Decimal
+	balance    decimal.Decimal
+	daily      decimal.Decimal
+	lowest     decimal.Decimal
+	lowestDate time.Time
+	alerting   bool
+}
+
+// normalizedBalance is the balance positive in the account's normal
+// direction: money held for BANK and CASH, money owed for CREDIT
+func (fa *forecastAccount) normalizedBalance() decimal.Decimal {
+	if fa.account.IsDebitAccount() {
+		return fa.balance
+	}
+	return fa.balance.Neg()
+}
+
+// alertReason reports why the current balance needs an alert, or "": a BANK
+// account below the threshold, or a CREDIT account owing more than the
+// credit limit
+func (fa *forecastAccount) alertReason(threshold decimal.Decimal, creditLimit *decimal.Decimal) string {
+	if !fa.monitored {
+		return ""
+	}
+	if fa.account.AccountType == entity.AccountTypeCredit {
+		if fa.normalizedBalance().GreaterThan(*creditLimit) {
+			return ForecastAlertOverCreditLimit
+		}
+		return ""
+	}
+	if fa.normalizedBalance().LessThan(threshold) {
+		return ForecastAlertBelowThreshold
+	}
+	return ""
+}
+
+// GetForecast projects the end-of-day balance of every BANK, CASH and CREDIT
+// account for the next days days. It starts from today's balance, adds
+// transactions already entered with a later date and the occurrences of
+// enabled scheduled transactions (overdue occurrences land on the first day).
+// With includeHistory, each account also moves by its median monthly spending
+// over the last historyMonths full months, spread evenly over the days;
+// spending is any transaction touching an EXPENSE account that was not
+// created from a scheduled transaction. BANK accounts are flagged on the days
+// their balance is below threshold, and with a creditLimit CREDIT accounts on
+// the days they owe more than it.
+func (s *AnalyticsService) GetForecast(ctx context.Context, days int, threshold, creditLimit string, includeHistory bool, historyMonths int) (*dto.ForecastResponse, error) {
+	if days == 0 {
+		days = defaultForecastDays
+	}
+	if days < 1 || days > maxForecastDays {
+		return nil, validationError("days must be between 1 and %d", maxForecastDays)
+	}
+	if historyMonths == 0 {
+		historyMonths = defaultHistoryMonths
+	}
+	if historyMonths < 1 || historyMonths > maxHistoryMonths {
+		return nil, validationError("history_months must be between 1 and %d", maxHistoryMonths)
+	}
+
+	limit := decimal.Zero
+	if threshold != "" {
+		var err error
+		limit, err = decimal.NewFromString(threshold)
+		if err != nil {
+			return nil, validationError("invalid threshold %q", threshold)
+		}
+	}
+	var owedLimit *decimal.Decimal
+	if creditLimit != "" {
+		value, err := decimal.NewFromString(creditLimit)
+		if err != nil {
+			return nil, validationError("invalid credit_limit %q", creditLimit)
+		}
+		owedLimit = &value
+	}
+
+	allAccounts, err := s.accountRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	names := accountFullNames(allAccounts)
+
+	today := dateOnly(time.Now())
+	end := today.AddDate(0, 0, days)
+
+	var tracked []*forecastAccount
+	byGUID := make(map[string]*forecastAccount)
+	for _, accountType := range forecastAccountTypes {
+		accounts, err := s.accountRepo.FindByType(ctx, accountType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s accounts: %w", accountType, err)
+		}
+		for _, acc := range accounts {
+			if acc.Placeholder {
+				continue
+			}
+			num, denom, err := s.accountRepo.GetBalanceAsOf(ctx, acc.GUID, endOfDay(today))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get balance for %s: %w", acc.Name, err)
+			}
+			fa := &forecastAccount{
+				account:    acc,
+				name:       names[acc.GUID],
+				monitored:  acc.AccountType == entity.AccountTypeBank || (acc.AccountType == entity.AccountTypeCredit && owedLimit != nil),
+				balance:    gnucash.RationalToDecimal(num, denom),
+				lowestDate: today,
+			}
+			fa.starting = fa.balance
+			fa.lowest = fa.balance
+			tracked = append(tracked, fa)
+			byGUID[acc.GUID] = fa
+		}
+	}
+	sort.SliceStable(tracked, func(i, j int) bool { return tracked[i].name < tracked[j].name })
+
+	response := &dto.ForecastResponse{
+		StartDate: today.Format("2006-01-02"),
+		EndDate:   end.Format("2006-01-02"),
+		NumDays:   days,
+		Threshold: limit.String(),
+		Accounts:  make([]dto.ForecastAccount, 0, len(tracked)),
+		Days:      make([]dto.ForecastDay, 0, days),
+		Alerts:    []dto.ForecastAlert{},
+	}
+
+	// Known postings by forecast day (1 = tomorrow), each with its per-account amounts
+	deltas := make([]map[string]decimal.Decimal, days+1)
+	events := make([][]dto.ForecastEvent, days+1)
+	addEvent := func(date time.Time, event dto.ForecastEvent, amount decimal.Decimal) {
+		day := int(date.Sub(today).Hours() / 24)
+		if day < 1 {
+			day = 1
+		}
+		if deltas[day] == nil {
+			deltas[day] = make(map[string]decimal.Decimal)
+		}
+		deltas[day][event.AccountGUID] = deltas[day][event.AccountGUID].Add(amount)
+		event.Date = date.Format("2006-01-02")
+		event.Amount = amount.String()
+		events[day] = append(events[day], event)
+	}
+
+	tomorrow := today.AddDate(0, 0, 1)
+	endTime := endOfDay(end)
+	entered, err := s.transactionRepo.FindAll(ctx, &repository.TransactionFilter{
+		StartDate: &tomorrow,
+		EndDate:   &endTime,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get future transactions: %w", err)
+	}
+	sort.SliceStable(entered, func(i, j int) bool { return entered[i].PostDate.Before(entered[j].PostDate) })
+	for _, tx := range entered {
+		for _, split := range tx.Splits {
+			if _, ok := byGUID[split.AccountGUID]; !ok {
+				continue
+			}
+			addEvent(dateOnly(tx.PostDate), dto.ForecastEvent{
+				Source:          ForecastSourceTransaction,
+				TransactionGUID: tx.GUID,
+				Description:     entity.StringOrEmpty(tx.Description),
+				AccountGUID:     split.AccountGUID,
+			}, gnucash.RationalToDecimal(split.QuantityNum, split.QuantityDenom))
+		}
+	}
+
+	sxs, err := s.sxRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduled transactions: %w", err)
+	}
+	for _, sx := range sxs {
+		if !sx.Enabled {
+			continue
+		}
+		occurrences := sx.Occurrences(end, maxScheduledOccurrences)
+		if len(occurrences) == 0 {
+			continue
+		}
+		for _, template := range sx.Templates {
+			for _, split := range template.Splits {
+				fa, ok := byGUID[split.AccountGUID]
+				if !ok {
+					continue
+				}
+				if fa.account.CommodityGUID == nil || *fa.account.CommodityGUID != template.CurrencyGUID {
+					response.Warnings = append(response.Warnings, fmt.Sprintf(
+						"scheduled transaction %q posts to %s in a different commodity and was left out", entity.StringOrEmpty(sx.Name), fa.name))
+					continue
+				}
+				amount, err := templateSplitValue(split)
+				if err != nil {
+					response.Warnings = append(response.Warnings, fmt.Sprintf(
+						"scheduled transaction %q was left out for %s: %v", entity.StringOrEmpty(sx.Name), fa.name, err))
+					continue
+				}
+				for _, date := range occurrences {
+					addEvent(date, dto.ForecastEvent{
+						Source:                   ForecastSourceScheduled,
+						ScheduledTransactionGUID: sx.GUID,
+						Description:              entity.StringOrEmpty(template.Description),
+						AccountGUID:              split.AccountGUID,
+					}, amount)
+				}
+			}
+		}
+	}
+
+	if includeHistory {
+		response.HistoryMonths = historyMonths
+		if err := s.projectSpending(ctx, byGUID, today, historyMonths); err != nil {
+			return nil, err
+		}
+	}
+
+	if owedLimit != nil {
+		response.CreditLimit = owedLimit.String()
+	}
+
+	for _, fa := range tracked {
+		if reason := fa.alertReason(limit, owedLimit); reason != "" {
+			fa.alerting = true
+			response.Alerts = append(response.Alerts, forecastAlert(today, fa, reason))
+		}
+	}
+
+	for day := 1; day <= days; day++ {
+		date := today.AddDate(0, 0, day)
+		row := dto.ForecastDay{
+			Date:     date.Format("2006-01-02"),
+			Balances: make(map[string]string, len(tracked)),
+			Events:   events[day],
+		}
+		for _, fa := range tracked {
+			fa.balance = fa.balance.Add(deltas[day][fa.account.GUID]).Add(fa.daily)
+			row.Balances[fa.account.GUID] = fa.balance.StringFixed(2)
+
+			if fa.balance.LessThan(fa.lowest) {
+				fa.lowest = fa.balance
+				fa.lowestDate = date
+			}
+
+			reason := fa.alertReason(limit, owedLimit)
+			switch reason {
+			case ForecastAlertBelowThreshold:
+				row.BelowThreshold = append(row.BelowThreshold, fa.account.GUID)
+			case ForecastAlertOverCreditLimit:
+				row.OverCreditLimit = append(row.OverCreditLimit, fa.account.GUID)
+			}
+			if reason != "" && !fa.alerting {
+				response.Alerts = append(response.Alerts, forecastAlert(date, fa, reason))
+			}
+			fa.alerting = reason != ""
+		}
+		response.Days = append(response.Days, row)
+	}
+
+	for _, fa := range tracked {
+		item := dto.ForecastAccount{
+			AccountGUID:     fa.account.GUID,
+			AccountName:     fa.name,
+			AccountType:     string(fa.account.AccountType),
+			Commodity:       fa.account.CommodityMnemonic,
+			Monitored:       fa.monitored,
+			StartingBalance: fa.starting.StringFixed(2),
+			EndingBalance:   fa.balance.StringFixed(2),
+			LowestBalance:   fa.lowest.StringFixed(2),
+			LowestDate:      fa.lowestDate.Format("2006-01-02"),
+		}
+		if includeHistory {
+			item.ProjectedDailySpending = fa.daily.StringFixed(2)
+		}
+		response.Accounts = append(response.Accounts, item)
+	}
+
+	return response, nil
+}
+
+// projectSpending sets each account's daily drift to its median monthly
+// spending over the last months full months, spread over the year
+func (s *AnalyticsService) projectSpending(ctx context.Context, byGUID map[string]*forecastAccount, today time.Time, months int) error {
+	monthly := make(map[string][]decimal.Decimal, len(byGUID))
+	thisMonth := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= months; i++ {
+		start := thisMonth.AddDate(0, -i, 0)
+		end := endOfDay(start.AddDate(0, 1, -1))
+		aggregates, err := s.transactionRepo.SumSpendingByAccount(ctx, start, end)
+		if err != nil {
+			return fmt.Errorf("failed to get spending history: %w", err)
+		}
+		for _, agg := range aggregates {
+			if _, ok := byGUID[agg.AccountGUID]; ok {
+				monthly[agg.AccountGUID] = append(monthly[agg.AccountGUID], gnucash.RationalToDecimal(agg.TotalAmount, agg.Denominator))
+			}
+		}
+	}
+
+	perDay := decimal.NewFromInt(12).Div(decimal.NewFromInt(365))
+	for guid, fa := range byGUID {
+		totals := monthly[guid]
+		for len(totals) < months {
+			totals = append(totals, decimal.Zero)
+		}
+		fa.daily = medianDecimal(totals).Mul(perDay)
+	}
+	return nil
+}
+
+// medianDecimal returns the median of a non-empty set of values
+func medianDecimal(values []decimal.Decimal) decimal.Decimal {
+	sorted := make([]decimal.Decimal, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].LessThan(sorted[j]) })
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return sorted[mid-1].Add(sorted[mid]).Div(decimal.NewFromInt(2))
+}
+
+// forecastAlert records an account crossing its limit on date for reason
+func forecastAlert(date time.Time, fa *forecastAccount, reason string) dto.ForecastAlert {
+	return dto.ForecastAlert{
+		Date:        date.Format("2006-01-02"),
+		Reason:      reason,
+		AccountGUID: fa.account.GUID,
+		AccountName: fa.name,
+		Balance:     fa.balance.StringFixed(2),
+	}
+}
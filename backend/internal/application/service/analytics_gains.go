@@ -0,0 +1,370 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
+)
+
+// Cost basis methods for matching sales against open lots
+const (
+	CostMethodFIFO    = "fifo"
+	CostMethodLIFO    = "lifo"
+	CostMethodAverage = "average"
+)
+
+// Holding periods of a realized gain
+const (
+	GainTermShort = "short"
+	GainTermLong  = "long"
+)
+
+// openLot is the unsold part of one acquisition
+type openLot struct {
+	lotGUID  *string
+	key      string
+	acquired time.Time
+	shares   decimal.Decimal
+	cost     decimal.Decimal
+}
+
+// lotPosition tracks the open lots of one security account
+type lotPosition struct {
+	method string
+	lots   []*openLot
+	// poolCost is the total cost of all open lots under average cost
+	poolCost decimal.Decimal
+}
+
+// realization is the part of a sale matched against one lot
+type realization struct {
+	lot      *openLot
+	shares   decimal.Decimal
+	cost     decimal.Decimal
+	proceeds decimal.Decimal
+}
+
+// acquire records a purchase. Purchases assigned to the same GnuCash lot are
+// combined and keep the lot's earliest acquisition date.
+func (p *lotPosition) acquire(split *entity.Split, date time.Time, shares, cost decimal.Decimal) {
+	p.poolCost = p.poolCost.Add(cost)
+
+	if split.LotGUID != nil {
+		for _, lot := range p.lots {
+			if lot.lotGUID != nil && *lot.lotGUID == *split.LotGUID {
+				lot.shares = lot.shares.Add(shares)
+				lot.cost = lot.cost.Add(cost)
+				return
+			}
+		}
+	}
+
+	key := split.GUID
+	if split.LotGUID != nil {
+		key = *split.LotGUID
+	}
+	p.lots = append(p.lots, &openLot{
+		lotGUID:  split.LotGUID,
+		key:      key,
+		acquired: date,
+		shares:   shares,
+		cost:     cost,
+	})
+}
+
+// dispose matches a sale against the open lots. A sale assigned to a GnuCash
+// lot is taken from that lot first; the remainder follows the position's
+// method, with average cost consuming lots oldest first for holding periods.
+// Shares sold beyond the open position are returned with no lot and no cost.
+func (p *lotPosition) dispose(split *entity.Split, shares, proceeds decimal.Decimal) []realization {
+	totalShares := decimal.Zero
+	for _, lot := range p.lots {
+		totalShares = totalShares.Add(lot.shares)
+	}
+	averageCost := decimal.Zero
+	if totalShares.IsPositive() {
+		averageCost = p.poolCost.Div(totalShares)
+	}
+
+	order := make([]*openLot, 0, len(p.lots))
+	if split.LotGUID != nil {
+		for _, lot := range p.lots {
+			if lot.lotGUID != nil && *lot.lotGUID == *split.LotGUID {
+				order = append(order, lot)
+			}
+		}
+	}
+	if p.method == CostMethodLIFO {
+		for i := len(p.lots) - 1; i >= 0; i-- {
+			if !containsLot(order, p.lots[i]) {
+				order = append(order, p.lots[i])
+			}
+		}
+	} else {
+		for _, lot := range p.lots {
+			if !containsLot(order, lot) {
+				order = append(order, lot)
+			}
+		}
+	}
+
+	var results []realization
+	remaining := shares
+	for _, lot := range order {
+		if !remaining.IsPositive() {
+			break
+		}
+		if !lot.shares.IsPositive() {
+			continue
+		}
+
+		taken := decimal.Min(remaining, lot.shares)
+		lotCost := lot.cost.Mul(taken).Div(lot.shares)
+		cost := lotCost
+		if p.method == CostMethodAverage {
+			cost = averageCost.Mul(taken)
+		}
+
+		lot.shares = lot.shares.Sub(taken)
+		lot.cost = lot.cost.Sub(lotCost)
+		p.poolCost = p.poolCost.Sub(cost)
+		remaining = remaining.Sub(taken)
+
+		results = append(results, realization{
+			lot:      lot,
+			shares:   taken,
+			cost:     cost,
+			proceeds: proceeds.Mul(taken).Div(shares),
+		})
+	}
+
+	if remaining.IsPositive() {
+		results = append(results, realization{
+			shares:   remaining,
+			cost:     decimal.Zero,
+			proceeds: proceeds.Mul(remaining).Div(shares),
+		})
+	}
+
+	// Drop exhausted lots
+	open := p.lots[:0]
+	for _, lot := range p.lots {
+		if lot.shares.IsPositive() {
+			open = append(open, lot)
+		}
+	}
+	p.lots = open
+	if len(p.lots) == 0 {
+		p.poolCost = decimal.Zero
+	}
+
+	return results
+}
+
+// containsLot reports whether lots includes lot
+func containsLot(lots []*openLot, lot *openLot) bool {
+	for _, l := range lots {
+		if l == lot {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCapitalGains returns the gains realized by sales in a calendar tax year
+// across all STOCK and MUTUAL accounts, split into short-term (held one year
+// or less) and long-term holdings. Sales are matched to purchases by FIFO,
+// LIFO or average cost; splits assigned to a GnuCash lot are matched to that
+// lot first. Amounts are converted to the report currency at the price on
+// each transaction date.
+func (s *AnalyticsService) GetCapitalGains(ctx context.Context, year int, method, currencyRef string) (*dto.CapitalGainsResponse, error) {
+	method = strings.ToLower(method)
+	if method == "" {
+		method = CostMethodFIFO
+	}
+	if method != CostMethodFIFO && method != CostMethodLIFO && method != CostMethodAverage {
+		return nil, validationError("method must be one of fifo, lifo or average")
+	}
+	if year < 1900 || year > 9999 {
+		return nil, validationError("invalid tax year %d", year)
+	}
+
+	currency, err := s.resolveReportCurrency(ctx, currencyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	yearStart := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	yearEnd := endOfDay(time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC))
+
+	accounts, err := s.accountRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	names := accountFullNames(accounts)
+
+	// Rate tables are loaded lazily, one per transaction date needing conversion
+	rateTables := make(map[string]*rateTable)
+	convert := func(amount decimal.Decimal, fromGUID string, date time.Time) (decimal.Decimal, bool, error) {
+		if fromGUID == currency.GUID {
+			return amount, true, nil
+		}
+		day := date.Format("2006-01-02")
+		table, ok := rateTables[day]
+		if !ok {
+			prices, err := s.priceRepo.FindAllAsOf(ctx, endOfDay(date))
+			if err != nil {
+				return decimal.Zero, false, fmt.Errorf("failed to load prices: %w", err)
+			}
+			table = newRateTable(prices)
+			rateTables[day] = table
+		}
+		rate, ok := table.Rate(fromGUID, currency.GUID)
+		if !ok {
+			return decimal.Zero, false, nil
+		}
+		return amount.Mul(rate.Rate), true, nil
+	}
+
+	response := &dto.CapitalGainsResponse{
+		Year:             year,
+		Method:           method,
+		CurrencyMnemonic: currency.Mnemonic,
+		ShortTerm:        []dto.RealizedGainItem{},
+		LongTerm:         []dto.RealizedGainItem{},
+	}
+	var shortProceeds, shortCost, longProceeds, longCost decimal.Decimal
+
+	for _, acc := range accounts {
+		if acc.AccountType != entity.AccountTypeStock && acc.AccountType != entity.AccountTypeMutual {
+			continue
+		}
+
+		titles := make(map[string]string)
+		lots, err := s.lotRepo.FindByAccount(ctx, acc.GUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get lots: %w", err)
+		}
+		for _, lot := range lots {
+			if lot.Title != nil {
+				titles[lot.GUID] = *lot.Title
+			}
+		}
+
+		filter := &repository.TransactionFilter{
+			AccountGUID: &acc.GUID,
+			EndDate:     &yearEnd,
+		}
+		transactions, err := s.transactionRepo.FindAll(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transactions: %w", err)
+		}
+
+		position := &lotPosition{method: method}
+
+		// FindAll returns newest first
+		for i := len(transactions) - 1; i >= 0; i-- {
+			tx := transactions[i]
+			for _, split := range tx.Splits {
+				if split.AccountGUID != acc.GUID {
+					continue
+				}
+
+				shares := gnucash.RationalToDecimal(split.QuantityNum, split.QuantityDenom)
+				if shares.IsZero() {
+					continue
+				}
+				value, converted, err := convert(gnucash.RationalToDecimal(split.ValueNum, split.ValueDenom), tx.CurrencyGUID, tx.PostDate)
+				if err != nil {
+					return nil, err
+				}
+
+				if shares.IsPositive() {
+					position.acquire(split, tx.PostDate, shares, value)
+					if !converted {
+						response.Incomplete = true
+					}
+					continue
+				}
+
+				realized := position.dispose(split, shares.Neg(), value.Neg())
+				if tx.PostDate.Before(yearStart) {
+					continue
+				}
+				if !converted {
+					response.Incomplete = true
+				}
+
+				for _, r := range realized {
+					item := dto.RealizedGainItem{
+						AccountGUID: acc.GUID,
+						AccountName: names[acc.GUID],
+						Commodity:   acc.CommodityMnemonic,
+						SaleTxGUID:  tx.GUID,
+						SoldDate:    tx.PostDate.Format("2006-01-02"),
+						Shares:      r.shares.String(),
+						Proceeds:    r.proceeds.StringFixed(2),
+						CostBasis:   r.cost.StringFixed(2),
+						Gain:        r.proceeds.Sub(r.cost).StringFixed(2),
+						Term:        GainTermShort,
+					}
+
+					if r.lot == nil {
+						item.Unmatched = true
+						response.Incomplete = true
+					} else {
+						item.LotGUID = r.lot.lotGUID
+						if r.lot.lotGUID != nil {
+							item.LotTitle = titles[*r.lot.lotGUID]
+						}
+						item.AcquiredDate = r.lot.acquired.Format("2006-01-02")
+						if tx.PostDate.After(r.lot.acquired.AddDate(1, 0, 0)) {
+							item.Term = GainTermLong
+						}
+					}
+
+					if item.Term == GainTermLong {
+						response.LongTerm = append(response.LongTerm, item)
+						longProceeds = longProceeds.Add(r.proceeds)
+						longCost = longCost.Add(r.cost)
+					} else {
+						response.ShortTerm = append(response.ShortTerm, item)
+						shortProceeds = shortProceeds.Add(r.proceeds)
+						shortCost = shortCost.Add(r.cost)
+					}
+				}
+			}
+		}
+	}
+
+	for _, items := range [][]dto.RealizedGainItem{response.ShortTerm, response.LongTerm} {
+		sort.SliceStable(items, func(i, j int) bool {
+			if items[i].SoldDate != items[j].SoldDate {
+				return items[i].SoldDate < items[j].SoldDate
+			}
+			return items[i].AccountName < items[j].AccountName
+		})
+	}
+
+	response.ShortTermTotal = dto.CapitalGainsTotal{
+		Proceeds:  shortProceeds.StringFixed(2),
+		CostBasis: shortCost.StringFixed(2),
+		Gain:      shortProceeds.Sub(shortCost).StringFixed(2),
+	}
+	response.LongTermTotal = dto.CapitalGainsTotal{
+		Proceeds:  longProceeds.StringFixed(2),
+		CostBasis: longCost.StringFixed(2),
+		Gain:      longProceeds.Sub(longCost).StringFixed(2),
+	}
+	response.TotalGain = shortProceeds.Sub(shortCost).Add(longProceeds.Sub(longCost)).StringFixed(2)
+
+	return response, nil
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
+)
+
+// holdingRollup accumulates holdings under one ancestor account
+type holdingRollup struct {
+	holdings    int
+	costBasis   decimal.Decimal
+	marketValue decimal.Decimal
+	incomplete  bool
+}
+
+// GetHoldings returns shares, average cost basis, latest price, market value
+// and unrealized gain for every STOCK and MUTUAL account as of a date, with
+// rollups for each ancestor account. Amounts are in the report currency (the
+// ROOT account's currency if currencyRef is empty); cost in other currencies
+// is converted at the rate on the report date.
+func (s *AnalyticsService) GetHoldings(ctx context.Context, currencyRef string, asOf time.Time) (*dto.HoldingsResponse, error) {
+	currency, err := s.resolveReportCurrency(ctx, currencyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := endOfDay(asOf)
+	prices, err := s.priceRepo.FindAllAsOf(ctx, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load prices: %w", err)
+	}
+	rates := newRateTable(prices)
+
+	accounts, err := s.accountRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	byGUID := make(map[string]*entity.Account, len(accounts))
+	for _, acc := range accounts {
+		byGUID[acc.GUID] = acc
+	}
+	names := accountFullNames(accounts)
+
+	response := &dto.HoldingsResponse{
+		Holdings:         []dto.HoldingItem{},
+		Rollups:          []dto.HoldingRollup{},
+		CurrencyMnemonic: currency.Mnemonic,
+		AsOf:             asOf.Format("2006-01-02"),
+	}
+	rollups := make(map[string]*holdingRollup)
+	totalCost := decimal.Zero
+	totalValue := decimal.Zero
+
+	for _, acc := range accounts {
+		if acc.AccountType != entity.AccountTypeStock && acc.AccountType != entity.AccountTypeMutual {
+			continue
+		}
+		if acc.Placeholder || acc.CommodityGUID == nil {
+			continue
+		}
+
+		shares, cost, costComplete, err := s.averageCostPosition(ctx, acc.GUID, cutoff, currency.GUID, rates)
+		if err != nil {
+			return nil, err
+		}
+		if shares.IsZero() && cost.IsZero() {
+			continue
+		}
+
+		item := dto.HoldingItem{
+			AccountGUID: acc.GUID,
+			AccountName: names[acc.GUID],
+			AccountType: string(acc.AccountType),
+			Commodity:   acc.CommodityMnemonic,
+			Shares:      shares.String(),
+			CostBasis:   cost.StringFixed(2),
+		}
+
+		price, ok := rates.Rate(*acc.CommodityGUID, currency.GUID)
+		if ok {
+			marketValue := shares.Mul(price.Rate)
+			item.LatestPrice = price.Rate.Round(6).String()
+			item.PriceMethod = price.Method
+			if !price.Date.IsZero() {
+				item.PriceDate = price.Date.Format("2006-01-02")
+			}
+			item.MarketValue = marketValue.StringFixed(2)
+			item.UnrealizedGain = marketValue.Sub(cost).StringFixed(2)
+			totalValue = totalValue.Add(marketValue)
+			totalCost = totalCost.Add(cost)
+		} else {
+			item.PriceMissing = true
+		}
+		item.CostIncomplete = !costComplete
+		response.Holdings = append(response.Holdings, item)
+
+		// Roll the holding up into every ancestor below the root
+		for parentGUID := acc.ParentGUID; parentGUID != nil; {
+			parent, exists := byGUID[*parentGUID]
+			if !exists || parent.AccountType == entity.AccountTypeRoot {
+				break
+			}
+			r := rollups[parent.GUID]
+			if r == nil {
+				r = &holdingRollup{}
+				rollups[parent.GUID] = r
+			}
+			r.holdings++
+			if item.PriceMissing || item.CostIncomplete {
+				r.incomplete = true
+			}
+			if ok {
+				r.costBasis = r.costBasis.Add(cost)
+				r.marketValue = r.marketValue.Add(shares.Mul(price.Rate))
+			}
+			parentGUID = parent.ParentGUID
+		}
+	}
+
+	for guid, r := range rollups {
+		response.Rollups = append(response.Rollups, dto.HoldingRollup{
+			AccountGUID:    guid,
+			AccountName:    names[guid],
+			Holdings:       r.holdings,
+			CostBasis:      r.costBasis.StringFixed(2),
+			MarketValue:    r.marketValue.StringFixed(2),
+			UnrealizedGain: r.marketValue.Sub(r.costBasis).StringFixed(2),
+			Incomplete:     r.incomplete,
+		})
+	}
+
+	sort.Slice(response.Holdings, func(i, j int) bool {
+		return response.Holdings[i].AccountName < response.Holdings[j].AccountName
+	})
+	sort.Slice(response.Rollups, func(i, j int) bool {
+		return response.Rollups[i].AccountName < response.Rollups[j].AccountName
+	})
+
+	response.TotalCostBasis = totalCost.StringFixed(2)
+	response.TotalMarketValue = totalValue.StringFixed(2)
+	response.TotalUnrealizedGain = totalValue.Sub(totalCost).StringFixed(2)
+
+	return response, nil
+}
+
+// averageCostPosition replays an account's splits oldest first and returns the
+// share count and average cost basis in the report currency. Purchases add their
+// split value to the basis; sales remove the average cost of the shares sold.
+// The returned flag is false when a transaction currency could not be converted.
+func (s *AnalyticsService) averageCostPosition(ctx context.Context, accountGUID string, cutoff time.Time, currencyGUID string, rates *rateTable) (decimal.Decimal, decimal.Decimal, bool, error) {
+	filter := &repository.TransactionFilter{
+		AccountGUID: &accountGUID,
+		EndDate:     &cutoff,
+	}
+	transactions, err := s.transactionRepo.FindAll(ctx, filter)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, false, fmt.Errorf("failed to get transactions: %w", err)
+	}
+
+	shares := decimal.Zero
+	cost := decimal.Zero
+	complete := true
+
+	// FindAll returns newest first
+	for i := len(transactions) - 1; i >= 0; i-- {
+		tx := transactions[i]
+		rate, ok := rates.Rate(tx.CurrencyGUID, currencyGUID)
+		if !ok {
+			complete = false
+			continue
+		}
+
+		for _, split := range tx.Splits {
+			if split.AccountGUID != accountGUID {
+				continue
+			}
+
+			quantity := gnucash.RationalToDecimal(split.QuantityNum, split.QuantityDenom)
+			value := gnucash.RationalToDecimal(split.ValueNum, split.ValueDenom).Mul(rate.Rate)
+
+			switch {
+			case quantity.IsPositive():
+				shares = shares.Add(quantity)
+				cost = cost.Add(value)
+			case quantity.IsNegative():
+				if shares.IsPositive() {
+					sold := decimal.Min(quantity.Neg(), shares)
+					cost = cost.Sub(cost.Mul(sold).Div(shares))
+				}
+				shares = shares.Add(quantity)
+				if !shares.IsPositive() {
+					cost = decimal.Zero
+				}
+			}
+		}
+	}
+
+	return shares, cost, complete, nil
+}
+
+// accountFullNames maps account GUIDs to colon-separated names below the root
+func accountFullNames(accounts []*entity.Account) map[string]string {
+	byGUID := make(map[string]*entity.Account, len(accounts))
+	for _, acc := range accounts {
+		byGUID[acc.GUID] = acc
+	}
+
+	names := make(map[string]string, len(accounts))
+	var fullName func(acc *entity.Account) string
+	fullName = func(acc *entity.Account) string {
+		if name, ok := names[acc.GUID]; ok {
+			return name
+		}
+		name := acc.Name
+		if acc.ParentGUID != nil {
+			if parent, ok := byGUID[*acc.ParentGUID]; ok && parent.AccountType != entity.AccountTypeRoot {
+				name = fullName(parent) + ":" + acc.Name
+			}
+		}
+		names[acc.GUID] = name
+		return name
+	}
+
+	for _, acc := range accounts {
+		fullName(acc)
+	}
+
+	return names
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
+)
+
+// Report period lengths
+const (
+	PeriodMonth   = "month"
+	PeriodQuarter = "quarter"
+	PeriodYear    = "year"
+)
+
+// maxReportPeriods bounds the number of columns of a periodic report
+const maxReportPeriods = 120
+
+// reportPeriod is a closed date range of a periodic report
+type reportPeriod struct {
+	label string
+	start time.Time
+	end   time.Time
+}
+
+// GetIncomeStatement reports the income and expense trees with one column per
+// period between startDate and endDate. Amounts are signed so refunds reduce
+// spending, and are converted into the report currency (the ROOT account's
+// currency if currencyRef is empty) at the prices of each period's end.
+// Hidden accounts and accounts without activity are left out unless
+// requested; their amounts still count towards their parents.
+func (s *AnalyticsService) GetIncomeStatement(ctx context.Context, startDate, endDate time.Time, period, currencyRef string, includeHidden, includeZero bool) (*dto.IncomeStatementResponse, error) {
+	if period == "" {
+		period = PeriodMonth
+	}
+	periods, err := reportPeriods(startDate, endDate, period)
+	if err != nil {
+		return nil, err
+	}
+
+	currency, err := s.resolveReportCurrency(ctx, currencyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	rootGUID, err := s.accountRepo.FindRootGUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find root account: %w", err)
+	}
+	accounts, err := s.accountRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+
+	b := &incomeStatementBuilder{
+		tree:          newAccountTree(rootGUID, accounts),
+		currencyGUID:  currency.GUID,
+		includeHidden: includeHidden,
+		includeZero:   includeZero,
+	}
+	response := &dto.IncomeStatementResponse{
+		StartDate:        startDate.Format("2006-01-02"),
+		EndDate:          endDate.Format("2006-01-02"),
+		Period:           period,
+		CurrencyMnemonic: currency.Mnemonic,
+	}
+
+	for _, p := range periods {
+		end := endOfDay(p.end)
+		sums, err := s.transactionRepo.SumQuantityByAccount(ctx, &p.start, &end)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sum period %s: %w", p.label, err)
+		}
+		byAccount := make(map[string]*repository.AccountAggregate, len(sums))
+		for _, agg := range sums {
+			byAccount[agg.AccountGUID] = agg
+		}
+
+		prices, err := s.priceRepo.FindAllAsOf(ctx, end)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load prices: %w", err)
+		}
+
+		b.sums = append(b.sums, byAccount)
+		b.rates = append(b.rates, newRateTable(prices))
+		response.Periods = append(response.Periods, dto.IncomeStatementPeriod{
+			Label:     p.label,
+			StartDate: p.start.Format("2006-01-02"),
+			EndDate:   p.end.Format("2006-01-02"),
+		})
+	}
+
+	var income, expense []decimal.Decimal
+	response.Income, income = b.section(entity.AccountTypeIncome, false)
+	response.Expense, expense = b.section(entity.AccountTypeExpense, true)
+
+	net := make([]decimal.Decimal, len(periods))
+	for i := range net {
+		net[i] = income[i].Sub(expense[i])
+	}
+	response.NetIncome, response.TotalNetIncome = formatColumns(net)
+	response.MissingPrices = b.missingPrices
+
+	return response, nil
+}
+
+// reportPeriods splits a date range into calendar months, quarters or years,
+// clipping the first and last period to the range
+func reportPeriods(startDate, endDate time.Time, period string) ([]reportPeriod, error) {
+	start := dateOnly(startDate)
+	end := dateOnly(endDate)
+	if end.Before(start) {
+		return nil, validationError("end_date must not be before start_date")
+	}
+
+	var periods []reportPeriod
+	for current := start; !current.After(end); {
+		var next time.Time
+		var label string
+		switch period {
+		case PeriodMonth:
+			next = time.Date(current.Year(), current.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			label = current.Format("2006-01")
+		case PeriodQuarter:
+			quarter := (int(current.Month()) - 1) / 3
+			next = time.Date(current.Year(), time.Month(quarter*3+4), 1, 0, 0, 0, 0, time.UTC)
+			label = fmt.Sprintf("%d-Q%d", current.Year(), quarter+1)
+		case PeriodYear:
+			next = time.Date(current.Year()+1, 1, 1, 0, 0, 0, 0, time.UTC)
+			label = current.Format("2006")
+		default:
+			return nil, validationError("period must be month, quarter or year")
+		}
+
+		last := next.AddDate(0, 0, -1)
+		if last.After(end) {
+			last = end
+		}
+		periods = append(periods, reportPeriod{label: label, start: current, end: last})
+		if len(periods) > maxReportPeriods {
+			return nil, validationError("the report would have more than %d periods; use a longer period or a shorter range", maxReportPeriods)
+		}
+		current = next
+	}
+
+	return periods, nil
+}
+
+// incomeStatementBuilder turns per-period account sums into section trees
+type incomeStatementBuilder struct {
+	tree          *accountTree
+	sums          []map[string]*repository.AccountAggregate
+	rates         []*rateTable
+	currencyGUID  string
+	includeHidden bool
+	includeZero   bool
+	missingPrices int
+}
+
+// section builds the tree of one account type below the root with its totals
+func (b *incomeStatementBuilder) section(accountType entity.AccountType, debit bool) (dto.IncomeStatementSection, []decimal.Decimal) {
+	section := dto.IncomeStatementSection{Accounts: []dto.IncomeStatementNode{}}
+	totals := make([]decimal.Decimal, len(b.sums))
+	for i := range totals {
+		totals[i] = decimal.Zero
+	}
+
+	for _, acc := range b.tree.sortedChildren(b.tree.rootGUID) {
+		node, subtotals, ok := b.node(acc, accountType, debit)
+		if !ok {
+			continue
+		}
+		for i := range totals {
+			totals[i] = totals[i].Add(subtotals[i])
+		}
+		if b.visible(acc, &node, subtotals) {
+			section.Accounts = append(section.Accounts, node)
+		}
+	}
+
+	section.Totals, section.Total = formatColumns(totals)
+	return section, totals
+}
+
+// node builds the node of an account. It reports false when neither the
+// account nor any descendant has the section's type.
+func (b *incomeStatementBuilder) node(acc *entity.Account, accountType entity.AccountType, debit bool) (dto.IncomeStatementNode, []decimal.Decimal, bool) {
+	node := dto.IncomeStatementNode{
+		AccountGUID: acc.GUID,
+		AccountName: acc.Name,
+		AccountType: string(acc.AccountType),
+		Code:        entity.StringOrEmpty(acc.Code),
+		Hidden:      acc.Hidden,
+		Placeholder: acc.Placeholder,
+	}
+
+	own := acc.AccountType == accountType
+	amounts := make([]decimal.Decimal, len(b.sums))
+	for i := range amounts {
+		amounts[i] = decimal.Zero
+		if !own {
+			continue
+		}
+		amount, ok := b.amount(acc, i, debit)
+		if !ok {
+			node.PriceMissing = true
+		}
+		amounts[i] = amount
+	}
+	node.Amounts, node.Total = formatColumns(amounts)
+
+	subtotals := append([]decimal.Decimal(nil), amounts...)
+	relevant := own
+	for _, child := range b.tree.sortedChildren(acc.GUID) {
+		childNode, childSubtotals, ok := b.node(child, accountType, debit)
+		if !ok {
+			continue
+		}
+		relevant = true
+		for i := range subtotals {
+			subtotals[i] = subtotals[i].Add(childSubtotals[i])
+		}
+		if b.visible(child, &childNode, childSubtotals) {
+			node.Children = append(node.Children, childNode)
+		}
+	}
+	node.Subtotals, node.SubtotalTotal = formatColumns(subtotals)
+
+	return node, subtotals, relevant
+}
+
+// amount returns an account's signed amount for a period in the report
+// currency. It reports false, counting a missing price, when a non-zero
+// amount cannot be converted.
+func (b *incomeStatementBuilder) amount(acc *entity.Account, period int, debit bool) (decimal.Decimal, bool) {
+	sum, ok := b.sums[period][acc.GUID]
+	if !ok {
+		return decimal.Zero, true
+	}
+	native := gnucash.RationalToDecimal(gnucash.NormalizeSign(sum.TotalAmount, debit), sum.Denominator)
+	if native.IsZero() {
+		return decimal.Zero, true
+	}
+
+	if acc.CommodityGUID == nil {
+		b.missingPrices++
+		return decimal.Zero, false
+	}
+	rate, ok := b.rates[period].Rate(*acc.CommodityGUID, b.currencyGUID)
+	if !ok {
+		b.missingPrices++
+		return decimal.Zero, false
+	}
+	return native.Mul(rate.Rate), true
+}
+
+// visible reports whether a node is shown under the requested options
+func (b *incomeStatementBuilder) visible(acc *entity.Account, node *dto.IncomeStatementNode, subtotals []decimal.Decimal) bool {
+	if acc.Hidden && !b.includeHidden {
+		return false
+	}
+	if b.includeZero || len(node.Children) > 0 || node.PriceMissing {
+		return true
+	}
+	for _, amount := range subtotals {
+		if !amount.Round(2).IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// formatColumns formats per-period amounts and returns them with their total
+func formatColumns(amounts []decimal.Decimal) ([]string, string) {
+	formatted := make([]string, len(amounts))
+	total := decimal.Zero
+	for i, amount := range amounts {
+		formatted[i] = amount.StringFixed(2)
+		total = total.Add(amount)
+	}
+	return formatted, total.StringFixed(2)
+}
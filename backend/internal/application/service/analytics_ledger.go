@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
+)
+
+// GetTrialBalance lists every account's debit and credit totals for
+// transactions posted between startDate (or the first transaction when nil)
+// and endDate, one row per account and transaction currency. Sums are exact,
+// and each currency is checked for total debits equalling total credits.
+func (s *AnalyticsService) GetTrialBalance(ctx context.Context, startDate *time.Time, endDate time.Time) (*dto.TrialBalanceResponse, error) {
+	response := &dto.TrialBalanceResponse{
+		EndDate:  endDate.Format("2006-01-02"),
+		Rows:     []dto.TrialBalanceRow{},
+		Totals:   []dto.TrialBalanceTotal{},
+		Balanced: true,
+	}
+	var start *time.Time
+	if startDate != nil {
+		if endDate.Before(*startDate) {
+			return nil, validationError("end_date must not be before start_date")
+		}
+		day := dateOnly(*startDate)
+		start = &day
+		response.StartDate = startDate.Format("2006-01-02")
+	}
+
+	rootGUID, err := s.accountRepo.FindRootGUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find root account: %w", err)
+	}
+	accounts, err := s.accountRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+	tree := newAccountTree(rootGUID, accounts)
+	fullNames := accountFullNames(accounts)
+
+	end := endOfDay(endDate)
+	sums, err := s.transactionRepo.SumDebitsCredits(ctx, start, &end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum debits and credits: %w", err)
+	}
+
+	type currencyTotals struct {
+		mnemonic                    string
+		debit, credit, net          gnucash.RationalSum
+		balanceDebit, balanceCredit gnucash.RationalSum
+	}
+	totals := make(map[string]*currencyTotals)
+
+	for _, sum := range sums {
+		acc, ok := tree.accounts[sum.AccountGUID]
+		if !ok || !tree.inBook(sum.AccountGUID) {
+			continue
+		}
+
+		currency, ok := totals[sum.CurrencyGUID]
+		if !ok {
+			currency = &currencyTotals{mnemonic: sum.CurrencyGUID}
+			if commodity, err := s.commodityRepo.FindByGUID(ctx, sum.CurrencyGUID); err == nil {
+				currency.mnemonic = commodity.Mnemonic
+			}
+			totals[sum.CurrencyGUID] = currency
+		}
+
+		var net gnucash.RationalSum
+		net.Add(sum.DebitNum, sum.DebitDenom)
+		net.Add(-sum.CreditNum, sum.CreditDenom)
+
+		row := dto.TrialBalanceRow{
+			AccountGUID: acc.GUID,
+			AccountName: fullNames[acc.GUID],
+			AccountType: string(acc.AccountType),
+			Currency:    currency.mnemonic,
+			Debit:       gnucash.FormatExact(sum.DebitNum, sum.DebitDenom),
+			Credit:      gnucash.FormatExact(sum.CreditNum, sum.CreditDenom),
+			SplitCount:  sum.SplitCount,
+		}
+		switch {
+		case net.Sign() > 0:
+			row.BalanceDebit = net.String()
+			currency.balanceDebit.AddSum(&net)
+		case net.Sign() < 0:
+			var credit gnucash.RationalSum
+			credit.Add(sum.CreditNum, sum.CreditDenom)
+			credit.Add(-sum.DebitNum, sum.DebitDenom)
+			row.BalanceCredit = credit.String()
+			currency.balanceCredit.AddSum(&credit)
+		}
+		response.Rows = append(response.Rows, row)
+
+		currency.debit.Add(sum.DebitNum, sum.DebitDenom)
+		currency.credit.Add(sum.CreditNum, sum.CreditDenom)
+		currency.net.AddSum(&net)
+	}
+
+	sort.Slice(response.Rows, func(i, j int) bool {
+		if response.Rows[i].AccountName != response.Rows[j].AccountName {
+			return response.Rows[i].AccountName < response.Rows[j].AccountName
+		}
+		return response.Rows[i].Currency < response.Rows[j].Currency
+	})
+
+	for _, currency := range totals {
+		total := dto.TrialBalanceTotal{
+			Currency:      currency.mnemonic,
+			Debit:         currency.debit.String(),
+			Credit:        currency.credit.String(),
+			BalanceDebit:  currency.balanceDebit.String(),
+			BalanceCredit: currency.balanceCredit.String(),
+			Balanced:      currency.net.IsZero(),
+		}
+		response.Totals = append(response.Totals, total)
+		response.Balanced = response.Balanced && total.Balanced
+	}
+	sort.Slice(response.Totals, func(i, j int) bool { return response.Totals[i].Currency < response.Totals[j].Currency })
+
+	return response, nil
+}
+
+// GetGeneralJournal lists the transactions posted within the optional date
+// range oldest first with their splits as debits and credits. A limit of
+// zero returns every transaction.
+func (s *AnalyticsService) GetGeneralJournal(ctx context.Context, startDate, endDate *time.Time, limit, offset int) (*dto.GeneralJournalResponse, error) {
+	if limit < 0 || offset < 0 {
+		return nil, validationError("limit and offset must not be negative")
+	}
+	filter := &repository.TransactionFilter{
+		OldestFirst:      true,
+		ExcludeTemplates: true,
+		Limit:            limit,
+		Offset:           offset,
+	}
+	response := &dto.GeneralJournalResponse{Entries: []dto.JournalEntry{}, Limit: limit, Offset: offset}
+	if startDate != nil {
+		start := dateOnly(*startDate)
+		filter.StartDate = &start
+		response.StartDate = start.Format("2006-01-02")
+	}
+	if endDate != nil {
+		end := endOfDay(*endDate)
+		filter.EndDate = &end
+		response.EndDate = end.Format("2006-01-02")
+	}
+	if filter.StartDate != nil && filter.EndDate != nil && filter.EndDate.Before(*filter.StartDate) {
+		return nil, validationError("end_date must not be before start_date")
+	}
+
+	accounts, err := s.accountRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+	byGUID := make(map[string]*entity.Account, len(accounts))
+	for _, acc := range accounts {
+		byGUID[acc.GUID] = acc
+	}
+	fullNames := accountFullNames(accounts)
+
+	transactions, err := s.transactionRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transactions: %w", err)
+	}
+	if response.Total, err = s.transactionRepo.Count(ctx, filter); err != nil {
+		return nil, fmt.Errorf("failed to count transactions: %w", err)
+	}
+
+	for _, tx := range transactions {
+		entry := dto.JournalEntry{
+			TransactionGUID: tx.GUID,
+			PostDate:        tx.PostDate.Format("2006-01-02"),
+			Num:             entity.StringOrEmpty(tx.Num),
+			Description:     entity.StringOrEmpty(tx.Description),
+			Currency:        tx.CurrencyMnemonic,
+			Voided:          tx.Voided,
+			Splits:          make([]dto.JournalSplit, 0, len(tx.Splits)),
+		}
+
+		var debit, credit gnucash.RationalSum
+		for _, split := range tx.Splits {
+			line := dto.JournalSplit{
+				AccountGUID:    split.AccountGUID,
+				AccountName:    fullNames[split.AccountGUID],
+				Memo:           entity.StringOrEmpty(split.Memo),
+				Action:         entity.StringOrEmpty(split.Action),
+				ReconcileState: split.ReconcileState,
+			}
+			switch {
+			case split.ValueNum > 0:
+				line.Debit = gnucash.FormatExact(split.ValueNum, split.ValueDenom)
+				debit.Add(split.ValueNum, split.ValueDenom)
+			case split.ValueNum < 0:
+				line.Credit = gnucash.FormatExact(-split.ValueNum, split.ValueDenom)
+				credit.Add(-split.ValueNum, split.ValueDenom)
+			}
+			if acc, ok := byGUID[split.AccountGUID]; ok && acc.CommodityGUID != nil && *acc.CommodityGUID != tx.CurrencyGUID {
+				line.Quantity = gnucash.FormatExact(split.QuantityNum, split.QuantityDenom)
+			}
+			entry.Splits = append(entry.Splits, line)
+		}
+		entry.Debit = debit.String()
+		entry.Credit = credit.String()
+
+		response.Entries = append(response.Entries, entry)
+	}
+
+	return response, nil
+}
@@ -16,16 +16,31 @@ import (
 type AnalyticsService struct {
 	accountRepo     repository.AccountRepository
 	transactionRepo repository.TransactionRepository
+	commodityRepo   repository.CommodityRepository
+	priceRepo       repository.PriceRepository
+	lotRepo         repository.LotRepository
+	budgetRepo      repository.BudgetRepository
+	sxRepo          repository.ScheduledTransactionRepository
 }
 
 // NewAnalyticsService creates a new analytics service
 func NewAnalyticsService(
 	accountRepo repository.AccountRepository,
 	transactionRepo repository.TransactionRepository,
+	commodityRepo repository.CommodityRepository,
+	priceRepo repository.PriceRepository,
+	lotRepo repository.LotRepository,
+	budgetRepo repository.BudgetRepository,
+	sxRepo repository.ScheduledTransactionRepository,
 ) *AnalyticsService {
 	return &AnalyticsService{
 		accountRepo:     accountRepo,
 		transactionRepo: transactionRepo,
+		commodityRepo:   commodityRepo,
+		priceRepo:       priceRepo,
+		lotRepo:         lotRepo,
+		budgetRepo:      budgetRepo,
+		sxRepo:          sxRepo,
 	}
 }
 
@@ -175,8 +190,43 @@ func (s *AnalyticsService) GetCategoryBreakdown(ctx context.Context, startDate,
 	}, nil
 }
 
-// GetNetWorth calculates current net worth
-func (s *AnalyticsService) GetNetWorth(ctx context.Context) (*dto.NetWorthResponse, error) {
+// resolveReportCurrency returns the commodity named by currencyRef, or the
+// ROOT account's commodity when no reference is given
+func (s *AnalyticsService) resolveReportCurrency(ctx context.Context, currencyRef string) (*entity.Commodity, error) {
+	if currencyRef != "" {
+		return resolveCommodityRef(ctx, s.commodityRepo, currencyRef)
+	}
+
+	rootGUID, err := s.accountRepo.FindRootGUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find root account: %w", err)
+	}
+	root, err := s.accountRepo.FindByGUID(ctx, rootGUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get root account: %w", err)
+	}
+	if root.CommodityGUID == nil {
+		return nil, validationError("the root account has no currency; pass a report currency")
+	}
+
+	return s.commodityRepo.FindByGUID(ctx, *root.CommodityGUID)
+}
+
+// GetNetWorth calculates net worth as of a date, converting every balance
+// into the report currency (the ROOT account's currency if currencyRef is empty)
+func (s *AnalyticsService) GetNetWorth(ctx context.Context, currencyRef string, asOf time.Time) (*dto.NetWorthResponse, error) {
+	currency, err := s.resolveReportCurrency(ctx, currencyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := endOfDay(asOf)
+	prices, err := s.priceRepo.FindAllAsOf(ctx, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load prices: %w", err)
+	}
+	rates := newRateTable(prices)
+
 	// Get asset accounts
 	assetTypes := []entity.AccountType{
 		entity.AccountTypeBank,
@@ -188,6 +238,7 @@ func (s *AnalyticsService) GetNetWorth(ctx context.Context) (*dto.NetWorthRespon
 
 	var assets []dto.NetWorthItem
 	totalAssets := decimal.Zero
+	missingPrices := 0
 
 	for _, accType := range assetTypes {
 		accounts, err := s.accountRepo.FindByType(ctx, accType)
@@ -200,19 +251,20 @@ func (s *AnalyticsService) GetNetWorth(ctx context.Context) (*dto.NetWorthRespon
 				continue
 			}
 
-			balanceNum, balanceDenom, err := s.accountRepo.GetBalance(ctx, acc.GUID)
+			balanceNum, balanceDenom, err := s.accountRepo.GetBalanceAsOf(ctx, acc.GUID, cutoff)
 			if err != nil {
 				continue
 			}
 
 			balance := gnucash.RationalToDecimal(balanceNum, balanceDenom)
 			if !balance.IsZero() {
-				assets = append(assets, dto.NetWorthItem{
-					AccountName: acc.Name,
-					AccountType: string(acc.AccountType),
-					Balance:     balance.StringFixed(2),
-				})
-				totalAssets = totalAssets.Add(balance)
+				item, converted, ok := convertNetWorthItem(acc, balance, currency.GUID, rates)
+				if ok {
+					totalAssets = totalAssets.Add(converted)
+				} else {
+					missingPrices++
+				}
+				assets = append(assets, item)
 			}
 		}
 	}
@@ -238,19 +290,20 @@ func (s *AnalyticsService) GetNetWorth(ctx context.Context) (*dto.NetWorthRespon
 				continue
 			}
 
-			balanceNum, balanceDenom, err := s.accountRepo.GetBalance(ctx, acc.GUID)
+			balanceNum, balanceDenom, err := s.accountRepo.GetBalanceAsOf(ctx, acc.GUID, cutoff)
 			if err != nil {
 				continue
 			}
 
 			balance := gnucash.RationalToDecimal(balanceNum, balanceDenom)
 			if !balance.IsZero() {
-				liabilities = append(liabilities, dto.NetWorthItem{
-					AccountName: acc.Name,
-					AccountType: string(acc.AccountType),
-					Balance:     balance.Abs().StringFixed(2),
-				})
-				totalLiabilities = totalLiabilities.Add(balance.Abs())
+				item, converted, ok := convertNetWorthItem(acc, balance.Abs(), currency.GUID, rates)
+				if ok {
+					totalLiabilities = totalLiabilities.Add(converted)
+				} else {
+					missingPrices++
+				}
+				liabilities = append(liabilities, item)
 			}
 		}
 	}
@@ -263,6 +316,42 @@ func (s *AnalyticsService) GetNetWorth(ctx context.Context) (*dto.NetWorthRespon
 		TotalAssets:      totalAssets.StringFixed(2),
 		TotalLiabilities: totalLiabilities.StringFixed(2),
 		NetWorth:         netWorth.StringFixed(2),
-		CurrencyMnemonic: s.getRootCurrencyMnemonic(ctx),
+		CurrencyMnemonic: currency.Mnemonic,
+		AsOf:             asOf.Format("2006-01-02"),
+		MissingPrices:    missingPrices,
 	}, nil
 }
+
+// convertNetWorthItem converts an account balance into the report currency.
+// It reports false, with the item flagged, when no rate is available.
+func convertNetWorthItem(acc *entity.Account, balance decimal.Decimal, currencyGUID string, rates *rateTable) (dto.NetWorthItem, decimal.Decimal, bool) {
+	item := dto.NetWorthItem{
+		AccountGUID:   acc.GUID,
+		AccountName:   acc.Name,
+		AccountType:   string(acc.AccountType),
+		Commodity:     acc.CommodityMnemonic,
+		NativeBalance: balance.String(),
+	}
+
+	if acc.CommodityGUID == nil {
+		item.PriceMissing = true
+		return item, decimal.Zero, false
+	}
+
+	rate, ok := rates.Rate(*acc.CommodityGUID, currencyGUID)
+	if !ok {
+		item.PriceMissing = true
+		return item, decimal.Zero, false
+	}
+
+	converted := balance.Mul(rate.Rate)
+	item.Balance = converted.StringFixed(2)
+	item.Rate = rate.Rate.Round(10).String()
+	item.RateMethod = rate.Method
+	item.RateVia = rate.Via
+	if !rate.Date.IsZero() {
+		item.RateDate = rate.Date.Format("2006-01-02")
+	}
+
+	return item, converted, true
+}
@@ -0,0 +1,441 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
+)
+
+// Budget defaults and limits
+const (
+	defaultBudgetPeriods = 12
+	maxBudgetPeriods     = 1000
+)
+
+// BudgetService handles budget business logic
+type BudgetService struct {
+	budgetRepo      repository.BudgetRepository
+	accountRepo     repository.AccountRepository
+	transactionRepo repository.TransactionRepository
+}
+
+// NewBudgetService creates a new budget service
+func NewBudgetService(
+	budgetRepo repository.BudgetRepository,
+	accountRepo repository.AccountRepository,
+	transactionRepo repository.TransactionRepository,
+) *BudgetService {
+	return &BudgetService{
+		budgetRepo:      budgetRepo,
+		accountRepo:     accountRepo,
+		transactionRepo: transactionRepo,
+	}
+}
+
+// ListBudgets returns every budget with its period layout
+func (s *BudgetService) ListBudgets(ctx context.Context) ([]dto.BudgetResponse, error) {
+	budgets, err := s.budgetRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budgets: %w", err)
+	}
+
+	response := make([]dto.BudgetResponse, len(budgets))
+	for i, b := range budgets {
+		response[i] = budgetToResponse(b)
+	}
+	return response, nil
+}
+
+// GetBudget returns a single budget
+func (s *BudgetService) GetBudget(ctx context.Context, guid string) (*dto.BudgetResponse, error) {
+	b, err := s.budgetRepo.FindByGUID(ctx, guid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := budgetToResponse(b)
+	return &response, nil
+}
+
+// CreateBudget creates an empty budget with its recurrence
+func (s *BudgetService) CreateBudget(ctx context.Context, req *dto.CreateBudgetRequest) (*dto.BudgetResponse, error) {
+	budget, err := newBudget(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.budgetRepo.Create(ctx, budget, nil); err != nil {
+		return nil, fmt.Errorf("failed to create budget: %w", err)
+	}
+
+	response := budgetToResponse(budget)
+	return &response, nil
+}
+
+// CreateBudgetFromActuals creates a budget whose amounts are the actuals of
+// each period one year earlier, for INCOME and EXPENSE accounts unless other
+// account types are requested
+func (s *BudgetService) CreateBudgetFromActuals(ctx context.Context, req *dto.CreateBudgetFromActualsRequest) (*dto.BudgetResponse, error) {
+	budget, err := newBudget(&req.CreateBudgetRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	types := map[entity.AccountType]bool{
+		entity.AccountTypeIncome:  true,
+		entity.AccountTypeExpense: true,
+	}
+	if len(req.AccountTypes) > 0 {
+		types = make(map[entity.AccountType]bool)
+		for _, t := range req.AccountTypes {
+			accountType := entity.AccountType(strings.ToUpper(t))
+			if !accountType.IsValid() || accountType == entity.AccountTypeRoot {
+				return nil, validationError("invalid account type %q", t)
+			}
+			types[accountType] = true
+		}
+	}
+
+	accounts, err := s.accountRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	byGUID := make(map[string]*entity.Account, len(accounts))
+	for _, acc := range accounts {
+		byGUID[acc.GUID] = acc
+	}
+
+	var amounts []*entity.BudgetAmount
+	for period := 0; period < budget.NumPeriods; period++ {
+		start := budget.PeriodStart(period).AddDate(-1, 0, 0)
+		end := endOfDay(budget.PeriodEnd(period).AddDate(-1, 0, 0))
+
+		sums, err := s.transactionRepo.SumQuantityByAccount(ctx, &start, &end)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get actuals: %w", err)
+		}
+
+		for _, sum := range sums {
+			acc, ok := byGUID[sum.AccountGUID]
+			if !ok || !types[acc.AccountType] {
+				continue
+			}
+
+			denom := accountDenom(acc)
+			num := gnucash.RationalToDecimal(sum.TotalAmount, sum.Denominator).
+				Mul(decimal.NewFromInt(denom)).Round(0).IntPart()
+			if num == 0 {
+				continue
+			}
+
+			amounts = append(amounts, &entity.BudgetAmount{
+				AccountGUID: acc.GUID,
+				PeriodNum:   period,
+				AmountNum:   num,
+				AmountDenom: denom,
+			})
+		}
+	}
+
+	if err := s.budgetRepo.Create(ctx, budget, amounts); err != nil {
+		return nil, fmt.Errorf("failed to create budget: %w", err)
+	}
+
+	response := budgetToResponse(budget)
+	return &response, nil
+}
+
+// UpdateBudget renames a budget, changes its description or its number of periods
+func (s *BudgetService) UpdateBudget(ctx context.Context, guid string, req *dto.UpdateBudgetRequest) (*dto.BudgetResponse, error) {
+	budget, err := s.budgetRepo.FindByGUID(ctx, guid)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, validationError("name must not be empty")
+		}
+		budget.Name = name
+	}
+	if req.Description != nil {
+		budget.Description = req.Description
+	}
+	if req.NumPeriods != nil {
+		if *req.NumPeriods < 1 || *req.NumPeriods > maxBudgetPeriods {
+			return nil, validationError("num_periods must be between 1 and %d", maxBudgetPeriods)
+		}
+		budget.NumPeriods = *req.NumPeriods
+	}
+
+	if err := s.budgetRepo.Update(ctx, budget); err != nil {
+		return nil, err
+	}
+
+	response := budgetToResponse(budget)
+	return &response, nil
+}
+
+// DeleteBudget removes a budget and all of its amounts
+func (s *BudgetService) DeleteBudget(ctx context.Context, guid string) error {
+	return s.budgetRepo.Delete(ctx, guid)
+}
+
+// GetAmounts returns every amount set in a budget
+func (s *BudgetService) GetAmounts(ctx context.Context, guid string) ([]dto.BudgetAmountResponse, error) {
+	if _, err := s.budgetRepo.FindByGUID(ctx, guid); err != nil {
+		return nil, err
+	}
+
+	accounts, err := s.accountRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	amounts, err := s.budgetRepo.FindAmounts(ctx, guid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget amounts: %w", err)
+	}
+
+	byGUID := make(map[string]*entity.Account, len(accounts))
+	for _, acc := range accounts {
+		byGUID[acc.GUID] = acc
+	}
+	names := accountFullNames(accounts)
+
+	response := make([]dto.BudgetAmountResponse, 0, len(amounts))
+	for _, a := range amounts {
+		amount := gnucash.RationalToDecimal(a.AmountNum, a.AmountDenom)
+		if acc, ok := byGUID[a.AccountGUID]; ok {
+			amount = naturalSign(acc, amount)
+		}
+		response = append(response, dto.BudgetAmountResponse{
+			AccountGUID: a.AccountGUID,
+			AccountName: names[a.AccountGUID],
+			Period:      a.PeriodNum,
+			Amount:      amount.String(),
+		})
+	}
+
+	return response, nil
+}
+
+// SetAmounts sets or clears individual budget amounts
+func (s *BudgetService) SetAmounts(ctx context.Context, guid string, req *dto.SetBudgetAmountsRequest) ([]dto.BudgetAmountResponse, error) {
+	budget, accounts, err := s.loadForAmounts(ctx, guid)
+	if err != nil {
+		return nil, err
+	}
+
+	var set, cleared []*entity.BudgetAmount
+	for _, r := range req.Amounts {
+		acc, err := budgetableAccount(accounts, r.AccountGUID)
+		if err != nil {
+			return nil, err
+		}
+		if r.Period < 0 || r.Period >= budget.NumPeriods {
+			return nil, validationError("period must be between 0 and %d", budget.NumPeriods-1)
+		}
+
+		if r.Amount == nil {
+			cleared = append(cleared, &entity.BudgetAmount{AccountGUID: acc.GUID, PeriodNum: r.Period})
+			continue
+		}
+		amount, err := newBudgetAmount(acc, r.Period, *r.Amount)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, amount)
+	}
+
+	if err := s.budgetRepo.UpdateAmounts(ctx, guid, set, cleared); err != nil {
+		return nil, fmt.Errorf("failed to update budget amounts: %w", err)
+	}
+
+	return s.GetAmounts(ctx, guid)
+}
+
+// FillAmounts sets the same amount for an account over a range of periods
+func (s *BudgetService) FillAmounts(ctx context.Context, guid string, req *dto.FillBudgetAmountsRequest) ([]dto.BudgetAmountResponse, error) {
+	budget, accounts, err := s.loadForAmounts(ctx, guid)
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := budgetableAccount(accounts, req.AccountGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := 0, budget.NumPeriods-1
+	if req.StartPeriod != nil {
+		start = *req.StartPeriod
+	}
+	if req.EndPeriod != nil {
+		end = *req.EndPeriod
+	}
+	if start < 0 || end >= budget.NumPeriods || start > end {
+		return nil, validationError("periods must satisfy 0 <= start_period <= end_period <= %d", budget.NumPeriods-1)
+	}
+
+	var set []*entity.BudgetAmount
+	for period := start; period <= end; period++ {
+		amount, err := newBudgetAmount(acc, period, req.Amount)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, amount)
+	}
+
+	if err := s.budgetRepo.UpdateAmounts(ctx, guid, set, nil); err != nil {
+		return nil, fmt.Errorf("failed to update budget amounts: %w", err)
+	}
+
+	return s.GetAmounts(ctx, guid)
+}
+
+// loadForAmounts loads a budget and the accounts by GUID for amount edits
+func (s *BudgetService) loadForAmounts(ctx context.Context, guid string) (*entity.Budget, map[string]*entity.Account, error) {
+	budget, err := s.budgetRepo.FindByGUID(ctx, guid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	accounts, err := s.accountRepo.FindAll(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	byGUID := make(map[string]*entity.Account, len(accounts))
+	for _, acc := range accounts {
+		byGUID[acc.GUID] = acc
+	}
+
+	return budget, byGUID, nil
+}
+
+// budgetableAccount returns the account if a budget amount may be set on it
+func budgetableAccount(accounts map[string]*entity.Account, guid string) (*entity.Account, error) {
+	acc, ok := accounts[guid]
+	if !ok {
+		return nil, validationError("account %s not found", guid)
+	}
+	if acc.AccountType == entity.AccountTypeRoot {
+		return nil, validationError("the root account cannot be budgeted")
+	}
+	return acc, nil
+}
+
+// newBudgetAmount converts an amount in the account's natural direction to
+// a budget_amounts row stored with the account's split sign and fraction
+func newBudgetAmount(acc *entity.Account, period int, value string) (*entity.BudgetAmount, error) {
+	amount, err := decimal.NewFromString(value)
+	if err != nil {
+		return nil, validationError("invalid amount %q", value)
+	}
+
+	denom := accountDenom(acc)
+	num, ok := gnucash.DecimalToRationalExact(naturalSign(acc, amount), denom)
+	if !ok {
+		return nil, validationError("amount %s has more precision than account %s allows", value, acc.Name)
+	}
+
+	return &entity.BudgetAmount{
+		AccountGUID: acc.GUID,
+		PeriodNum:   period,
+		AmountNum:   num,
+		AmountDenom: denom,
+	}, nil
+}
+
+// accountDenom returns the smallest commodity unit of an account
+func accountDenom(acc *entity.Account) int64 {
+	if acc.CommoditySCU > 0 {
+		return int64(acc.CommoditySCU)
+	}
+	return 100
+}
+
+// newBudget validates a create request and builds the budget entity
+func newBudget(req *dto.CreateBudgetRequest) (*entity.Budget, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, validationError("name is required")
+	}
+
+	numPeriods := req.NumPeriods
+	if numPeriods == 0 {
+		numPeriods = defaultBudgetPeriods
+	}
+	if numPeriods < 1 || numPeriods > maxBudgetPeriods {
+		return nil, validationError("num_periods must be between 1 and %d", maxBudgetPeriods)
+	}
+
+	recurrence := &entity.Recurrence{
+		Mult:          1,
+		PeriodType:    entity.RecurrencePeriodMonth,
+		PeriodStart:   time.Date(time.Now().Year(), 1, 1, 0, 0, 0, 0, time.UTC),
+		WeekendAdjust: entity.WeekendAdjustNone,
+	}
+	if r := req.Recurrence; r != nil {
+		if r.Mult != 0 {
+			recurrence.Mult = r.Mult
+		}
+		if r.PeriodType != "" {
+			recurrence.PeriodType = strings.ToLower(r.PeriodType)
+		}
+		if r.PeriodStart != "" {
+			start, err := time.Parse("2006-01-02", r.PeriodStart)
+			if err != nil {
+				return nil, validationError("invalid period_start format, use YYYY-MM-DD")
+			}
+			recurrence.PeriodStart = start
+		}
+	}
+	if recurrence.Mult < 1 {
+		return nil, validationError("recurrence mult must be at least 1")
+	}
+	if !entity.IsValidRecurrencePeriod(recurrence.PeriodType) || recurrence.PeriodType == entity.RecurrencePeriodOnce {
+		return nil, validationError("invalid budget period type %q", recurrence.PeriodType)
+	}
+
+	return &entity.Budget{
+		GUID:        gnucash.NewGUID(),
+		Name:        name,
+		Description: req.Description,
+		NumPeriods:  numPeriods,
+		Recurrence:  recurrence,
+	}, nil
+}
+
+// budgetToResponse converts a budget entity to its response DTO
+func budgetToResponse(b *entity.Budget) dto.BudgetResponse {
+	response := dto.BudgetResponse{
+		GUID:        b.GUID,
+		Name:        b.Name,
+		Description: b.Description,
+		NumPeriods:  b.NumPeriods,
+	}
+
+	if b.Recurrence != nil {
+		response.Recurrence = &dto.RecurrenceResponse{
+			Mult:          b.Recurrence.Mult,
+			PeriodType:    b.Recurrence.PeriodType,
+			PeriodStart:   b.Recurrence.PeriodStart.Format("2006-01-02"),
+			WeekendAdjust: b.Recurrence.WeekendAdjust,
+		}
+		if b.NumPeriods > 0 {
+			response.StartDate = b.PeriodStart(0).Format("2006-01-02")
+			response.EndDate = b.PeriodEnd(b.NumPeriods - 1).Format("2006-01-02")
+		}
+	}
+
+	return response
+}
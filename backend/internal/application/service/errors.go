@@ -0,0 +1,19 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrValidation is returned when a request violates a business rule
+	ErrValidation = errors.New("validation failed")
+
+	// ErrReconciled is returned when an operation would alter reconciled splits
+	ErrReconciled = errors.New("transaction has reconciled splits")
+)
+
+// validationError wraps ErrValidation with a human-readable reason
+func validationError(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrValidation, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+	"github.com/udai-kiran/agentic-cash/pkg/importer"
+)
+
+// PreviewCSV reads a CSV file with a saved mapping profile and proposes a
+// transaction for each row. Rows that cannot be read are reported with their
+// line number and left out; lines that match an existing split of the
+// account by date, amount and description are marked as duplicates.
+func (s *ImportService) PreviewCSV(ctx context.Context, req *dto.CSVPreviewRequest, r io.Reader) (*dto.ImportPreviewResponse, error) {
+	profile, err := s.profileRepo.FindByID(ctx, req.ProfileID)
+	if err != nil {
+		return nil, err
+	}
+
+	accountGUID := req.AccountGUID
+	if accountGUID == "" {
+		accountGUID = profile.AccountGUID
+	}
+	account, err := s.importAccount(ctx, accountGUID)
+	if err != nil {
+		return nil, err
+	}
+	counterGUID := req.CounterAccountGUID
+	if counterGUID == "" {
+		counterGUID = entity.StringOrEmpty(profile.CounterAccountGUID)
+	}
+	if err := s.checkCounterAccount(ctx, counterGUID); err != nil {
+		return nil, err
+	}
+
+	statement := &importer.Statement{Format: importer.FormatCSV}
+	var rowErrors []dto.ImportRowError
+	reader := importer.NewCSVReader(r, csvMapping(profile))
+	for {
+		tx, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var rowErr *importer.RowError
+		if errors.As(err, &rowErr) {
+			rowErrors = append(rowErrors, dto.ImportRowError{Line: rowErr.Line, Message: rowErr.Message})
+			continue
+		}
+		if err != nil {
+			return nil, validationError("invalid CSV file: %v", err)
+		}
+		statement.Transactions = append(statement.Transactions, tx)
+	}
+
+	response, err := s.preview(ctx, account, statement, counterGUID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyRules(ctx, account.GUID, statement, response); err != nil {
+		return nil, err
+	}
+	response.Errors = rowErrors
+	return response, nil
+}
+
+// ListCSVProfiles returns every CSV mapping profile
+func (s *ImportService) ListCSVProfiles(ctx context.Context) ([]dto.CSVProfileResponse, error) {
+	profiles, err := s.profileRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CSV profiles: %w", err)
+	}
+
+	response := make([]dto.CSVProfileResponse, 0, len(profiles))
+	for _, profile := range profiles {
+		response = append(response, csvProfileToResponse(profile))
+	}
+	return response, nil
+}
+
+// GetCSVProfile returns one CSV mapping profile
+func (s *ImportService) GetCSVProfile(ctx context.Context, id int64) (*dto.CSVProfileResponse, error) {
+	profile, err := s.profileRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := csvProfileToResponse(profile)
+	return &response, nil
+}
+
+// CreateCSVProfile validates and stores a new CSV mapping profile
+func (s *ImportService) CreateCSVProfile(ctx context.Context, req *dto.CSVProfileRequest) (*dto.CSVProfileResponse, error) {
+	profile, err := s.newCSVProfile(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.profileRepo.Create(ctx, profile); err != nil {
+		return nil, csvProfileError(err, profile.Name)
+	}
+
+	response := csvProfileToResponse(profile)
+	return &response, nil
+}
+
+// UpdateCSVProfile replaces a CSV mapping profile
+func (s *ImportService) UpdateCSVProfile(ctx context.Context, id int64, req *dto.CSVProfileRequest) (*dto.CSVProfileResponse, error) {
+	profile, err := s.newCSVProfile(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	profile.ID = id
+
+	if err := s.profileRepo.Update(ctx, profile); err != nil {
+		return nil, csvProfileError(err, profile.Name)
+	}
+
+	response := csvProfileToResponse(profile)
+	return &response, nil
+}
+
+// DeleteCSVProfile removes a CSV mapping profile
+func (s *ImportService) DeleteCSVProfile(ctx context.Context, id int64) error {
+	return s.profileRepo.Delete(ctx, id)
+}
+
+// newCSVProfile validates a request and fills in the defaults
+func (s *ImportService) newCSVProfile(ctx context.Context, req *dto.CSVProfileRequest) (*entity.CSVProfile, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, validationError("name is required")
+	}
+	if _, err := s.importAccount(ctx, req.AccountGUID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, validationError("account %s not found", req.AccountGUID)
+		}
+		return nil, err
+	}
+	var counterGUID *string
+	if req.CounterAccountGUID != nil && *req.CounterAccountGUID != "" {
+		if err := s.checkCounterAccount(ctx, *req.CounterAccountGUID); err != nil {
+			return nil, err
+		}
+		counterGUID = req.CounterAccountGUID
+	}
+
+	profile := &entity.CSVProfile{
+		Name:               name,
+		AccountGUID:        req.AccountGUID,
+		CounterAccountGUID: counterGUID,
+		Delimiter:          req.Delimiter,
+		HasHeader:          req.HasHeader == nil || *req.HasHeader,
+		SkipRows:           req.SkipRows,
+		DateColumn:         strings.TrimSpace(req.DateColumn),
+		DateFormat:         req.DateFormat,
+		AmountColumn:       strings.TrimSpace(req.AmountColumn),
+		DebitColumn:        strings.TrimSpace(req.DebitColumn),
+		CreditColumn:       strings.TrimSpace(req.CreditColumn),
+		SignConvention:     req.SignConvention,
+		DecimalSeparator:   req.DecimalSeparator,
+		DescriptionColumn:  strings.TrimSpace(req.DescriptionColumn),
+		MemoColumn:         strings.TrimSpace(req.MemoColumn),
+	}
+	if profile.Delimiter == "" {
+		profile.Delimiter = ","
+	}
+	if profile.DateFormat == "" {
+		profile.DateFormat = "YYYY-MM-DD"
+	}
+	if profile.SignConvention == "" {
+		profile.SignConvention = entity.SignDepositsPositive
+	}
+	if profile.DecimalSeparator == "" {
+		profile.DecimalSeparator = "."
+	}
+
+	if profile.Delimiter == `\t` {
+		profile.Delimiter = "\t"
+	}
+	if utf8.RuneCountInString(profile.Delimiter) != 1 || strings.ContainsAny(profile.Delimiter, "\"\r\n") {
+		return nil, validationError("delimiter must be a single character other than a quote or line break")
+	}
+	if profile.SkipRows < 0 {
+		return nil, validationError("skip_rows cannot be negative")
+	}
+	if profile.DateColumn == "" {
+		return nil, validationError("date_column is required")
+	}
+	hasAmount := profile.AmountColumn != ""
+	hasDebitCredit := profile.DebitColumn != "" || profile.CreditColumn != ""
+	if hasAmount == hasDebitCredit {
+		return nil, validationError("set either amount_column or debit_column/credit_column")
+	}
+	if profile.SignConvention != entity.SignDepositsPositive && profile.SignConvention != entity.SignWithdrawalsPositive {
+		return nil, validationError("sign_convention must be %s or %s", entity.SignDepositsPositive, entity.SignWithdrawalsPositive)
+	}
+	if profile.DecimalSeparator != "." && profile.DecimalSeparator != "," {
+		return nil, validationError("decimal_separator must be . or ,")
+	}
+
+	return profile, nil
+}
+
+// csvMapping converts a profile into the importer's CSV mapping
+func csvMapping(profile *entity.CSVProfile) *importer.CSVMapping {
+	delimiter, _ := utf8.DecodeRuneInString(profile.Delimiter)
+	return &importer.CSVMapping{
+		Delimiter:         delimiter,
+		HasHeader:         profile.HasHeader,
+		SkipRows:          profile.SkipRows,
+		DateColumn:        profile.DateColumn,
+		DateFormat:        profile.DateFormat,
+		AmountColumn:      profile.AmountColumn,
+		DebitColumn:       profile.DebitColumn,
+		CreditColumn:      profile.CreditColumn,
+		InvertSign:        profile.SignConvention == entity.SignWithdrawalsPositive,
+		DecimalComma:      profile.DecimalSeparator == ",",
+		DescriptionColumn: profile.DescriptionColumn,
+		MemoColumn:        profile.MemoColumn,
+	}
+}
+
+// csvProfileError explains a failed profile write
+func csvProfileError(err error, name string) error {
+	if errors.Is(err, repository.ErrConflict) {
+		return fmt.Errorf("a CSV profile named %q already exists: %w", name, err)
+	}
+	if errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	return fmt.Errorf("failed to save CSV profile: %w", err)
+}
+
+// csvProfileToResponse converts a profile for API responses
+func csvProfileToResponse(p *entity.CSVProfile) dto.CSVProfileResponse {
+	return dto.CSVProfileResponse{
+		ID:                 p.ID,
+		Name:               p.Name,
+		AccountGUID:        p.AccountGUID,
+		CounterAccountGUID: p.CounterAccountGUID,
+		Delimiter:          p.Delimiter,
+		HasHeader:          p.HasHeader,
+		SkipRows:           p.SkipRows,
+		DateColumn:         p.DateColumn,
+		DateFormat:         p.DateFormat,
+		AmountColumn:       p.AmountColumn,
+		DebitColumn:        p.DebitColumn,
+		CreditColumn:       p.CreditColumn,
+		SignConvention:     p.SignConvention,
+		DecimalSeparator:   p.DecimalSeparator,
+		DescriptionColumn:  p.DescriptionColumn,
+		MemoColumn:         p.MemoColumn,
+		CreatedAt:          p.CreatedAt,
+		UpdatedAt:          p.UpdatedAt,
+	}
+}
@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/pkg/importer"
+)
+
+// PreviewQIF parses a QIF file and proposes a transaction for each record of
+// the chosen account. QIF categories are mapped to accounts through the
+// request's category map, then by full account name, then under the book's
+// top-level income or expense account, then by a unique trailing name match.
+// Transfers and securities are matched by name only. With CreateMissing, the
+// remaining categories are proposed as new accounts for the commit to create.
+// Import rules apply to the records without a category.
+func (s *ImportService) PreviewQIF(ctx context.Context, req *dto.QIFPreviewRequest, r io.Reader) (*dto.ImportPreviewResponse, error) {
+	account, err := s.importAccount(ctx, req.AccountGUID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCounterAccount(ctx, req.CounterAccountGUID); err != nil {
+		return nil, err
+	}
+
+	var opts importer.QIFOptions
+	switch strings.ToLower(req.DateFormat) {
+	case "", "mdy":
+	case "dmy":
+		opts.DayFirst = true
+	default:
+		return nil, validationError("date_format must be mdy or dmy")
+	}
+
+	categoryMap := make(map[string]string)
+	if req.CategoryMap != "" {
+		if err := json.Unmarshal([]byte(req.CategoryMap), &categoryMap); err != nil {
+			return nil, validationError("category_map must be a JSON object of category to account GUID")
+		}
+	}
+
+	statements, rowErrors, err := importer.ParseQIF(r, opts)
+	if err != nil {
+		return nil, validationError("invalid QIF file: %v", err)
+	}
+	if len(statements) == 0 {
+		return nil, validationError("the file has no transactions")
+	}
+	statement, err := pickStatement(statements, req.StatementAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.preview(ctx, account, statement, req.CounterAccountGUID)
+	if err != nil {
+		return nil, err
+	}
+	for _, rowErr := range rowErrors {
+		response.Errors = append(response.Errors, dto.ImportRowError{Line: rowErr.Line, Message: rowErr.Message})
+	}
+
+	categories, err := s.newCategoryResolver(ctx, categoryMap, req.CreateMissing)
+	if err != nil {
+		return nil, err
+	}
+	for _, guid := range categoryMap {
+		if _, ok := categories.byGUID[guid]; !ok {
+			return nil, validationError("category_map account %s not found", guid)
+		}
+	}
+
+	for i, tx := range statement.Transactions {
+		entry := &response.Entries[i]
+		if len(tx.Splits) == 0 {
+			if tx.Category == "" {
+				continue
+			}
+			entry.Category = tx.Category
+			entry.CounterAccountGUID, entry.CounterNewAccount = categories.resolve(tx.Category, tx.Transfer, false, tx.Amount.IsPositive())
+			if entry.CounterAccountGUID == "" && entry.CounterNewAccount == "" {
+				entry.CounterAccountGUID = req.CounterAccountGUID
+			}
+			continue
+		}
+
+		entry.CounterAccountGUID = ""
+		for _, split := range tx.Splits {
+			guid, newAccount := categories.resolve(split.Category, split.Transfer, split.Security, split.Amount.IsPositive())
+			splitResponse := dto.ImportSplitResponse{
+				Category:    split.Category,
+				Transfer:    split.Transfer,
+				AccountGUID: guid,
+				NewAccount:  newAccount,
+				Amount:      split.Amount.String(),
+				Memo:        split.Memo,
+			}
+			if split.Security {
+				splitResponse.Quantity = split.Quantity.String()
+			}
+			entry.Splits = append(entry.Splits, splitResponse)
+		}
+	}
+
+	if err := s.applyRules(ctx, account.GUID, statement, response); err != nil {
+		return nil, err
+	}
+
+	response.NewAccounts = categories.proposed
+	for _, category := range categories.unresolved {
+		response.Warnings = append(response.Warnings, fmt.Sprintf("%q matches no account; map it with category_map", category))
+	}
+
+	return response, nil
+}
+
+// categoryResolver maps QIF categories, transfer accounts and securities to
+// the book's accounts
+type categoryResolver struct {
+	explicit      map[string]string
+	byGUID        map[string]*entity.Account
+	byFullName    map[string]string
+	topLevel      map[entity.AccountType]string
+	createMissing bool
+	resolved      map[string][2]string
+	proposed      []dto.ImportNewAccount
+	unresolved    []string
+}
+
+// newCategoryResolver indexes the book's accounts by lower-cased full name
+func (s *ImportService) newCategoryResolver(ctx context.Context, explicit map[string]string, createMissing bool) (*categoryResolver, error) {
+	tree, fullNames, err := s.bookAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &categoryResolver{
+		explicit:      explicit,
+		byGUID:        make(map[string]*entity.Account),
+		byFullName:    make(map[string]string),
+		topLevel:      make(map[entity.AccountType]string),
+		createMissing: createMissing,
+		resolved:      make(map[string][2]string),
+	}
+	for guid, name := range fullNames {
+		acc := tree.accounts[guid]
+		c.byGUID[guid] = acc
+		c.byFullName[strings.ToLower(name)] = guid
+		if acc.ParentGUID != nil && *acc.ParentGUID == tree.rootGUID {
+			if existing, ok := c.topLevel[acc.AccountType]; !ok || name < existing {
+				c.topLevel[acc.AccountType] = name
+			}
+		}
+	}
+	return c, nil
+}
+
+// resolve returns the account GUID for a category, or the full name of the
+// account proposed for it. Income decides whether a missing category becomes
+// an INCOME or EXPENSE account.
+func (c *categoryResolver) resolve(category string, transfer, security, income bool) (string, string) {
+	key := fmt.Sprintf("%t|%t|%t|%s", transfer, security, income, category)
+	if result, ok := c.resolved[key]; ok {
+		return result[0], result[1]
+	}
+
+	guid, newAccount := c.lookup(category, transfer, security, income)
+	c.resolved[key] = [2]string{guid, newAccount}
+	if guid == "" && newAccount == "" && !slices.Contains(c.unresolved, category) {
+		c.unresolved = append(c.unresolved, category)
+	}
+	return guid, newAccount
+}
+
+// lookup tries each way of matching a category in turn
+func (c *categoryResolver) lookup(category string, transfer, security, income bool) (string, string) {
+	if guid, ok := c.explicit[category]; ok {
+		return guid, ""
+	}
+	name := strings.ToLower(category)
+	if guid, ok := c.byFullName[name]; ok {
+		return guid, ""
+	}
+
+	accountType := entity.AccountTypeExpense
+	if income {
+		accountType = entity.AccountTypeIncome
+	}
+	if category == importer.OpeningBalanceCategory {
+		accountType = entity.AccountTypeEquity
+	}
+	if !transfer && !security {
+		if top, ok := c.topLevel[accountType]; ok {
+			if guid, ok := c.byFullName[strings.ToLower(top+accountSeparator+category)]; ok {
+				return guid, ""
+			}
+		}
+	}
+
+	var match string
+	for fullName, guid := range c.byFullName {
+		if strings.HasSuffix(fullName, accountSeparator+name) {
+			if match != "" {
+				match = ""
+				break
+			}
+			match = guid
+		}
+	}
+	if match != "" {
+		return match, ""
+	}
+
+	if !c.createMissing || transfer || security {
+		return "", ""
+	}
+	top, ok := c.topLevel[accountType]
+	if !ok {
+		top = map[entity.AccountType]string{
+			entity.AccountTypeExpense: "Expenses",
+			entity.AccountTypeIncome:  "Income",
+			entity.AccountTypeEquity:  "Equity",
+		}[accountType]
+	}
+	fullName := top + accountSeparator + category
+	for _, proposed := range c.proposed {
+		if strings.EqualFold(proposed.FullName, fullName) {
+			return "", proposed.FullName
+		}
+	}
+	c.proposed = append(c.proposed, dto.ImportNewAccount{FullName: fullName, Type: string(accountType)})
+	return "", fullName
+}
+
+// createImportAccounts creates the requested accounts and their missing
+// parents in the given commodity, reusing accounts that already exist, and
+// returns their GUIDs by newAccountKey
+func (s *ImportService) createImportAccounts(ctx context.Context, commodityGUID string, accounts []dto.ImportNewAccount) (map[string]string, error) {
+	created := make(map[string]string, len(accounts))
+	if len(accounts) == 0 {
+		return created, nil
+	}
+
+	tree, fullNames, err := s.bookAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byFullName := make(map[string]string, len(fullNames))
+	for guid, name := range fullNames {
+		byFullName[strings.ToLower(name)] = guid
+	}
+
+	for _, na := range accounts {
+		parts := accountPath(na.FullName)
+		parentGUID := tree.rootGUID
+		path := ""
+		for _, part := range parts {
+			if path != "" {
+				path += accountSeparator
+			}
+			path += part
+			if guid, ok := byFullName[strings.ToLower(path)]; ok {
+				parentGUID = guid
+				continue
+			}
+
+			acc, err := s.accountService.CreateAccount(ctx, &dto.CreateAccountRequest{
+				Name:          part,
+				Type:          na.Type,
+				ParentGUID:    parentGUID,
+				CommodityGUID: commodityGUID,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("new account %s: %w", path, err)
+			}
+			byFullName[strings.ToLower(path)] = acc.GUID
+			parentGUID = acc.GUID
+		}
+		created[newAccountKey(na.FullName)] = parentGUID
+	}
+
+	return created, nil
+}
+
+// bookAccounts loads the accounts below the book root with their full names,
+// leaving out the root and the scheduled-transaction templates
+func (s *ImportService) bookAccounts(ctx context.Context) (*accountTree, map[string]string, error) {
+	rootGUID, err := s.accountRepo.FindRootGUID(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find root account: %w", err)
+	}
+	accounts, err := s.accountRepo.FindAll(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+
+	tree := newAccountTree(rootGUID, accounts)
+	fullNames := make(map[string]string, len(accounts))
+	for guid, name := range accountFullNames(accounts) {
+		if guid != rootGUID && tree.inBook(guid) {
+			fullNames[guid] = name
+		}
+	}
+	return tree, fullNames, nil
+}
+
+// accountPath splits a full account name into its trimmed, non-empty parts
+func accountPath(fullName string) []string {
+	var parts []string
+	for _, part := range strings.Split(fullName, accountSeparator) {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// newAccountKey normalizes a full account name for lookups
+func newAccountKey(fullName string) string {
+	return strings.ToLower(strings.Join(accountPath(fullName), accountSeparator))
+}
@@ -0,0 +1,475 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/shopspring/decimal"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
+	"github.com/udai-kiran/agentic-cash/pkg/importer"
+)
+
+const (
+	// suggestionWords is how many leading words of a description make two
+	// descriptions similar when learning rules
+	suggestionWords = 3
+	// suggestionShare is the share of similar transactions that must use the
+	// same counter account before a rule is suggested
+	suggestionShare = 0.8
+	// suggestionExamples bounds the example descriptions of a suggestion
+	suggestionExamples = 3
+)
+
+// compiledRule is an enabled import rule ready for matching
+type compiledRule struct {
+	rule         *entity.ImportRule
+	description  *regexp.Regexp
+	memo         *regexp.Regexp
+	counterparty string
+	iban         bool
+}
+
+// compileRule compiles a rule's patterns case-insensitively
+func compileRule(rule *entity.ImportRule) (*compiledRule, error) {
+	c := &compiledRule{rule: rule, counterparty: strings.TrimSpace(rule.Counterparty)}
+	var err error
+	if rule.DescriptionPattern != "" {
+		if c.description, err = regexp.Compile("(?i)" + rule.DescriptionPattern); err != nil {
+			return nil, validationError("invalid description_pattern: %v", err)
+		}
+	}
+	if rule.MemoPattern != "" {
+		if c.memo, err = regexp.Compile("(?i)" + rule.MemoPattern); err != nil {
+			return nil, validationError("invalid memo_pattern: %v", err)
+		}
+	}
+	if iban := importer.NormalizeIBAN(c.counterparty); iban != "" {
+		c.counterparty, c.iban = iban, true
+	}
+	return c, nil
+}
+
+// matches reports whether a statement line of the account meets every
+// condition of the rule
+func (c *compiledRule) matches(accountGUID string, tx *importer.Transaction) bool {
+	rule := c.rule
+	if rule.SourceAccountGUID != nil && *rule.SourceAccountGUID != accountGUID {
+		return false
+	}
+	if c.description != nil && !c.description.MatchString(tx.Description()) {
+		return false
+	}
+	if c.memo != nil && !c.memo.MatchString(tx.Memo) {
+		return false
+	}
+	if rule.MinAmount != nil && tx.Amount.LessThan(*rule.MinAmount) {
+		return false
+	}
+	if rule.MaxAmount != nil && tx.Amount.GreaterThan(*rule.MaxAmount) {
+		return false
+	}
+	if c.counterparty != "" {
+		if c.iban {
+			return tx.CounterpartyIBAN == c.counterparty
+		}
+		return strings.EqualFold(strings.TrimSpace(tx.Payee), c.counterparty)
+	}
+	return true
+}
+
+// applyRules sets the counter account, description and memo of each entry
+// from the first enabled rule that matches its statement line. Entries that
+// already carry a QIF category or splits keep them.
+func (s *ImportService) applyRules(ctx context.Context, accountGUID string, statement *importer.Statement, response *dto.ImportPreviewResponse) error {
+	rules, err := s.enabledRules(ctx)
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	for i, tx := range statement.Transactions {
+		entry := &response.Entries[i]
+		if entry.Category != "" || len(entry.Splits) > 0 {
+			continue
+		}
+		for _, c := range rules {
+			if !c.matches(accountGUID, tx) {
+				continue
+			}
+			rule := c.rule
+			entry.MatchedRuleID = &rule.ID
+			entry.MatchedRuleName = rule.Name
+			if rule.DestinationAccountGUID != nil && *rule.DestinationAccountGUID != accountGUID {
+				entry.CounterAccountGUID = *rule.DestinationAccountGUID
+			}
+			if rule.PayeeRewrite != nil && *rule.PayeeRewrite != entry.Description {
+				entry.BankDescription = entry.Description
+				entry.Description = *rule.PayeeRewrite
+			}
+			if rule.Memo != nil {
+				entry.Memo = *rule.Memo
+			}
+			break
+		}
+	}
+
+	return nil
+}
+
+// enabledRules loads and compiles the enabled rules in the order they apply
+func (s *ImportService) enabledRules(ctx context.Context) ([]*compiledRule, error) {
+	rules, err := s.ruleRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import rules: %w", err)
+	}
+
+	compiled := make([]*compiledRule, 0, len(rules))
+	for _, rule := range rules {
+		if !rule.Enabled {
+			continue
+		}
+		c, err := compileRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+		compiled = append(compiled, c)
+	}
+	return compiled, nil
+}
+
+// ListRules returns every import rule in the order they apply
+func (s *ImportService) ListRules(ctx context.Context) ([]dto.ImportRuleResponse, error) {
+	rules, err := s.ruleRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import rules: %w", err)
+	}
+
+	response := make([]dto.ImportRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		response = append(response, importRuleToResponse(rule))
+	}
+	return response, nil
+}
+
+// GetRule returns one import rule
+func (s *ImportService) GetRule(ctx context.Context, id int64) (*dto.ImportRuleResponse, error) {
+	rule, err := s.ruleRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := importRuleToResponse(rule)
+	return &response, nil
+}
+
+// CreateRule validates and stores a new import rule
+func (s *ImportService) CreateRule(ctx context.Context, req *dto.ImportRuleRequest) (*dto.ImportRuleResponse, error) {
+	rule, err := s.newImportRule(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Create(ctx, rule); err != nil {
+		return nil, importRuleError(err, rule.Name)
+	}
+
+	response := importRuleToResponse(rule)
+	return &response, nil
+}
+
+// UpdateRule replaces an import rule
+func (s *ImportService) UpdateRule(ctx context.Context, id int64, req *dto.ImportRuleRequest) (*dto.ImportRuleResponse, error) {
+	rule, err := s.newImportRule(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	rule.ID = id
+
+	if err := s.ruleRepo.Update(ctx, rule); err != nil {
+		return nil, importRuleError(err, rule.Name)
+	}
+
+	response := importRuleToResponse(rule)
+	return &response, nil
+}
+
+// DeleteRule removes an import rule
+func (s *ImportService) DeleteRule(ctx context.Context, id int64) error {
+	return s.ruleRepo.Delete(ctx, id)
+}
+
+// SuggestRules learns rules from past two-split transactions. Transactions
+// are grouped by the first words of their description, ignoring digits and
+// punctuation; a group with enough transactions that were nearly all booked
+// against one account becomes a suggestion, unless an existing rule already
+// matches its latest transaction.
+func (s *ImportService) SuggestRules(ctx context.Context, req *dto.RuleSuggestionRequest) ([]dto.RuleSuggestionResponse, error) {
+	months := req.Months
+	if months == 0 {
+		months = 12
+	}
+	minCount := req.MinCount
+	if minCount == 0 {
+		minCount = 3
+	}
+	if req.AccountGUID != "" {
+		if _, err := s.accountRepo.FindByGUID(ctx, req.AccountGUID); err != nil {
+			return nil, err
+		}
+	}
+
+	since := dateOnly(time.Now().UTC()).AddDate(0, -months, 0)
+	history, err := s.importRepo.FindCategorizedHistory(ctx, req.AccountGUID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction history: %w", err)
+	}
+
+	rules, err := s.enabledRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	_, fullNames, err := s.bookAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type group struct {
+		key     string
+		rows    []*entity.CategorizedSplit
+		counter map[string]int
+	}
+	groups := make(map[string]*group)
+	var order []*group
+	for _, row := range history {
+		key := descriptionKey(row.Description)
+		if key == "" {
+			continue
+		}
+		g, ok := groups[key]
+		if !ok {
+			g = &group{key: key, counter: make(map[string]int)}
+			groups[key] = g
+			order = append(order, g)
+		}
+		g.rows = append(g.rows, row)
+		g.counter[row.CounterAccountGUID]++
+	}
+
+	suggestions := make([]dto.RuleSuggestionResponse, 0)
+	for _, g := range order {
+		if len(g.rows) < minCount {
+			continue
+		}
+		var best string
+		for guid, count := range g.counter {
+			if best == "" || count > g.counter[best] || (count == g.counter[best] && guid < best) {
+				best = guid
+			}
+		}
+		if float64(g.counter[best]) < suggestionShare*float64(len(g.rows)) {
+			continue
+		}
+		name, ok := fullNames[best]
+		if !ok {
+			continue
+		}
+
+		// History is newest first, so the first row is the latest transaction
+		latest := g.rows[0]
+		line := &importer.Transaction{
+			Payee:  latest.Description,
+			Amount: gnucash.RationalToDecimal(latest.Split.QuantityNum, latest.Split.QuantityDenom),
+		}
+		covered := false
+		for _, c := range rules {
+			if c.matches(latest.Split.AccountGUID, line) {
+				covered = true
+				break
+			}
+		}
+		if covered {
+			continue
+		}
+
+		suggestion := dto.RuleSuggestionResponse{
+			Name:                   "Auto: " + g.key,
+			DescriptionPattern:     descriptionPattern(g.key),
+			DestinationAccountGUID: best,
+			DestinationAccountName: name,
+			MatchCount:             g.counter[best],
+			TotalCount:             len(g.rows),
+		}
+		if req.AccountGUID != "" {
+			suggestion.SourceAccountGUID = &req.AccountGUID
+		}
+		seen := make(map[string]bool)
+		for _, row := range g.rows {
+			if len(suggestion.Examples) == suggestionExamples {
+				break
+			}
+			if !seen[row.Description] {
+				seen[row.Description] = true
+				suggestion.Examples = append(suggestion.Examples, row.Description)
+			}
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].MatchCount > suggestions[j].MatchCount
+	})
+	return suggestions, nil
+}
+
+// newImportRule validates a request and fills in the defaults
+func (s *ImportService) newImportRule(ctx context.Context, req *dto.ImportRuleRequest) (*entity.ImportRule, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, validationError("name is required")
+	}
+
+	rule := &entity.ImportRule{
+		Name:               name,
+		Priority:           req.Priority,
+		Enabled:            req.Enabled == nil || *req.Enabled,
+		DescriptionPattern: req.DescriptionPattern,
+		MemoPattern:        req.MemoPattern,
+		Counterparty:       strings.TrimSpace(req.Counterparty),
+		PayeeRewrite:       req.PayeeRewrite,
+		Memo:               req.Memo,
+	}
+	if iban := importer.NormalizeIBAN(rule.Counterparty); iban != "" {
+		rule.Counterparty = iban
+	}
+
+	var err error
+	if rule.MinAmount, err = parseRuleAmount("min_amount", req.MinAmount); err != nil {
+		return nil, err
+	}
+	if rule.MaxAmount, err = parseRuleAmount("max_amount", req.MaxAmount); err != nil {
+		return nil, err
+	}
+	if rule.MinAmount != nil && rule.MaxAmount != nil && rule.MinAmount.GreaterThan(*rule.MaxAmount) {
+		return nil, validationError("min_amount must not exceed max_amount")
+	}
+	if _, err := compileRule(rule); err != nil {
+		return nil, err
+	}
+
+	if req.SourceAccountGUID != nil && *req.SourceAccountGUID != "" {
+		if _, err := s.accountRepo.FindByGUID(ctx, *req.SourceAccountGUID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, validationError("source account %s not found", *req.SourceAccountGUID)
+			}
+			return nil, fmt.Errorf("failed to load source account: %w", err)
+		}
+		rule.SourceAccountGUID = req.SourceAccountGUID
+	}
+	if req.DestinationAccountGUID != nil && *req.DestinationAccountGUID != "" {
+		account, err := s.accountRepo.FindByGUID(ctx, *req.DestinationAccountGUID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, validationError("destination account %s not found", *req.DestinationAccountGUID)
+			}
+			return nil, fmt.Errorf("failed to load destination account: %w", err)
+		}
+		if account.AccountType == entity.AccountTypeRoot || account.Placeholder {
+			return nil, validationError("destination account %s does not accept postings", account.Name)
+		}
+		rule.DestinationAccountGUID = req.DestinationAccountGUID
+	}
+
+	hasCondition := rule.DescriptionPattern != "" || rule.MemoPattern != "" || rule.MinAmount != nil ||
+		rule.MaxAmount != nil || rule.SourceAccountGUID != nil || rule.Counterparty != ""
+	if !hasCondition {
+		return nil, validationError("a rule needs at least one condition")
+	}
+	if rule.DestinationAccountGUID == nil && rule.PayeeRewrite == nil && rule.Memo == nil {
+		return nil, validationError("set destination_account_guid, payee_rewrite or memo")
+	}
+
+	return rule, nil
+}
+
+// parseRuleAmount reads an optional amount bound
+func parseRuleAmount(field string, value *string) (*decimal.Decimal, error) {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return nil, nil
+	}
+	amount, err := decimal.NewFromString(strings.TrimSpace(*value))
+	if err != nil {
+		return nil, validationError("invalid %s %q", field, *value)
+	}
+	return &amount, nil
+}
+
+// descriptionKey reduces a description to its first words in lower case,
+// dropping digits and punctuation, so that "AMAZON MKTPLACE 1234" and
+// "Amazon Mktplace 5678" share a key
+func descriptionKey(description string) string {
+	words := strings.FieldsFunc(strings.ToLower(description), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	if len(words) > suggestionWords {
+		words = words[:suggestionWords]
+	}
+	return strings.Join(words, " ")
+}
+
+// descriptionPattern builds a pattern matching descriptions that start with
+// the words of a key, with anything but letters between them
+func descriptionPattern(key string) string {
+	words := strings.Fields(key)
+	for i, word := range words {
+		words[i] = regexp.QuoteMeta(word)
+	}
+	return `^[^\pL]*` + strings.Join(words, `[^\pL]+`) + `(?:[^\pL]|$)`
+}
+
+// importRuleError explains a failed rule write
+func importRuleError(err error, name string) error {
+	if errors.Is(err, repository.ErrConflict) {
+		return fmt.Errorf("an import rule named %q already exists: %w", name, err)
+	}
+	if errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	return fmt.Errorf("failed to save import rule: %w", err)
+}
+
+// importRuleToResponse converts a rule for API responses
+func importRuleToResponse(rule *entity.ImportRule) dto.ImportRuleResponse {
+	response := dto.ImportRuleResponse{
+		ID:                     rule.ID,
+		Name:                   rule.Name,
+		Priority:               rule.Priority,
+		Enabled:                rule.Enabled,
+		DescriptionPattern:     rule.DescriptionPattern,
+		MemoPattern:            rule.MemoPattern,
+		SourceAccountGUID:      rule.SourceAccountGUID,
+		Counterparty:           rule.Counterparty,
+		DestinationAccountGUID: rule.DestinationAccountGUID,
+		PayeeRewrite:           rule.PayeeRewrite,
+		Memo:                   rule.Memo,
+		CreatedAt:              rule.CreatedAt,
+		UpdatedAt:              rule.UpdatedAt,
+	}
+	if rule.MinAmount != nil {
+		response.MinAmount = optionalString(rule.MinAmount.String())
+	}
+	if rule.MaxAmount != nil {
+		response.MaxAmount = optionalString(rule.MaxAmount.String())
+	}
+	return response
+}
@@ -0,0 +1,516 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
+	"github.com/udai-kiran/agentic-cash/pkg/importer"
+)
+
+// Import entry statuses
+const (
+	ImportStatusNew       = "new"
+	ImportStatusDuplicate = "duplicate"
+)
+
+// ImportService turns bank statements into GnuCash transactions. Files are
+// previewed first; the reviewed entries are then committed as transactions
+// between the imported account and a counter account or category splits.
+type ImportService struct {
+	importRepo         repository.ImportRepository
+	profileRepo        repository.CSVProfileRepository
+	ruleRepo           repository.ImportRuleRepository
+	accountRepo        repository.AccountRepository
+	accountService     *AccountService
+	transactionService *TransactionService
+}
+
+// NewImportService creates a new import service
+func NewImportService(
+	importRepo repository.ImportRepository,
+	profileRepo repository.CSVProfileRepository,
+	ruleRepo repository.ImportRuleRepository,
+	accountRepo repository.AccountRepository,
+	accountService *AccountService,
+	transactionService *TransactionService,
+) *ImportService {
+	return &ImportService{
+		importRepo:         importRepo,
+		profileRepo:        profileRepo,
+		ruleRepo:           ruleRepo,
+		accountRepo:        accountRepo,
+		accountService:     accountService,
+		transactionService: transactionService,
+	}
+}
+
+// PreviewOFX parses an OFX or QFX file and proposes a transaction for each
+// statement line, marking lines whose FITID is already imported as duplicates
+func (s *ImportService) PreviewOFX(ctx context.Context, req *dto.ImportPreviewRequest, r io.Reader) (*dto.ImportPreviewResponse, error) {
+	return s.previewStatements(ctx, req, r, "OFX", importer.ParseOFX)
+}
+
+// PreviewCAMT053 parses an ISO 20022 camt.053 statement and proposes a
+// transaction for each booked entry, marking entries whose account servicer
+// reference is already imported as duplicates
+func (s *ImportService) PreviewCAMT053(ctx context.Context, req *dto.ImportPreviewRequest, r io.Reader) (*dto.ImportPreviewResponse, error) {
+	return s.previewStatements(ctx, req, r, "camt.053", importer.ParseCAMT053)
+}
+
+// PreviewMT940 parses a SWIFT MT940 statement and proposes a transaction for
+// each statement line, marking lines whose bank reference is already
+// imported as duplicates
+func (s *ImportService) PreviewMT940(ctx context.Context, req *dto.ImportPreviewRequest, r io.Reader) (*dto.ImportPreviewResponse, error) {
+	return s.previewStatements(ctx, req, r, "MT940", importer.ParseMT940)
+}
+
+// previewStatements previews one statement of a file read by parse
+func (s *ImportService) previewStatements(
+	ctx context.Context,
+	req *dto.ImportPreviewRequest,
+	r io.Reader,
+	format string,
+	parse func(io.Reader) ([]*importer.Statement, error),
+) (*dto.ImportPreviewResponse, error) {
+	account, err := s.importAccount(ctx, req.AccountGUID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCounterAccount(ctx, req.CounterAccountGUID); err != nil {
+		return nil, err
+	}
+
+	statements, err := parse(r)
+	if err != nil {
+		return nil, validationError("invalid %s file: %v", format, err)
+	}
+	statement, err := pickStatement(statements, req.StatementAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.preview(ctx, account, statement, req.CounterAccountGUID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyRules(ctx, account.GUID, statement, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Commit creates a transaction for each reviewed entry in one database
+// transaction. Entries whose online id is already imported are skipped;
+// entries without one get the synthetic id of their date, amount and bank
+// description, so submitting the same entries twice books them once. The
+// request's new accounts are created first, so they remain when the entries
+// fail to commit; a retry reuses them.
+func (s *ImportService) Commit(ctx context.Context, req *dto.ImportCommitRequest) (*dto.ImportCommitResponse, error) {
+	account, err := s.importAccount(ctx, req.AccountGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	declared := make(map[string]bool, len(req.NewAccounts))
+	for _, na := range req.NewAccounts {
+		if len(accountPath(na.FullName)) == 0 {
+			return nil, validationError("new account name must not be empty")
+		}
+		declared[newAccountKey(na.FullName)] = true
+	}
+	for i, entry := range req.Entries {
+		if entry.CounterNewAccount != "" {
+			if !declared[newAccountKey(entry.CounterNewAccount)] {
+				return nil, validationError("entry %d: %s is not in new_accounts", i+1, entry.CounterNewAccount)
+			}
+		}
+		for j, split := range entry.Splits {
+			if split.NewAccount != "" {
+				if !declared[newAccountKey(split.NewAccount)] {
+					return nil, validationError("entry %d, split %d: %s is not in new_accounts", i+1, j+1, split.NewAccount)
+				}
+			}
+		}
+	}
+
+	created, err := s.createImportAccounts(ctx, *account.CommodityGUID, req.NewAccounts)
+	if err != nil {
+		return nil, err
+	}
+
+	enterDate := time.Now().UTC().Truncate(time.Second)
+	imports := make([]*entity.ImportedTransaction, 0, len(req.Entries))
+	occurrences := make(map[string]int)
+	for i, entry := range req.Entries {
+		amount, err := decimal.NewFromString(entry.Amount)
+		if err != nil {
+			return nil, validationError("entry %d: invalid amount %q", i+1, entry.Amount)
+		}
+		onlineID := entry.OnlineID
+		if onlineID == "" {
+			postDate, err := time.Parse("2006-01-02", entry.PostDate)
+			if err != nil {
+				return nil, validationError("entry %d: invalid post_date %q", i+1, entry.PostDate)
+			}
+			description := entry.Description
+			if entry.BankDescription != "" {
+				description = entry.BankDescription
+			}
+			key := duplicateKey(postDate, amount, description)
+			onlineID = syntheticOnlineID(key, occurrences[key])
+			occurrences[key]++
+		}
+		splits := []dto.SplitRequest{
+			{AccountGUID: account.GUID, Value: amount.String(), Memo: optionalString(entry.Memo)},
+		}
+
+		if len(entry.Splits) == 0 {
+			counter := entry.CounterAccountGUID
+			if counter == "" && entry.CounterNewAccount != "" {
+				counter = created[newAccountKey(entry.CounterNewAccount)]
+			}
+			if counter == "" {
+				counter = req.CounterAccountGUID
+			}
+			if counter == "" {
+				return nil, validationError("entry %d: counter_account_guid is required", i+1)
+			}
+			if counter == account.GUID {
+				return nil, validationError("entry %d: counter account must differ from the imported account", i+1)
+			}
+			splits = append(splits, dto.SplitRequest{AccountGUID: counter, Value: amount.Neg().String()})
+		}
+		for j, split := range entry.Splits {
+			guid := split.AccountGUID
+			if guid == "" && split.NewAccount != "" {
+				guid = created[newAccountKey(split.NewAccount)]
+			}
+			if guid == "" {
+				return nil, validationError("entry %d, split %d: account_guid or new_account is required", i+1, j+1)
+			}
+			if guid == account.GUID {
+				return nil, validationError("entry %d, split %d: account must differ from the imported account", i+1, j+1)
+			}
+			value, err := decimal.NewFromString(split.Amount)
+			if err != nil {
+				return nil, validationError("entry %d, split %d: invalid amount %q", i+1, j+1, split.Amount)
+			}
+			splits = append(splits, dto.SplitRequest{
+				AccountGUID: guid,
+				Value:       value.Neg().String(),
+				Quantity:    split.Quantity,
+				Memo:        optionalString(split.Memo),
+			})
+		}
+
+		txReq := &dto.CreateTransactionRequest{
+			CurrencyGUID: *account.CommodityGUID,
+			Num:          optionalString(entry.Num),
+			PostDate:     entry.PostDate,
+			Description:  optionalString(entry.Description),
+			Splits:       splits,
+		}
+		tx, err := s.transactionService.buildTransaction(ctx, txReq)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+
+		tx.GUID = gnucash.NewGUID()
+		tx.EnterDate = enterDate
+		for _, split := range tx.Splits {
+			split.GUID = gnucash.NewGUID()
+			split.TxGUID = tx.GUID
+		}
+		imports = append(imports, &entity.ImportedTransaction{
+			Transaction:      tx,
+			OnlineID:         onlineID,
+			CounterpartyIBAN: importer.NormalizeIBAN(entry.CounterpartyIBAN),
+			BankDescription:  entry.BankDescription,
+		})
+	}
+
+	skipped, err := s.importRepo.Commit(ctx, account.GUID, imports)
+	if err != nil {
+		return nil, fmt.Errorf("failed to commit import: %w", err)
+	}
+
+	isSkipped := make(map[*entity.ImportedTransaction]bool, len(skipped))
+	response := &dto.ImportCommitResponse{
+		Created: make([]dto.ImportedEntryResponse, 0, len(imports)-len(skipped)),
+		Skipped: make([]string, 0, len(skipped)),
+	}
+	for _, imp := range skipped {
+		isSkipped[imp] = true
+		response.Skipped = append(response.Skipped, imp.OnlineID)
+	}
+	for i, imp := range imports {
+		if isSkipped[imp] {
+			continue
+		}
+		response.Created = append(response.Created, dto.ImportedEntryResponse{
+			Line:            i + 1,
+			OnlineID:        imp.OnlineID,
+			TransactionGUID: imp.Transaction.GUID,
+		})
+	}
+
+	return response, nil
+}
+
+// preview proposes a transaction for each statement line. Lines from a
+// counterparty IBAN seen in earlier imports get that import's counter
+// account instead of the default.
+func (s *ImportService) preview(ctx context.Context, account *entity.Account, statement *importer.Statement, counterGUID string) (*dto.ImportPreviewResponse, error) {
+	response := &dto.ImportPreviewResponse{
+		Format:             statement.Format,
+		AccountGUID:        account.GUID,
+		AccountName:        account.Name,
+		Currency:           statement.Currency,
+		StatementAccountID: statement.AccountID,
+		Entries:            make([]dto.ImportEntryResponse, 0, len(statement.Transactions)),
+	}
+	if statement.StartDate != nil {
+		response.StartDate = statement.StartDate.Format("2006-01-02")
+	}
+	if statement.EndDate != nil {
+		response.EndDate = statement.EndDate.Format("2006-01-02")
+	}
+	if statement.LedgerBalance != nil {
+		response.LedgerBalance = statement.LedgerBalance.String()
+	}
+	if statement.Currency != "" && !strings.EqualFold(statement.Currency, account.CommodityMnemonic) {
+		response.Warnings = append(response.Warnings, fmt.Sprintf(
+			"statement currency %s differs from account %s (%s)", statement.Currency, account.Name, account.CommodityMnemonic))
+	}
+
+	onlineIDs := lineOnlineIDs(statement.Transactions)
+	matches, err := s.findDuplicates(ctx, account.GUID, statement.Transactions, onlineIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var ibans []string
+	for _, tx := range statement.Transactions {
+		if tx.CounterpartyIBAN != "" {
+			ibans = append(ibans, tx.CounterpartyIBAN)
+		}
+	}
+	counterByIBAN, err := s.importRepo.FindCounterAccountsByIBAN(ctx, ibans)
+	if err != nil {
+		return nil, fmt.Errorf("failed to match counterparty IBANs: %w", err)
+	}
+
+	for i, tx := range statement.Transactions {
+		entry := dto.ImportEntryResponse{
+			Line:               i + 1,
+			Status:             ImportStatusNew,
+			OnlineID:           onlineIDs[i],
+			PostDate:           tx.Date.Format("2006-01-02"),
+			Amount:             tx.Amount.String(),
+			Description:        tx.Description(),
+			Memo:               tx.Memo,
+			Num:                tx.CheckNum,
+			Type:               tx.Type,
+			CounterpartyIBAN:   tx.CounterpartyIBAN,
+			CounterAccountGUID: counterGUID,
+		}
+		if tx.ValueDate != nil {
+			entry.ValueDate = tx.ValueDate.Format("2006-01-02")
+		}
+		if counter, ok := counterByIBAN[tx.CounterpartyIBAN]; ok && counter != account.GUID {
+			entry.CounterAccountGUID = counter
+		}
+		if match, ok := matches[i]; ok {
+			entry.Status = ImportStatusDuplicate
+			entry.MatchedTransactionGUID = match
+		}
+
+		if entry.Status == ImportStatusDuplicate {
+			response.DuplicateCount++
+		} else {
+			response.NewCount++
+		}
+		response.Entries = append(response.Entries, entry)
+	}
+
+	return response, nil
+}
+
+// findDuplicates finds the statement lines that are already in the account,
+// keyed by line index with the matching transaction GUID (empty for a repeat
+// of an earlier line). Lines are matched by online_id, the bank's identifier
+// or the synthetic one from lineOnlineIDs. Lines without a bank identifier
+// that are not found by it, such as entries made by hand, are then matched by
+// post date, amount and description, each existing split matching at most
+// one line. An import whose description a rule rewrote is matched by its
+// stored bank description as well.
+func (s *ImportService) findDuplicates(ctx context.Context, accountGUID string, lines []*importer.Transaction, onlineIDs []string) (map[int]string, error) {
+	matches := make(map[int]string)
+
+	existing, err := s.importRepo.FindByOnlineIDs(ctx, accountGUID, onlineIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find imported entries: %w", err)
+	}
+
+	var start, end time.Time
+	matched := make(map[string]bool)
+	seen := make(map[string]bool)
+	for i, tx := range lines {
+		id := onlineIDs[i]
+		if match, ok := existing[id]; ok {
+			matches[i] = match
+			matched[match] = true
+		} else if seen[id] {
+			matches[i] = ""
+		}
+		seen[id] = true
+		if _, ok := matches[i]; ok || tx.ID != "" {
+			continue
+		}
+		if start.IsZero() || tx.Date.Before(start) {
+			start = tx.Date
+		}
+		if tx.Date.After(end) {
+			end = tx.Date
+		}
+	}
+	if start.IsZero() {
+		return matches, nil
+	}
+
+	splits, err := s.importRepo.FindAccountSplits(ctx, accountGUID, start, endOfDay(end))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find existing splits: %w", err)
+	}
+	candidates := make(map[string][]string)
+	for _, row := range splits {
+		if matched[row.Split.TxGUID] {
+			continue
+		}
+		amount := gnucash.RationalToDecimal(row.Split.QuantityNum, row.Split.QuantityDenom)
+		key := duplicateKey(row.PostDate, amount, entity.StringOrEmpty(row.Description))
+		candidates[key] = append(candidates[key], row.Split.TxGUID)
+		if row.BankDescription != nil {
+			if bankKey := duplicateKey(row.PostDate, amount, *row.BankDescription); bankKey != key {
+				candidates[bankKey] = append(candidates[bankKey], row.Split.TxGUID)
+			}
+		}
+	}
+
+	for i, tx := range lines {
+		if _, ok := matches[i]; ok || tx.ID != "" {
+			continue
+		}
+		key := duplicateKey(tx.Date, tx.Amount, tx.Description())
+		for len(candidates[key]) > 0 {
+			found := candidates[key][0]
+			candidates[key] = candidates[key][1:]
+			if !matched[found] {
+				matches[i] = found
+				matched[found] = true
+				break
+			}
+		}
+	}
+
+	return matches, nil
+}
+
+// lineOnlineIDs returns the online id of each statement line: the bank's
+// identifier when it has one, otherwise a synthetic id from the line's post
+// date, amount and description and how many identical lines precede it
+func lineOnlineIDs(lines []*importer.Transaction) []string {
+	ids := make([]string, len(lines))
+	occurrences := make(map[string]int)
+	for i, tx := range lines {
+		if tx.ID != "" {
+			ids[i] = tx.ID
+			continue
+		}
+		key := duplicateKey(tx.Date, tx.Amount, tx.Description())
+		ids[i] = syntheticOnlineID(key, occurrences[key])
+		occurrences[key]++
+	}
+	return ids
+}
+
+// syntheticOnlineID derives a stable online id for the occurrence-th line with
+// the given duplicate key
+func syntheticOnlineID(key string, occurrence int) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d", key, occurrence)))
+	return "gen:" + hex.EncodeToString(sum[:16])
+}
+
+// duplicateKey identifies a line by post date, amount and description
+func duplicateKey(date time.Time, amount decimal.Decimal, description string) string {
+	return date.Format("2006-01-02") + "|" + amount.String() + "|" + strings.ToLower(strings.TrimSpace(description))
+}
+
+// importAccount loads the account a statement is imported into
+func (s *ImportService) importAccount(ctx context.Context, guid string) (*entity.Account, error) {
+	account, err := s.accountRepo.FindByGUID(ctx, guid)
+	if err != nil {
+		return nil, err
+	}
+	if account.AccountType == entity.AccountTypeRoot || account.Placeholder {
+		return nil, validationError("account %s does not accept postings", account.Name)
+	}
+	if account.CommodityGUID == nil {
+		return nil, validationError("account %s has no commodity", account.Name)
+	}
+	return account, nil
+}
+
+// checkCounterAccount verifies that an optional counter account exists
+func (s *ImportService) checkCounterAccount(ctx context.Context, guid string) error {
+	if guid == "" {
+		return nil
+	}
+	if _, err := s.accountRepo.FindByGUID(ctx, guid); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return validationError("counter account %s not found", guid)
+		}
+		return fmt.Errorf("failed to load counter account: %w", err)
+	}
+	return nil
+}
+
+// pickStatement selects the statement to import from a file, by the bank's
+// account id when the file holds more than one
+func pickStatement(statements []*importer.Statement, accountID string) (*importer.Statement, error) {
+	if accountID != "" {
+		for _, statement := range statements {
+			if statement.AccountID == accountID {
+				return statement, nil
+			}
+		}
+		return nil, validationError("the file has no statement for account %s", accountID)
+	}
+	if len(statements) > 1 {
+		ids := make([]string, 0, len(statements))
+		for _, statement := range statements {
+			ids = append(ids, statement.AccountID)
+		}
+		return nil, validationError("the file has statements for accounts %s; choose one with statement_account_id", strings.Join(ids, ", "))
+	}
+	return statements[0], nil
+}
+
+// optionalString returns nil for an empty string
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
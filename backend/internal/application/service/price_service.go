@@ -0,0 +1,345 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
+)
+
+// maxPriceDecimals caps the precision stored for a price value
+const maxPriceDecimals = 9
+
+// PriceService handles price database business logic
+type PriceService struct {
+	priceRepo     repository.PriceRepository
+	commodityRepo repository.CommodityRepository
+}
+
+// NewPriceService creates a new price service
+func NewPriceService(priceRepo repository.PriceRepository, commodityRepo repository.CommodityRepository) *PriceService {
+	return &PriceService{
+		priceRepo:     priceRepo,
+		commodityRepo: commodityRepo,
+	}
+}
+
+// GetLatest returns the most recent price of a commodity, optionally in a given currency
+func (s *PriceService) GetLatest(ctx context.Context, commodityRef, currencyRef string) (*entity.Price, error) {
+	commodityGUID, currencyGUID, err := s.resolvePair(ctx, commodityRef, currencyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.priceRepo.FindLatest(ctx, commodityGUID, currencyGUID)
+}
+
+// GetAsOf returns the most recent price of a commodity on or before date (YYYY-MM-DD)
+func (s *PriceService) GetAsOf(ctx context.Context, commodityRef, currencyRef, date string) (*entity.Price, error) {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, validationError("invalid date format, use YYYY-MM-DD")
+	}
+
+	commodityGUID, currencyGUID, err := s.resolvePair(ctx, commodityRef, currencyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.priceRepo.FindAsOf(ctx, commodityGUID, currencyGUID, endOfDay(day))
+}
+
+// GetHistory returns the prices of a commodity within an optional date range
+func (s *PriceService) GetHistory(ctx context.Context, commodityRef, currencyRef string, startDate, endDate *time.Time) ([]*entity.Price, error) {
+	commodityGUID, currencyGUID, err := s.resolvePair(ctx, commodityRef, currencyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if endDate != nil {
+		end := endOfDay(*endDate)
+		endDate = &end
+	}
+
+	return s.priceRepo.FindHistory(ctx, commodityGUID, currencyGUID, startDate, endDate)
+}
+
+// CreatePrice adds a price using the num/denom, source and type conventions of GnuCash
+func (s *PriceService) CreatePrice(ctx context.Context, req *dto.CreatePriceRequest) (*entity.Price, error) {
+	commodity, err := resolveCommodityRef(ctx, s.commodityRepo, req.Commodity)
+	if err != nil {
+		return nil, err
+	}
+	currency, err := resolveCommodityRef(ctx, s.commodityRepo, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if currency.Namespace != "CURRENCY" {
+		return nil, validationError("%s is not a currency", currency.Mnemonic)
+	}
+	if commodity.GUID == currency.GUID {
+		return nil, validationError("commodity and currency must differ")
+	}
+
+	value, err := decimal.NewFromString(req.Value)
+	if err != nil || !value.IsPositive() {
+		return nil, validationError("value must be a positive number")
+	}
+	valueNum, valueDenom, err := priceToRational(value, int64(currency.Fraction))
+	if err != nil {
+		return nil, err
+	}
+
+	date := time.Now()
+	if req.Date != "" {
+		date, err = time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			return nil, validationError("invalid date format, use YYYY-MM-DD")
+		}
+	}
+
+	price := &entity.Price{
+		GUID:              gnucash.NewGUID(),
+		CommodityGUID:     commodity.GUID,
+		CommodityMnemonic: commodity.Mnemonic,
+		CurrencyGUID:      currency.GUID,
+		CurrencyMnemonic:  currency.Mnemonic,
+		Date:              gnucash.NeutralTime(date),
+		Source:            req.Source,
+		Type:              req.Type,
+		ValueNum:          valueNum,
+		ValueDenom:        valueDenom,
+	}
+	if price.Source == "" {
+		price.Source = entity.PriceSourceUser
+	}
+	if price.Type == "" {
+		price.Type = entity.PriceTypeLast
+	}
+
+	if err := s.priceRepo.Create(ctx, price); err != nil {
+		return nil, fmt.Errorf("failed to create price: %w", err)
+	}
+
+	return price, nil
+}
+
+// resolvePair resolves a commodity reference and an optional currency reference to GUIDs
+func (s *PriceService) resolvePair(ctx context.Context, commodityRef, currencyRef string) (string, string, error) {
+	commodity, err := resolveCommodityRef(ctx, s.commodityRepo, commodityRef)
+	if err != nil {
+		return "", "", err
+	}
+
+	if currencyRef == "" {
+		return commodity.GUID, "", nil
+	}
+
+	currency, err := resolveCommodityRef(ctx, s.commodityRepo, currencyRef)
+	if err != nil {
+		return "", "", err
+	}
+
+	return commodity.GUID, currency.GUID, nil
+}
+
+// resolveCommodityRef looks a commodity up by GUID or, failing that, by mnemonic
+func resolveCommodityRef(ctx context.Context, commodityRepo repository.CommodityRepository, ref string) (*entity.Commodity, error) {
+	if ref == "" {
+		return nil, validationError("a commodity is required")
+	}
+
+	if isGUID(ref) {
+		commodity, err := commodityRepo.FindByGUID(ctx, ref)
+		if err == nil {
+			return commodity, nil
+		}
+		if !errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	commodities, err := commodityRepo.FindByMnemonic(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	switch len(commodities) {
+	case 0:
+		return nil, validationError("commodity %s not found", ref)
+	case 1:
+		return commodities[0], nil
+	default:
+		return nil, validationError("commodity %s is ambiguous across namespaces; use its GUID", ref)
+	}
+}
+
+// priceToRational converts a price to num/denom, using a power-of-ten
+// denominator no smaller than the currency fraction
+func priceToRational(value decimal.Decimal, currencyFraction int64) (int64, int64, error) {
+	decimals := int32(0)
+	if value.Exponent() < 0 {
+		decimals = -value.Exponent()
+	}
+	if decimals > maxPriceDecimals {
+		value = value.Round(maxPriceDecimals)
+		decimals = maxPriceDecimals
+	}
+
+	denom := decimal.New(1, decimals).IntPart()
+	if denom < currencyFraction {
+		denom = currencyFraction
+	}
+
+	num, ok := gnucash.DecimalToRationalExact(value, denom)
+	if !ok {
+		return 0, 0, validationError("value %s cannot be represented", value.String())
+	}
+	return num, denom, nil
+}
+
+// isGUID reports whether s looks like a GnuCash GUID
+func isGUID(s string) bool {
+	if len(s) != 32 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// endOfDay returns the last second of the given date in UTC
+func endOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, time.UTC)
+}
+
+// Rate methods reported alongside a converted amount
+const (
+	RateMethodIdentity     = "identity"
+	RateMethodDirect       = "direct"
+	RateMethodInverse      = "inverse"
+	RateMethodTriangulated = "triangulated"
+)
+
+// exchangeRate is a conversion factor between two commodities
+type exchangeRate struct {
+	Rate   decimal.Decimal
+	Method string
+	Via    string
+	Date   time.Time
+}
+
+// rateTable answers conversion queries from a snapshot of the price database
+type rateTable struct {
+	quotes    map[string]map[string]*entity.Price
+	quotedIn  map[string]map[string]*entity.Price
+	mnemonics map[string]string
+}
+
+// newRateTable indexes the latest price of each commodity/currency pair
+func newRateTable(prices []*entity.Price) *rateTable {
+	t := &rateTable{
+		quotes:    make(map[string]map[string]*entity.Price),
+		quotedIn:  make(map[string]map[string]*entity.Price),
+		mnemonics: make(map[string]string),
+	}
+
+	for _, p := range prices {
+		if p.ValueNum == 0 || p.ValueDenom == 0 {
+			continue
+		}
+		if t.quotes[p.CommodityGUID] == nil {
+			t.quotes[p.CommodityGUID] = make(map[string]*entity.Price)
+		}
+		if t.quotedIn[p.CurrencyGUID] == nil {
+			t.quotedIn[p.CurrencyGUID] = make(map[string]*entity.Price)
+		}
+		t.quotes[p.CommodityGUID][p.CurrencyGUID] = p
+		t.quotedIn[p.CurrencyGUID][p.CommodityGUID] = p
+		t.mnemonics[p.CommodityGUID] = p.CommodityMnemonic
+		t.mnemonics[p.CurrencyGUID] = p.CurrencyMnemonic
+	}
+
+	return t
+}
+
+// leg returns the rate from one commodity to another using a single price,
+// quoted either way round
+func (t *rateTable) leg(from, to string) (*exchangeRate, bool) {
+	if p, ok := t.quotes[from][to]; ok {
+		return &exchangeRate{
+			Rate:   gnucash.RationalToDecimal(p.ValueNum, p.ValueDenom),
+			Method: RateMethodDirect,
+			Date:   p.Date,
+		}, true
+	}
+
+	if p, ok := t.quotes[to][from]; ok {
+		return &exchangeRate{
+			Rate:   decimal.NewFromInt(p.ValueDenom).Div(decimal.NewFromInt(p.ValueNum)),
+			Method: RateMethodInverse,
+			Date:   p.Date,
+		}, true
+	}
+
+	return nil, false
+}
+
+// Rate returns the factor converting an amount of from into to. It tries a
+// direct price, then an inverse one, then a path through one intermediate
+// commodity, preferring the path whose oldest price is most recent.
+func (t *rateTable) Rate(from, to string) (*exchangeRate, bool) {
+	if from == to {
+		return &exchangeRate{Rate: decimal.NewFromInt(1), Method: RateMethodIdentity}, true
+	}
+
+	if rate, ok := t.leg(from, to); ok {
+		return rate, true
+	}
+
+	var best *exchangeRate
+	var bestVia string
+	try := func(via string) {
+		if via == to {
+			return
+		}
+		first, ok := t.leg(from, via)
+		if !ok {
+			return
+		}
+		second, ok := t.leg(via, to)
+		if !ok {
+			return
+		}
+
+		date := first.Date
+		if second.Date.Before(date) {
+			date = second.Date
+		}
+		if best != nil && (date.Before(best.Date) || (date.Equal(best.Date) && via > bestVia)) {
+			return
+		}
+
+		best = &exchangeRate{
+			Rate:   first.Rate.Mul(second.Rate),
+			Method: RateMethodTriangulated,
+			Via:    t.mnemonics[via],
+			Date:   date,
+		}
+		bestVia = via
+	}
+
+	for via := range t.quotes[from] {
+		try(via)
+	}
+	for via := range t.quotedIn[from] {
+		try(via)
+	}
+
+	return best, best != nil
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
+)
+
+// ReconciliationService handles reconciling accounts against statements. A
+// reconciliation in progress is kept the way GnuCash postpones one, in the
+// account's reconcile-info slots, so it can be resumed from either program.
+type ReconciliationService struct {
+	reconcileRepo repository.ReconciliationRepository
+	accountRepo   repository.AccountRepository
+}
+
+// NewReconciliationService creates a new reconciliation service
+func NewReconciliationService(reconcileRepo repository.ReconciliationRepository, accountRepo repository.AccountRepository) *ReconciliationService {
+	return &ReconciliationService{
+		reconcileRepo: reconcileRepo,
+		accountRepo:   accountRepo,
+	}
+}
+
+// GetReconcileInfo returns an account's last reconcile date, reconciled
+// balance and any reconciliation in progress
+func (s *ReconciliationService) GetReconcileInfo(ctx context.Context, accountGUID string) (*dto.ReconcileInfoResponse, error) {
+	account, err := s.accountRepo.FindByGUID(ctx, accountGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := s.reconcileRepo.GetInfo(ctx, accountGUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reconcile info: %w", err)
+	}
+
+	response := &dto.ReconcileInfoResponse{
+		AccountGUID:       account.GUID,
+		AccountName:       account.Name,
+		ReconciledBalance: naturalSign(account, info.ReconciledBalance).StringFixed(2),
+	}
+	if info.LastDate != nil {
+		response.LastReconcileDate = info.LastDate.Format("2006-01-02")
+	}
+	if info.Postponed != nil {
+		response.InProgress = &dto.ReconcileStatementBrief{
+			StatementDate: info.Postponed.Date.Format("2006-01-02"),
+			EndingBalance: naturalSign(account, info.Postponed.EndingBalance).StringFixed(2),
+		}
+	}
+	return response, nil
+}
+
+// StartReconciliation begins (or restarts) reconciling an account against a
+// statement and returns the splits to tick off
+func (s *ReconciliationService) StartReconciliation(ctx context.Context, accountGUID string, req *dto.StartReconciliationRequest) (*dto.ReconcileSessionResponse, error) {
+	account, err := s.accountRepo.FindByGUID(ctx, accountGUID)
+	if err != nil {
+		return nil, err
+	}
+	if account.AccountType == entity.AccountTypeRoot || account.Placeholder {
+		return nil, validationError("account %s cannot be reconciled", account.Name)
+	}
+
+	date, err := time.Parse("2006-01-02", req.StatementDate)
+	if err != nil {
+		return nil, validationError("invalid statement_date format, use YYYY-MM-DD")
+	}
+	ending, err := decimal.NewFromString(req.EndingBalance)
+	if err != nil {
+		return nil, validationError("invalid ending_balance %q", req.EndingBalance)
+	}
+	if _, ok := gnucash.DecimalToRationalExact(ending, accountDenom(account)); !ok {
+		return nil, validationError("ending_balance %s has more precision than account %s allows", req.EndingBalance, account.Name)
+	}
+
+	statement := &entity.ReconcileStatement{
+		Date:          endOfDay(date),
+		EndingBalance: naturalSign(account, ending),
+	}
+	if err := s.reconcileRepo.SavePostponed(ctx, accountGUID, statement); err != nil {
+		return nil, fmt.Errorf("failed to start reconciliation: %w", err)
+	}
+
+	return s.session(ctx, account, statement)
+}
+
+// GetSession returns the reconciliation in progress for an account
+func (s *ReconciliationService) GetSession(ctx context.Context, accountGUID string) (*dto.ReconcileSessionResponse, error) {
+	account, statement, err := s.inProgress(ctx, accountGUID)
+	if err != nil {
+		return nil, err
+	}
+	return s.session(ctx, account, statement)
+}
+
+// ToggleSplits marks splits as cleared, or back to new, within the
+// reconciliation in progress
+func (s *ReconciliationService) ToggleSplits(ctx context.Context, accountGUID string, req *dto.ToggleReconcileSplitsRequest) (*dto.ReconcileSessionResponse, error) {
+	account, statement, err := s.inProgress(ctx, accountGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(req.SplitGUIDs))
+	splitGUIDs := make([]string, 0, len(req.SplitGUIDs))
+	for _, guid := range req.SplitGUIDs {
+		if !seen[guid] {
+			seen[guid] = true
+			splitGUIDs = append(splitGUIDs, guid)
+		}
+	}
+
+	if err := s.reconcileRepo.SetCleared(ctx, accountGUID, splitGUIDs, req.Cleared); err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return nil, validationError("every split must be an unreconciled split of account %s", account.Name)
+		}
+		return nil, fmt.Errorf("failed to update splits: %w", err)
+	}
+
+	return s.session(ctx, account, statement)
+}
+
+// FinishReconciliation marks the cleared splits as reconciled. It is refused
+// while the cleared balance differs from the statement's ending balance.
+func (s *ReconciliationService) FinishReconciliation(ctx context.Context, accountGUID string) (*dto.ReconcileInfoResponse, error) {
+	account, statement, err := s.inProgress(ctx, accountGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.session(ctx, account, statement)
+	if err != nil {
+		return nil, err
+	}
+	if !session.Balanced {
+		return nil, validationError("the cleared balance differs from the statement by %s", session.Difference)
+	}
+
+	if err := s.reconcileRepo.Finish(ctx, accountGUID, statement); err != nil {
+		if errors.Is(err, repository.ErrConflict) || errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to finish reconciliation: %w", err)
+	}
+
+	return s.GetReconcileInfo(ctx, accountGUID)
+}
+
+// CancelReconciliation drops the reconciliation in progress. Splits already
+// marked cleared stay cleared, as they do in GnuCash.
+func (s *ReconciliationService) CancelReconciliation(ctx context.Context, accountGUID string) error {
+	if _, _, err := s.inProgress(ctx, accountGUID); err != nil {
+		return err
+	}
+	if err := s.reconcileRepo.ClearPostponed(ctx, accountGUID); err != nil {
+		return fmt.Errorf("failed to cancel reconciliation: %w", err)
+	}
+	return nil
+}
+
+// inProgress loads an account and the statement it is being reconciled against
+func (s *ReconciliationService) inProgress(ctx context.Context, accountGUID string) (*entity.Account, *entity.ReconcileStatement, error) {
+	account, err := s.accountRepo.FindByGUID(ctx, accountGUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info, err := s.reconcileRepo.GetInfo(ctx, accountGUID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get reconcile info: %w", err)
+	}
+	if info.Postponed == nil {
+		return nil, nil, validationError("account %s has no reconciliation in progress", account.Name)
+	}
+
+	return account, info.Postponed, nil
+}
+
+// session lists the unreconciled splits up to the statement date with the
+// running cleared balance
+func (s *ReconciliationService) session(ctx context.Context, account *entity.Account, statement *entity.ReconcileStatement) (*dto.ReconcileSessionResponse, error) {
+	info, err := s.reconcileRepo.GetInfo(ctx, account.GUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reconcile info: %w", err)
+	}
+
+	splits, err := s.reconcileRepo.FindUnreconciledSplits(ctx, account.GUID, statement.Date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unreconciled splits: %w", err)
+	}
+
+	cleared := info.ReconciledBalance
+	rows := make([]dto.ReconcileSplitResponse, 0, len(splits))
+	for _, row := range splits {
+		amount := gnucash.RationalToDecimal(row.Split.QuantityNum, row.Split.QuantityDenom)
+		isCleared := row.Split.ReconcileState == entity.ReconcileStateCleared
+		if isCleared {
+			cleared = cleared.Add(amount)
+		}
+		rows = append(rows, dto.ReconcileSplitResponse{
+			SplitGUID:             row.Split.GUID,
+			TxGUID:                row.Split.TxGUID,
+			PostDate:              row.PostDate.Format("2006-01-02"),
+			Num:                   row.Num,
+			Description:           row.Description,
+			Memo:                  row.Split.Memo,
+			Amount:                naturalSign(account, amount).StringFixed(2),
+			Cleared:               isCleared,
+			RunningClearedBalance: naturalSign(account, cleared).StringFixed(2),
+		})
+	}
+
+	difference := naturalSign(account, statement.EndingBalance.Sub(cleared))
+	return &dto.ReconcileSessionResponse{
+		AccountGUID:     account.GUID,
+		AccountName:     account.Name,
+		StatementDate:   statement.Date.Format("2006-01-02"),
+		EndingBalance:   naturalSign(account, statement.EndingBalance).StringFixed(2),
+		StartingBalance: naturalSign(account, info.ReconciledBalance).StringFixed(2),
+		ClearedBalance:  naturalSign(account, cleared).StringFixed(2),
+		Difference:      difference.StringFixed(2),
+		Balanced:        difference.IsZero(),
+		Splits:          rows,
+	}, nil
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/udai-kiran/agentic-cash/pkg/logger"
+)
+
+// ScheduledTransactionRunner periodically posts due occurrences of scheduled
+// transactions that are marked for automatic creation
+type ScheduledTransactionRunner struct {
+	sxService *ScheduledTransactionService
+	interval  time.Duration
+	stopChan  chan struct{}
+}
+
+// NewScheduledTransactionRunner creates a new scheduled transaction runner
+func NewScheduledTransactionRunner(sxService *ScheduledTransactionService, interval time.Duration) *ScheduledTransactionRunner {
+	return &ScheduledTransactionRunner{
+		sxService: sxService,
+		interval:  interval,
+		stopChan:  make(chan struct{}),
+	}
+}
+
+// Start begins the periodic auto-create process
+func (r *ScheduledTransactionRunner) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	logger.Info("Scheduled transaction runner started", "interval", r.interval)
+
+	// Run immediately on start
+	r.run(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			r.run(ctx)
+		case <-r.stopChan:
+			logger.Info("Scheduled transaction runner stopped")
+			return
+		case <-ctx.Done():
+			logger.Info("Scheduled transaction runner context cancelled")
+			return
+		}
+	}
+}
+
+// Stop halts the runner
+func (r *ScheduledTransactionRunner) Stop() {
+	close(r.stopChan)
+}
+
+// run posts every due auto-create occurrence
+func (r *ScheduledTransactionRunner) run(ctx context.Context) {
+	result, err := r.sxService.PostDue(ctx, "", time.Now(), true)
+	if err != nil {
+		logger.Error("Failed to post due scheduled transactions", "error", err)
+		return
+	}
+
+	if len(result.Posted) > 0 || len(result.Failed) > 0 {
+		logger.Info("Posted due scheduled transactions", "posted", len(result.Posted), "failed", len(result.Failed))
+	}
+}
@@ -0,0 +1,375 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
+	"github.com/udai-kiran/agentic-cash/pkg/logger"
+)
+
+// maxScheduledOccurrences bounds the occurrences listed or posted per
+// scheduled transaction in one call
+const maxScheduledOccurrences = 1000
+
+// ScheduledTransactionService handles scheduled transaction business logic
+type ScheduledTransactionService struct {
+	sxRepo             repository.ScheduledTransactionRepository
+	accountRepo        repository.AccountRepository
+	transactionService *TransactionService
+}
+
+// NewScheduledTransactionService creates a new scheduled transaction service.
+// Instances are built through the transaction service so they get the same
+// validation as transactions entered through the API.
+func NewScheduledTransactionService(
+	sxRepo repository.ScheduledTransactionRepository,
+	accountRepo repository.AccountRepository,
+	transactionService *TransactionService,
+) *ScheduledTransactionService {
+	return &ScheduledTransactionService{
+		sxRepo:             sxRepo,
+		accountRepo:        accountRepo,
+		transactionService: transactionService,
+	}
+}
+
+// ListScheduledTransactions returns every scheduled transaction with its
+// templates and next occurrence
+func (s *ScheduledTransactionService) ListScheduledTransactions(ctx context.Context) ([]dto.ScheduledTransactionResponse, error) {
+	sxs, err := s.sxRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduled transactions: %w", err)
+	}
+
+	names, err := s.accountNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.ScheduledTransactionResponse, len(sxs))
+	for i, sx := range sxs {
+		response[i] = scheduledTransactionToResponse(sx, names)
+	}
+	return response, nil
+}
+
+// GetScheduledTransaction returns a single scheduled transaction
+func (s *ScheduledTransactionService) GetScheduledTransaction(ctx context.Context, guid string) (*dto.ScheduledTransactionResponse, error) {
+	sx, err := s.sxRepo.FindByGUID(ctx, guid)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := s.accountNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := scheduledTransactionToResponse(sx, names)
+	return &response, nil
+}
+
+// GetUpcoming lists the occurrences of enabled scheduled transactions (or
+// only the one with the given GUID) up to and including until
+func (s *ScheduledTransactionService) GetUpcoming(ctx context.Context, guid string, until time.Time) (*dto.UpcomingScheduledResponse, error) {
+	sxs, err := s.findScheduled(ctx, guid)
+	if err != nil {
+		return nil, err
+	}
+
+	today := dateOnly(time.Now())
+	until = dateOnly(until)
+
+	response := &dto.UpcomingScheduledResponse{
+		Until:       until.Format("2006-01-02"),
+		Occurrences: []dto.ScheduledOccurrenceResponse{},
+	}
+	for _, sx := range sxs {
+		if !sx.Enabled {
+			continue
+		}
+		dueBy := today.AddDate(0, 0, sx.AdvanceCreateDays)
+		for _, date := range sx.Occurrences(until, maxScheduledOccurrences) {
+			response.Occurrences = append(response.Occurrences, dto.ScheduledOccurrenceResponse{
+				ScheduledTransactionGUID: sx.GUID,
+				Name:                     entity.StringOrEmpty(sx.Name),
+				Date:                     date.Format("2006-01-02"),
+				Due:                      !date.After(dueBy),
+				AutoCreate:               sx.AutoCreate,
+			})
+		}
+	}
+
+	sort.SliceStable(response.Occurrences, func(i, j int) bool {
+		return response.Occurrences[i].Date < response.Occurrences[j].Date
+	})
+
+	return response, nil
+}
+
+// PostDue creates the transactions for every due occurrence of enabled
+// scheduled transactions (or only the one with the given GUID). An occurrence
+// is due when it falls on or before asOf plus the scheduled transaction's
+// advance-creation days. With autoCreateOnly, scheduled transactions that are
+// not marked for automatic creation are skipped. A failing occurrence is
+// reported and stops that scheduled transaction, so instances are never
+// posted out of order.
+func (s *ScheduledTransactionService) PostDue(ctx context.Context, guid string, asOf time.Time, autoCreateOnly bool) (*dto.PostDueScheduledResponse, error) {
+	sxs, err := s.findScheduled(ctx, guid)
+	if err != nil {
+		return nil, err
+	}
+
+	asOf = dateOnly(asOf)
+	response := &dto.PostDueScheduledResponse{
+		AsOf:   asOf.Format("2006-01-02"),
+		Posted: []dto.PostedInstanceResponse{},
+		Failed: []dto.FailedInstanceResponse{},
+	}
+
+	for _, sx := range sxs {
+		if !sx.Enabled || (autoCreateOnly && !sx.AutoCreate) {
+			continue
+		}
+
+		dueBy := asOf.AddDate(0, 0, sx.AdvanceCreateDays)
+		for _, date := range sx.Occurrences(dueBy, maxScheduledOccurrences) {
+			txGUIDs, err := s.postInstance(ctx, sx, date)
+			if err != nil {
+				logger.Error("Failed to post scheduled transaction", "guid", sx.GUID, "date", date.Format("2006-01-02"), "error", err)
+				response.Failed = append(response.Failed, dto.FailedInstanceResponse{
+					ScheduledTransactionGUID: sx.GUID,
+					Name:                     entity.StringOrEmpty(sx.Name),
+					Date:                     date.Format("2006-01-02"),
+					Error:                    postInstanceError(err),
+				})
+				break
+			}
+
+			response.Posted = append(response.Posted, dto.PostedInstanceResponse{
+				ScheduledTransactionGUID: sx.GUID,
+				Name:                     entity.StringOrEmpty(sx.Name),
+				Date:                     date.Format("2006-01-02"),
+				TransactionGUIDs:         txGUIDs,
+			})
+		}
+	}
+
+	return response, nil
+}
+
+// postInstance builds and stores the transactions for one occurrence, then
+// advances sx in memory to match the database
+func (s *ScheduledTransactionService) postInstance(ctx context.Context, sx *entity.ScheduledTransaction, date time.Time) ([]string, error) {
+	if len(sx.Templates) == 0 {
+		return nil, validationError("scheduled transaction has no template transactions")
+	}
+
+	enterDate := time.Now().UTC().Truncate(time.Second)
+	txs := make([]*entity.Transaction, 0, len(sx.Templates))
+	txGUIDs := make([]string, 0, len(sx.Templates))
+	for _, template := range sx.Templates {
+		req, err := instanceRequest(template, date)
+		if err != nil {
+			return nil, err
+		}
+
+		tx, err := s.transactionService.buildTransaction(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		tx.GUID = gnucash.NewGUID()
+		tx.EnterDate = enterDate
+		for _, split := range tx.Splits {
+			split.GUID = gnucash.NewGUID()
+			split.TxGUID = tx.GUID
+		}
+		txs = append(txs, tx)
+		txGUIDs = append(txGUIDs, tx.GUID)
+	}
+
+	if err := s.sxRepo.PostInstance(ctx, sx, date, txs); err != nil {
+		return nil, err
+	}
+
+	occurred := date
+	sx.LastOccur = &occurred
+	if sx.NumOccur > 0 {
+		sx.RemOccur--
+	}
+	sx.InstanceCount++
+
+	return txGUIDs, nil
+}
+
+// findScheduled loads one scheduled transaction by GUID, or all of them
+func (s *ScheduledTransactionService) findScheduled(ctx context.Context, guid string) ([]*entity.ScheduledTransaction, error) {
+	if guid != "" {
+		sx, err := s.sxRepo.FindByGUID(ctx, guid)
+		if err != nil {
+			return nil, err
+		}
+		return []*entity.ScheduledTransaction{sx}, nil
+	}
+
+	sxs, err := s.sxRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduled transactions: %w", err)
+	}
+	return sxs, nil
+}
+
+// accountNames returns the full name of every account by GUID
+func (s *ScheduledTransactionService) accountNames(ctx context.Context) (map[string]string, error) {
+	accounts, err := s.accountRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	return accountFullNames(accounts), nil
+}
+
+// instanceRequest converts a template transaction into a transaction request
+// posted on date
+func instanceRequest(template *entity.TemplateTransaction, date time.Time) (*dto.CreateTransactionRequest, error) {
+	req := &dto.CreateTransactionRequest{
+		CurrencyGUID: template.CurrencyGUID,
+		Num:          template.Num,
+		PostDate:     date.Format("2006-01-02"),
+		Description:  template.Description,
+		Splits:       make([]dto.SplitRequest, 0, len(template.Splits)),
+	}
+
+	for i, split := range template.Splits {
+		if split.AccountGUID == "" {
+			return nil, validationError("template split %d has no account", i+1)
+		}
+		value, err := templateSplitValue(split)
+		if err != nil {
+			return nil, validationError("template split %d: %v", i+1, err)
+		}
+		req.Splits = append(req.Splits, dto.SplitRequest{
+			AccountGUID: split.AccountGUID,
+			Value:       value.String(),
+			Memo:        split.Memo,
+			Action:      split.Action,
+		})
+	}
+
+	return req, nil
+}
+
+// templateSplitValue evaluates a template split's amount, positive for debits
+// and negative for credits
+func templateSplitValue(split *entity.TemplateSplit) (decimal.Decimal, error) {
+	debit, err := templateAmount(split.DebitFormula, split.DebitNum, split.DebitDenom)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	credit, err := templateAmount(split.CreditFormula, split.CreditNum, split.CreditDenom)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return debit.Sub(credit), nil
+}
+
+// templateAmount evaluates a template formula, reading the numeric slot only
+// when there is no formula. GnuCash leaves that slot empty or stale for
+// formulas with variables, so a formula that cannot be evaluated is an error
+// rather than a reason to post the cached amount.
+func templateAmount(formula string, num, denom int64) (decimal.Decimal, error) {
+	if formula == "" {
+		return gnucash.RationalToDecimal(num, denom), nil
+	}
+	return gnucash.EvalFormula(formula)
+}
+
+// postInstanceError describes why an occurrence could not be posted
+func postInstanceError(err error) string {
+	if errors.Is(err, repository.ErrConflict) {
+		return "scheduled transaction was advanced by another request"
+	}
+	return err.Error()
+}
+
+// dateOnly truncates t to midnight UTC of its calendar day
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// scheduledTransactionToResponse converts a scheduled transaction to its API
+// representation, naming accounts from names
+func scheduledTransactionToResponse(sx *entity.ScheduledTransaction, names map[string]string) dto.ScheduledTransactionResponse {
+	response := dto.ScheduledTransactionResponse{
+		GUID:                 sx.GUID,
+		Name:                 sx.Name,
+		Enabled:              sx.Enabled,
+		NumOccurrences:       sx.NumOccur,
+		RemainingOccurrences: sx.RemOccur,
+		InstanceCount:        sx.InstanceCount,
+		AutoCreate:           sx.AutoCreate,
+		AutoNotify:           sx.AutoNotify,
+		AdvanceCreateDays:    sx.AdvanceCreateDays,
+		AdvanceNotifyDays:    sx.AdvanceNotifyDays,
+		Recurrences:          make([]dto.RecurrenceResponse, 0, len(sx.Recurrences)),
+		Templates:            make([]dto.ScheduledTemplateResponse, 0, len(sx.Templates)),
+	}
+	if sx.StartDate != nil {
+		response.StartDate = sx.StartDate.Format("2006-01-02")
+	}
+	if sx.EndDate != nil {
+		response.EndDate = sx.EndDate.Format("2006-01-02")
+	}
+	if sx.LastOccur != nil {
+		response.LastOccurrence = sx.LastOccur.Format("2006-01-02")
+	}
+	if next := sx.Occurrences(time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC), 1); len(next) > 0 {
+		response.NextOccurrence = next[0].Format("2006-01-02")
+	}
+
+	for _, r := range sx.Recurrences {
+		response.Recurrences = append(response.Recurrences, dto.RecurrenceResponse{
+			Mult:          r.Mult,
+			PeriodType:    r.PeriodType,
+			PeriodStart:   r.PeriodStart.Format("2006-01-02"),
+			WeekendAdjust: r.WeekendAdjust,
+		})
+	}
+
+	for _, template := range sx.Templates {
+		t := dto.ScheduledTemplateResponse{
+			GUID:         template.GUID,
+			CurrencyGUID: template.CurrencyGUID,
+			Num:          template.Num,
+			Description:  template.Description,
+			Splits:       make([]dto.ScheduledSplitResponse, 0, len(template.Splits)),
+		}
+		for _, split := range template.Splits {
+			sr := dto.ScheduledSplitResponse{
+				AccountGUID:   split.AccountGUID,
+				AccountName:   names[split.AccountGUID],
+				Memo:          split.Memo,
+				Action:        split.Action,
+				DebitFormula:  split.DebitFormula,
+				CreditFormula: split.CreditFormula,
+			}
+			if value, err := templateSplitValue(split); err != nil {
+				sr.Error = err.Error()
+			} else {
+				sr.Value = value.String()
+			}
+			t.Splits = append(t.Splits, sr)
+		}
+		response.Templates = append(response.Templates, t)
+	}
+
+	return response
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
+)
+
+// BulkRecategorize moves the splits of the request's account that match its
+// filters to the target account in one database transaction. Splits of voided
+// transactions are never moved and reconciled splits only with
+// IncludeReconciled, which sets them back to not reconciled. With DryRun nothing is written and the response lists the
+// transactions that would change.
+func (s *TransactionService) BulkRecategorize(ctx context.Context, req *dto.BulkRecategorizeRequest) (*dto.BulkRecategorizeResponse, error) {
+	filter, err := recategorizeFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	rootGUID, err := s.accountRepo.FindRootGUID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find root account: %w", err)
+	}
+	accounts, err := s.accountRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+	tree := newAccountTree(rootGUID, accounts)
+
+	source, ok := tree.accounts[req.AccountGUID]
+	if !ok || !tree.inBook(req.AccountGUID) {
+		return nil, validationError("account %s not found", req.AccountGUID)
+	}
+	if err := tree.validateSplitDestination(source, req.TargetAccountGUID); err != nil {
+		return nil, err
+	}
+	target := tree.accounts[req.TargetAccountGUID]
+
+	transactions, err := s.transactionRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find transactions: %w", err)
+	}
+
+	response := &dto.BulkRecategorizeResponse{
+		SourceGUID:   source.GUID,
+		SourceName:   source.Name,
+		TargetGUID:   target.GUID,
+		TargetName:   target.Name,
+		DryRun:       req.DryRun,
+		Transactions: []dto.RecategorizedTransaction{},
+	}
+
+	var splitGUIDs []string
+	total := decimal.Zero
+	// Oldest first reads better in a preview than the list's newest first
+	for i := len(transactions) - 1; i >= 0; i-- {
+		tx := transactions[i]
+		if tx.Voided {
+			response.SkippedVoided++
+			continue
+		}
+
+		entry := dto.RecategorizedTransaction{GUID: tx.GUID, PostDate: tx.PostDate, Description: tx.Description}
+		amount := decimal.Zero
+		for _, split := range tx.Splits {
+			if split.AccountGUID != source.GUID {
+				continue
+			}
+			quantity := gnucash.RationalToDecimal(split.QuantityNum, split.QuantityDenom)
+			if !inAmountRange(quantity.Abs(), filter.MinAmount, filter.MaxAmount) {
+				continue
+			}
+			if split.ReconcileState == entity.ReconcileStateReconciled && !req.IncludeReconciled {
+				response.SkippedReconciled++
+				continue
+			}
+			entry.SplitGUIDs = append(entry.SplitGUIDs, split.GUID)
+			amount = amount.Add(quantity)
+		}
+		if len(entry.SplitGUIDs) == 0 {
+			continue
+		}
+
+		entry.Amount = amount.String()
+		total = total.Add(amount)
+		splitGUIDs = append(splitGUIDs, entry.SplitGUIDs...)
+		response.Transactions = append(response.Transactions, entry)
+	}
+
+	response.SplitCount = len(splitGUIDs)
+	response.TransactionCount = len(response.Transactions)
+	response.Total = total.String()
+
+	if req.DryRun || len(splitGUIDs) == 0 {
+		return response, nil
+	}
+
+	if err := s.transactionRepo.MoveSplits(ctx, splitGUIDs, source.GUID, target.GUID); err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to move splits: %w", err)
+	}
+
+	return response, nil
+}
+
+// recategorizeFilter validates the request's filters. The amount bounds are
+// applied again to each split since a transaction can have several splits in
+// the source account.
+func recategorizeFilter(req *dto.BulkRecategorizeRequest) (*repository.TransactionFilter, error) {
+	filter := &repository.TransactionFilter{AccountGUID: &req.AccountGUID}
+
+	if req.Description != "" {
+		filter.Description = &req.Description
+	}
+	if req.StartDate != "" {
+		start, err := time.Parse("2006-01-02", req.StartDate)
+		if err != nil {
+			return nil, validationError("invalid start_date format, use YYYY-MM-DD")
+		}
+		filter.StartDate = &start
+	}
+	if req.EndDate != "" {
+		end, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			return nil, validationError("invalid end_date format, use YYYY-MM-DD")
+		}
+		end = endOfDay(end)
+		filter.EndDate = &end
+	}
+	if filter.StartDate != nil && filter.EndDate != nil && filter.EndDate.Before(*filter.StartDate) {
+		return nil, validationError("end_date must not be before start_date")
+	}
+
+	for _, bound := range []struct {
+		name  string
+		value string
+		dest  **decimal.Decimal
+	}{
+		{"min_amount", req.MinAmount, &filter.MinAmount},
+		{"max_amount", req.MaxAmount, &filter.MaxAmount},
+	} {
+		if bound.value == "" {
+			continue
+		}
+		amount, err := decimal.NewFromString(bound.value)
+		if err != nil || amount.IsNegative() {
+			return nil, validationError("%s must be a non-negative number", bound.name)
+		}
+		*bound.dest = &amount
+	}
+	if filter.MinAmount != nil && filter.MaxAmount != nil && filter.MaxAmount.LessThan(*filter.MinAmount) {
+		return nil, validationError("max_amount must not be less than min_amount")
+	}
+
+	return filter, nil
+}
+
+// inAmountRange reports whether amount lies within the optional bounds
+func inAmountRange(amount decimal.Decimal, lower, upper *decimal.Decimal) bool {
+	if lower != nil && amount.LessThan(*lower) {
+		return false
+	}
+	if upper != nil && amount.GreaterThan(*upper) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,382 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
+)
+
+// TransactionService handles transaction write business logic
+type TransactionService struct {
+	transactionRepo repository.TransactionRepository
+	accountRepo     repository.AccountRepository
+	commodityRepo   repository.CommodityRepository
+}
+
+// NewTransactionService creates a new transaction service
+func NewTransactionService(
+	transactionRepo repository.TransactionRepository,
+	accountRepo repository.AccountRepository,
+	commodityRepo repository.CommodityRepository,
+) *TransactionService {
+	return &TransactionService{
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+		commodityRepo:   commodityRepo,
+	}
+}
+
+// CreateTransaction validates and stores a new balanced transaction
+func (s *TransactionService) CreateTransaction(ctx context.Context, req *dto.CreateTransactionRequest) (*entity.Transaction, error) {
+	tx, err := s.buildTransaction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.GUID = gnucash.NewGUID()
+	tx.EnterDate = time.Now().UTC().Truncate(time.Second)
+	for _, split := range tx.Splits {
+		split.GUID = gnucash.NewGUID()
+		split.TxGUID = tx.GUID
+	}
+
+	if err := s.transactionRepo.Create(ctx, tx); err != nil {
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	return s.transactionRepo.FindByGUID(ctx, tx.GUID)
+}
+
+// UpdateTransaction replaces a transaction's fields and split set. The update is
+// rejected with repository.ErrConflict if the transaction has changed since version.
+// Removing a reconciled split or changing its account or amount is rejected with
+// ErrReconciled unless force is set, in which case changed splits go back to new.
+func (s *TransactionService) UpdateTransaction(ctx context.Context, guid string, req *dto.UpdateTransactionRequest, version string, force bool) (*entity.Transaction, error) {
+	if version == "" {
+		version = req.Version
+	}
+	if version == "" {
+		return nil, validationError("a version is required (If-Match header or version field)")
+	}
+
+	current, err := s.transactionRepo.FindByGUID(ctx, guid)
+	if err != nil {
+		return nil, err
+	}
+	if current.Version() != version {
+		return nil, repository.ErrConflict
+	}
+	if current.Voided {
+		return nil, validationError("voided transactions are read-only")
+	}
+
+	tx, err := s.buildTransaction(ctx, &req.CreateTransactionRequest)
+	if err != nil {
+		return nil, err
+	}
+	tx.GUID = guid
+	tx.EnterDate = current.EnterDate
+
+	existing := make(map[string]*entity.Split, len(current.Splits))
+	for _, split := range current.Splits {
+		existing[split.GUID] = split
+	}
+
+	seen := make(map[string]bool, len(tx.Splits))
+	for i, split := range tx.Splits {
+		splitGUID := req.Splits[i].GUID
+		if splitGUID == "" {
+			split.GUID = gnucash.NewGUID()
+		} else {
+			old, ok := existing[splitGUID]
+			if !ok {
+				return nil, validationError("split %d: split %s does not belong to this transaction", i+1, splitGUID)
+			}
+			if seen[splitGUID] {
+				return nil, validationError("split %d: split %s is listed more than once", i+1, splitGUID)
+			}
+			split.GUID = splitGUID
+			split.ReconcileState = old.ReconcileState
+			split.ReconcileDate = old.ReconcileDate
+			if old.ReconcileState == entity.ReconcileStateReconciled && reconciledSplitChanged(old, split) {
+				if !force {
+					return nil, fmt.Errorf("%w: split %d is reconciled, use force to change it", ErrReconciled, i+1)
+				}
+				split.ReconcileState = entity.ReconcileStateNew
+				split.ReconcileDate = nil
+			}
+		}
+		seen[split.GUID] = true
+		split.TxGUID = guid
+	}
+	for _, old := range current.Splits {
+		if !seen[old.GUID] && old.ReconcileState == entity.ReconcileStateReconciled && !force {
+			return nil, fmt.Errorf("%w: reconciled split %s would be removed, use force to remove it", ErrReconciled, old.GUID)
+		}
+	}
+
+	if err := s.transactionRepo.Update(ctx, tx, version); err != nil {
+		if errors.Is(err, repository.ErrConflict) || errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update transaction: %w", err)
+	}
+
+	return s.transactionRepo.FindByGUID(ctx, guid)
+}
+
+// reconciledSplitChanged reports whether an update moves a split to another
+// account or changes its value or quantity, which would alter a reconciled balance
+func reconciledSplitChanged(old, updated *entity.Split) bool {
+	return old.AccountGUID != updated.AccountGUID ||
+		gnucash.RationalToDecimal(old.ValueNum, old.ValueDenom).Cmp(gnucash.RationalToDecimal(updated.ValueNum, updated.ValueDenom)) != 0 ||
+		gnucash.RationalToDecimal(old.QuantityNum, old.QuantityDenom).Cmp(gnucash.RationalToDecimal(updated.QuantityNum, updated.QuantityDenom)) != 0
+}
+
+// DeleteTransaction removes a transaction. The delete is rejected with
+// repository.ErrConflict if the transaction has changed since version.
+// Transactions with reconciled splits are only deleted when force is set.
+func (s *TransactionService) DeleteTransaction(ctx context.Context, guid string, version string, force bool) error {
+	if version == "" {
+		return validationError("a version is required (If-Match header)")
+	}
+
+	current, err := s.transactionRepo.FindByGUID(ctx, guid)
+	if err != nil {
+		return err
+	}
+	if current.Version() != version {
+		return repository.ErrConflict
+	}
+
+	if current.HasReconciledSplits() && !force {
+		return ErrReconciled
+	}
+
+	return s.transactionRepo.Delete(ctx, guid, version)
+}
+
+// VoidTransaction voids a transaction, keeping the original amounts in slots so
+// GnuCash can display and unvoid it. The void is rejected with
+// repository.ErrConflict if the transaction has changed since version.
+func (s *TransactionService) VoidTransaction(ctx context.Context, guid string, reason string, version string) (*entity.Transaction, error) {
+	if reason == "" {
+		return nil, validationError("a void reason is required")
+	}
+	if version == "" {
+		return nil, validationError("a version is required (If-Match header or version field)")
+	}
+
+	current, err := s.transactionRepo.FindByGUID(ctx, guid)
+	if err != nil {
+		return nil, err
+	}
+	if current.Voided {
+		return nil, validationError("transaction is already voided")
+	}
+
+	if current.Version() != version {
+		return nil, repository.ErrConflict
+	}
+
+	if err := s.transactionRepo.Void(ctx, guid, reason, version); err != nil {
+		if errors.Is(err, repository.ErrConflict) || errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to void transaction: %w", err)
+	}
+
+	return s.transactionRepo.FindByGUID(ctx, guid)
+}
+
+// ReverseTransaction creates a mirror transaction with every split negated,
+// posted on postDate (YYYY-MM-DD, defaults to today)
+func (s *TransactionService) ReverseTransaction(ctx context.Context, guid string, postDate string) (*entity.Transaction, error) {
+	original, err := s.transactionRepo.FindByGUID(ctx, guid)
+	if err != nil {
+		return nil, err
+	}
+	if original.Voided {
+		return nil, validationError("voided transactions cannot be reversed")
+	}
+	if original.ReversedByGUID != nil {
+		return nil, validationError("transaction was already reversed by %s", *original.ReversedByGUID)
+	}
+
+	date := time.Now()
+	if postDate != "" {
+		date, err = time.Parse("2006-01-02", postDate)
+		if err != nil {
+			return nil, validationError("invalid post_date format, use YYYY-MM-DD")
+		}
+	}
+
+	reversal := &entity.Transaction{
+		GUID:         gnucash.NewGUID(),
+		CurrencyGUID: original.CurrencyGUID,
+		Num:          original.Num,
+		PostDate:     gnucash.NeutralTime(date),
+		EnterDate:    time.Now().UTC().Truncate(time.Second),
+		Description:  original.Description,
+		Splits:       make([]*entity.Split, 0, len(original.Splits)),
+	}
+	for _, split := range original.Splits {
+		reversal.Splits = append(reversal.Splits, &entity.Split{
+			GUID:           gnucash.NewGUID(),
+			TxGUID:         reversal.GUID,
+			AccountGUID:    split.AccountGUID,
+			Memo:           split.Memo,
+			Action:         split.Action,
+			ReconcileState: entity.ReconcileStateNew,
+			ValueNum:       -split.ValueNum,
+			ValueDenom:     split.ValueDenom,
+			QuantityNum:    -split.QuantityNum,
+			QuantityDenom:  split.QuantityDenom,
+		})
+	}
+
+	if err := s.transactionRepo.Reverse(ctx, guid, reversal); err != nil {
+		if errors.Is(err, repository.ErrConflict) || errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to reverse transaction: %w", err)
+	}
+
+	return s.transactionRepo.FindByGUID(ctx, reversal.GUID)
+}
+
+// buildTransaction validates a request and converts it into an unsaved transaction.
+// Split values are stored over the currency fraction and quantities over the account SCU.
+func (s *TransactionService) buildTransaction(ctx context.Context, req *dto.CreateTransactionRequest) (*entity.Transaction, error) {
+	postDate, err := time.Parse("2006-01-02", req.PostDate)
+	if err != nil {
+		return nil, validationError("invalid post_date format, use YYYY-MM-DD")
+	}
+
+	if len(req.Splits) < 2 {
+		return nil, validationError("a transaction needs at least two splits")
+	}
+
+	currency, err := s.resolveCurrency(ctx, req.CurrencyGUID)
+	if err != nil {
+		return nil, err
+	}
+	currencyDenom := int64(currency.Fraction)
+
+	tx := &entity.Transaction{
+		CurrencyGUID:     currency.GUID,
+		CurrencyMnemonic: currency.Mnemonic,
+		Num:              req.Num,
+		PostDate:         gnucash.NeutralTime(postDate),
+		Description:      req.Description,
+		Splits:           make([]*entity.Split, 0, len(req.Splits)),
+	}
+
+	var total int64
+	for i, sr := range req.Splits {
+		account, err := s.accountRepo.FindByGUID(ctx, sr.AccountGUID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, validationError("split %d: account %s not found", i+1, sr.AccountGUID)
+			}
+			return nil, fmt.Errorf("failed to load account: %w", err)
+		}
+		if account.AccountType == entity.AccountTypeRoot || account.Placeholder {
+			return nil, validationError("split %d: account %s does not accept postings", i+1, account.Name)
+		}
+		if account.CommodityGUID == nil {
+			return nil, validationError("split %d: account %s has no commodity", i+1, account.Name)
+		}
+
+		value, err := decimal.NewFromString(sr.Value)
+		if err != nil {
+			return nil, validationError("split %d: invalid value %q", i+1, sr.Value)
+		}
+		valueNum, ok := gnucash.DecimalToRationalExact(value, currencyDenom)
+		if !ok {
+			return nil, validationError("split %d: value %s has more precision than %s allows", i+1, sr.Value, currency.Mnemonic)
+		}
+
+		quantity := value
+		if sr.Quantity != "" {
+			quantity, err = decimal.NewFromString(sr.Quantity)
+			if err != nil {
+				return nil, validationError("split %d: invalid quantity %q", i+1, sr.Quantity)
+			}
+		} else if *account.CommodityGUID != currency.GUID {
+			return nil, validationError("split %d: quantity is required because account %s is not in %s", i+1, account.Name, currency.Mnemonic)
+		}
+		if value.Sign()*quantity.Sign() < 0 {
+			return nil, validationError("split %d: value and quantity must have the same sign", i+1)
+		}
+
+		quantityDenom := int64(account.CommoditySCU)
+		if quantityDenom <= 0 {
+			quantityDenom = currencyDenom
+		}
+		quantityNum, ok := gnucash.DecimalToRationalExact(quantity, quantityDenom)
+		if !ok {
+			return nil, validationError("split %d: quantity %s has more precision than account %s allows", i+1, quantity.String(), account.Name)
+		}
+
+		tx.Splits = append(tx.Splits, &entity.Split{
+			AccountGUID:    account.GUID,
+			Memo:           sr.Memo,
+			Action:         sr.Action,
+			ReconcileState: entity.ReconcileStateNew,
+			ValueNum:       valueNum,
+			ValueDenom:     currencyDenom,
+			QuantityNum:    quantityNum,
+			QuantityDenom:  quantityDenom,
+			Account:        account,
+		})
+		total += valueNum
+	}
+
+	if total != 0 {
+		imbalance := gnucash.RationalToDecimal(total, currencyDenom)
+		return nil, validationError("splits do not balance: off by %s %s", imbalance.String(), currency.Mnemonic)
+	}
+
+	return tx, nil
+}
+
+// resolveCurrency loads the requested transaction currency, defaulting to the
+// commodity of the book's ROOT account
+func (s *TransactionService) resolveCurrency(ctx context.Context, currencyGUID string) (*entity.Commodity, error) {
+	if currencyGUID == "" {
+		roots, err := s.accountRepo.FindByType(ctx, entity.AccountTypeRoot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find root account: %w", err)
+		}
+		for _, root := range roots {
+			if root.CommodityGUID != nil {
+				currencyGUID = *root.CommodityGUID
+				break
+			}
+		}
+		if currencyGUID == "" {
+			return nil, validationError("currency_guid is required because the book has no default currency")
+		}
+	}
+
+	currency, err := s.commodityRepo.FindByGUID(ctx, currencyGUID)
+	if err != nil {
+		return nil, validationError("currency %s not found", currencyGUID)
+	}
+	if currency.Namespace != "CURRENCY" {
+		return nil, validationError("commodity %s is not a currency", currency.Mnemonic)
+	}
+	if currency.Fraction <= 0 {
+		return nil, validationError("currency %s has an invalid fraction", currency.Mnemonic)
+	}
+
+	return currency, nil
+}
@@ -9,10 +9,11 @@ import (
 
 // Config holds all configuration for the application
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	CORS     CORSConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	JWT       JWTConfig
+	CORS      CORSConfig
+	Scheduler SchedulerConfig
 }
 
 // CORSConfig holds CORS configuration
@@ -20,6 +21,12 @@ type CORSConfig struct {
 	AllowedOrigins []string `mapstructure:"allowedOrigins"`
 }
 
+// SchedulerConfig holds configuration for automatic creation of scheduled transactions
+type SchedulerConfig struct {
+	Enabled  bool
+	Interval time.Duration
+}
+
 // ServerConfig holds HTTP server configuration
 type ServerConfig struct {
 	Port         int
@@ -72,6 +79,8 @@ func Load(configPath string) (*Config, error) {
 	viper.SetDefault("jwt.secret", "")
 	viper.SetDefault("jwt.accessTokenTTL", "15m")
 	viper.SetDefault("jwt.refreshTokenTTL", "168h") // 7 days
+	viper.SetDefault("scheduler.enabled", false)
+	viper.SetDefault("scheduler.interval", "1h")
 
 	// Enable environment variable override
 	viper.AutomaticEnv()
@@ -87,6 +96,8 @@ func Load(configPath string) (*Config, error) {
 	viper.BindEnv("server.port", "SERVER_PORT")
 	viper.BindEnv("jwt.secret", "JWT_SECRET")
 	viper.BindEnv("cors.allowedOrigins", "CORS_ALLOWED_ORIGINS")
+	viper.BindEnv("scheduler.enabled", "SCHEDULER_ENABLED")
+	viper.BindEnv("scheduler.interval", "SCHEDULER_INTERVAL")
 
 	// Try to read config file (optional in Docker)
 	if configPath != "" {
@@ -106,5 +117,9 @@ func Load(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("JWT_SECRET must be at least 32 characters (set via JWT_SECRET env var)")
 	}
 
+	if config.Scheduler.Enabled && config.Scheduler.Interval <= 0 {
+		return nil, fmt.Errorf("SCHEDULER_INTERVAL must be a positive duration when the scheduler is enabled")
+	}
+
 	return &config, nil
 }
@@ -22,6 +22,7 @@ const (
 	AccountTypeEquity     AccountType = "EQUITY"
 	AccountTypeReceivable AccountType = "RECEIVABLE"
 	AccountTypePayable    AccountType = "PAYABLE"
+	AccountTypeTrading    AccountType = "TRADING"
 )
 
 // Account represents a GnuCash account
@@ -31,6 +32,7 @@ type Account struct {
 	AccountType     AccountType
 	CommodityGUID   *string
 	CommoditySCU    int
+	NonStdSCU       bool
 	ParentGUID      *string
 	Code            *string
 	Description     *string
@@ -58,3 +60,43 @@ func (a *Account) IsDebitAccount() bool {
 func (a *Account) IsCreditAccount() bool {
 	return !a.IsDebitAccount()
 }
+
+// IsValid returns true if the type is one GnuCash knows about
+func (t AccountType) IsValid() bool {
+	switch t {
+	case AccountTypeRoot, AccountTypeBank, AccountTypeCash, AccountTypeCredit,
+		AccountTypeAsset, AccountTypeLiability, AccountTypeStock, AccountTypeMutual,
+		AccountTypeCurrency, AccountTypeIncome, AccountTypeExpense, AccountTypeEquity,
+		AccountTypeReceivable, AccountTypePayable, AccountTypeTrading:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanHaveParent returns true if an account of this type may be placed under a
+// parent of the given type, following GnuCash's xaccAccountTypesCompatible rules
+func (t AccountType) CanHaveParent(parent AccountType) bool {
+	if parent == AccountTypeRoot {
+		return t != AccountTypeRoot
+	}
+
+	switch t {
+	case AccountTypeBank, AccountTypeCash, AccountTypeAsset, AccountTypeStock,
+		AccountTypeMutual, AccountTypeCurrency, AccountTypeCredit, AccountTypeLiability,
+		AccountTypeReceivable, AccountTypePayable:
+		switch parent {
+		case AccountTypeBank, AccountTypeCash, AccountTypeAsset, AccountTypeStock,
+			AccountTypeMutual, AccountTypeCurrency, AccountTypeCredit, AccountTypeLiability,
+			AccountTypeReceivable, AccountTypePayable:
+			return true
+		}
+	case AccountTypeIncome, AccountTypeExpense:
+		return parent == AccountTypeIncome || parent == AccountTypeExpense
+	case AccountTypeEquity:
+		return parent == AccountTypeEquity
+	case AccountTypeTrading:
+		return parent == AccountTypeTrading
+	}
+	return false
+}
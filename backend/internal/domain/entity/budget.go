@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+// Budget represents a GnuCash budget: NumPeriods consecutive periods laid
+// out by Recurrence
+type Budget struct {
+	GUID        string
+	Name        string
+	Description *string
+	NumPeriods  int
+	Recurrence  *Recurrence
+}
+
+// BudgetAmount represents the amount budgeted for one account in one period,
+// stored with the sign of a split in that account (debits positive)
+type BudgetAmount struct {
+	ID          int
+	BudgetGUID  string
+	AccountGUID string
+	PeriodNum   int
+	AmountNum   int64
+	AmountDenom int64
+}
+
+// PeriodStart returns the first day of period i
+func (b *Budget) PeriodStart(i int) time.Time {
+	return b.Recurrence.Nth(i)
+}
+
+// PeriodEnd returns the last day of period i
+func (b *Budget) PeriodEnd(i int) time.Time {
+	return b.Recurrence.Nth(i+1).AddDate(0, 0, -1)
+}
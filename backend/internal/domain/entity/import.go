@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ImportedTransaction is a transaction created from a statement line.
+// OnlineID is the bank's identifier for the line, CounterpartyIBAN the other
+// party's account and BankDescription the statement text when it differs
+// from the transaction's description; they are stored in slots of the split
+// that posts to the imported account.
+type ImportedTransaction struct {
+	Transaction      *Transaction
+	OnlineID         string
+	CounterpartyIBAN string
+	BankDescription  string
+}
+
+// Sign conventions of CSV amount columns
+const (
+	SignDepositsPositive    = "deposits_positive"
+	SignWithdrawalsPositive = "withdrawals_positive"
+)
+
+// CSVProfile is a saved mapping of a bank's CSV export onto an account.
+// Columns are header names, or 1-based column numbers when the file has no
+// header row. Either AmountColumn or DebitColumn/CreditColumn is set.
+type CSVProfile struct {
+	ID                 int64
+	Name               string
+	AccountGUID        string
+	CounterAccountGUID *string
+	Delimiter          string
+	HasHeader          bool
+	SkipRows           int
+	DateColumn         string
+	DateFormat         string
+	AmountColumn       string
+	DebitColumn        string
+	CreditColumn       string
+	SignConvention     string
+	DecimalSeparator   string
+	DescriptionColumn  string
+	MemoColumn         string
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// ImportRule categorizes imported statement lines. A rule matches when all of
+// its set conditions hold: the description and memo patterns are
+// case-insensitive regular expressions, the amount range is inclusive and
+// signed like the statement (negative for money out), and Counterparty is an
+// IBAN or the counterparty's name. The first enabled rule by priority sets the
+// line's counter account, description and memo.
+type ImportRule struct {
+	ID                     int64
+	Name                   string
+	Priority               int
+	Enabled                bool
+	DescriptionPattern     string
+	MemoPattern            string
+	MinAmount              *decimal.Decimal
+	MaxAmount              *decimal.Decimal
+	SourceAccountGUID      *string
+	Counterparty           string
+	DestinationAccountGUID *string
+	PayeeRewrite           *string
+	Memo                   *string
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+}
+
+// CategorizedSplit is a split of a two-split transaction together with the
+// account on the other side, used to learn rules from history
+type CategorizedSplit struct {
+	Split              *Split
+	PostDate           time.Time
+	Description        string
+	CounterAccountGUID string
+}
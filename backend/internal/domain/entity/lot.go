@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// Lot represents a GnuCash lot: a group of splits in one account that tracks
+// a purchase of a commodity until it is fully sold
+type Lot struct {
+	GUID          string
+	AccountGUID   string
+	IsClosed      bool
+	Title         *string
+	Notes         *string
+	QuantityNum   int64
+	QuantityDenom int64
+	SplitCount    int
+	OpenedDate    *time.Time
+}
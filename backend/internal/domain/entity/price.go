@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// Price source and type values GnuCash writes to the prices table
+const (
+	PriceSourceUser = "user:price-editor"
+	PriceTypeLast   = "last"
+)
+
+// Price represents a GnuCash price database entry: the value of one unit of
+// Commodity expressed in Currency on Date
+type Price struct {
+	GUID              string
+	CommodityGUID     string
+	CommodityMnemonic string
+	CurrencyGUID      string
+	CurrencyMnemonic  string
+	Date              time.Time
+	Source            string
+	Type              string
+	ValueNum          int64
+	ValueDenom        int64
+}
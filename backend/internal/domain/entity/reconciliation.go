@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ReconcileInfo holds an account's reconciliation state. GnuCash keeps the
+// last statement date and any postponed reconciliation in the account's
+// reconcile-info slots; the reconciled balance is the sum of its reconciled
+// splits.
+type ReconcileInfo struct {
+	AccountGUID       string
+	LastDate          *time.Time
+	ReconciledBalance decimal.Decimal
+	Postponed         *ReconcileStatement
+}
+
+// ReconcileStatement is the bank statement an account is reconciled against.
+// EndingBalance uses the GnuCash sign (debits positive).
+type ReconcileStatement struct {
+	Date          time.Time
+	EndingBalance decimal.Decimal
+}
@@ -0,0 +1,178 @@
+package entity
+
+import "time"
+
+// Recurrence period types as stored in recurrences.recurrence_period_type
+const (
+	RecurrencePeriodOnce        = "once"
+	RecurrencePeriodDay         = "day"
+	RecurrencePeriodWeek        = "week"
+	RecurrencePeriodMonth       = "month"
+	RecurrencePeriodEndOfMonth  = "end of month"
+	RecurrencePeriodNthWeekday  = "nth weekday"
+	RecurrencePeriodLastWeekday = "last weekday"
+	RecurrencePeriodYear        = "year"
+)
+
+// Weekend adjustments as stored in recurrences.recurrence_weekend_adjust
+const (
+	WeekendAdjustNone    = "none"
+	WeekendAdjustBack    = "back"
+	WeekendAdjustForward = "forward"
+)
+
+// Recurrence represents a GnuCash recurrence: every Mult periods of
+// PeriodType, starting on PeriodStart. Budgets and scheduled transactions
+// attach recurrences through ObjGUID.
+type Recurrence struct {
+	ID            int
+	ObjGUID       string
+	Mult          int
+	PeriodType    string
+	PeriodStart   time.Time
+	WeekendAdjust string
+}
+
+// IsValidRecurrencePeriod returns true if GnuCash knows the period type
+func IsValidRecurrencePeriod(periodType string) bool {
+	switch periodType {
+	case RecurrencePeriodOnce, RecurrencePeriodDay, RecurrencePeriodWeek,
+		RecurrencePeriodMonth, RecurrencePeriodEndOfMonth, RecurrencePeriodNthWeekday,
+		RecurrencePeriodLastWeekday, RecurrencePeriodYear:
+		return true
+	default:
+		return false
+	}
+}
+
+// Nth returns the date of the n-th occurrence, where occurrence 0 is
+// PeriodStart. Weekend adjustment is not applied.
+func (r *Recurrence) Nth(n int) time.Time {
+	start := time.Date(r.PeriodStart.Year(), r.PeriodStart.Month(), r.PeriodStart.Day(), 0, 0, 0, 0, time.UTC)
+	mult := r.Mult
+	if mult < 1 {
+		mult = 1
+	}
+
+	switch r.PeriodType {
+	case RecurrencePeriodOnce:
+		return start
+	case RecurrencePeriodDay:
+		return start.AddDate(0, 0, mult*n)
+	case RecurrencePeriodWeek:
+		return start.AddDate(0, 0, 7*mult*n)
+	case RecurrencePeriodEndOfMonth:
+		return lastDayOfMonth(start.Year(), start.Month()+time.Month(mult*n))
+	case RecurrencePeriodNthWeekday:
+		year, month := monthOffset(start, mult*n)
+		nth := (start.Day()-1)/7 + 1
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		day := first.AddDate(0, 0, (int(start.Weekday())-int(first.Weekday())+7)%7+7*(nth-1))
+		if day.Month() != month {
+			day = day.AddDate(0, 0, -7)
+		}
+		return day
+	case RecurrencePeriodLastWeekday:
+		year, month := monthOffset(start, mult*n)
+		last := lastDayOfMonth(year, month)
+		return last.AddDate(0, 0, -((int(last.Weekday()) - int(start.Weekday()) + 7) % 7))
+	case RecurrencePeriodYear:
+		return addMonthsClamped(start, 12*mult*n)
+	default:
+		return addMonthsClamped(start, mult*n)
+	}
+}
+
+// Adjust moves a date that falls on a weekend according to WeekendAdjust
+func (r *Recurrence) Adjust(t time.Time) time.Time {
+	switch t.Weekday() {
+	case time.Saturday:
+		switch r.WeekendAdjust {
+		case WeekendAdjustBack:
+			return t.AddDate(0, 0, -1)
+		case WeekendAdjustForward:
+			return t.AddDate(0, 0, 2)
+		}
+	case time.Sunday:
+		switch r.WeekendAdjust {
+		case WeekendAdjustBack:
+			return t.AddDate(0, 0, -2)
+		case WeekendAdjustForward:
+			return t.AddDate(0, 0, 1)
+		}
+	}
+	return t
+}
+
+// NextAfter returns the first weekend-adjusted occurrence strictly after t,
+// or false if the recurrence has none (a one-off date already passed)
+func (r *Recurrence) NextAfter(t time.Time) (time.Time, bool) {
+	n := 0
+	if r.PeriodType != RecurrencePeriodOnce && t.After(r.PeriodStart) {
+		// Skip ahead using an upper bound on the period length so the first
+		// candidate is never past the answer. Month-based dates can sit up to
+		// a period after the plain offset and weekend adjustment moves them by
+		// up to two days, which the two steps back cover.
+		days := int(t.Sub(r.PeriodStart).Hours() / 24)
+		n = days/r.maxPeriodDays() - 2
+		if n < 0 {
+			n = 0
+		}
+	}
+
+	for ; n <= maxRecurrenceSteps; n++ {
+		next := r.Adjust(r.Nth(n))
+		if next.After(t) {
+			return next, true
+		}
+		if r.PeriodType == RecurrencePeriodOnce {
+			break
+		}
+	}
+	return time.Time{}, false
+}
+
+// maxRecurrenceSteps bounds the search in NextAfter
+const maxRecurrenceSteps = 100000
+
+// maxPeriodDays returns the longest a single step of the recurrence can be
+func (r *Recurrence) maxPeriodDays() int {
+	mult := r.Mult
+	if mult < 1 {
+		mult = 1
+	}
+
+	switch r.PeriodType {
+	case RecurrencePeriodDay:
+		return mult
+	case RecurrencePeriodWeek:
+		return 7 * mult
+	case RecurrencePeriodYear:
+		return 366 * mult
+	default:
+		return 31 * mult
+	}
+}
+
+// monthOffset returns the year and month that lie months after t's month
+func monthOffset(t time.Time, months int) (int, time.Month) {
+	first := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, months, 0)
+	return first.Year(), first.Month()
+}
+
+// lastDayOfMonth returns the last day of a month; month may overflow into later years
+func lastDayOfMonth(year int, month time.Month) time.Time {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+}
+
+// addMonthsClamped adds months to t, clamping the day to the end of the
+// resulting month the way GLib's g_date_add_months does
+func addMonthsClamped(t time.Time, months int) time.Time {
+	year, month := monthOffset(t, months)
+	last := lastDayOfMonth(year, month)
+	day := t.Day()
+	if day > last.Day() {
+		day = last.Day()
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,108 @@
+package entity
+
+import "time"
+
+// ScheduledTransaction represents a GnuCash scheduled transaction (SX). Its
+// template transactions post to TemplateAccountGUID, an account under the
+// template root, and name the real accounts in sched-xaction slots.
+type ScheduledTransaction struct {
+	GUID                string
+	Name                *string
+	Enabled             bool
+	StartDate           *time.Time
+	EndDate             *time.Time
+	LastOccur           *time.Time
+	NumOccur            int
+	RemOccur            int
+	AutoCreate          bool
+	AutoNotify          bool
+	AdvanceCreateDays   int
+	AdvanceNotifyDays   int
+	InstanceCount       int
+	TemplateAccountGUID string
+	Recurrences         []*Recurrence
+	Templates           []*TemplateTransaction
+}
+
+// TemplateTransaction is a transaction that a scheduled transaction copies
+// on every occurrence
+type TemplateTransaction struct {
+	GUID         string
+	CurrencyGUID string
+	Num          *string
+	Description  *string
+	Splits       []*TemplateSplit
+}
+
+// TemplateSplit is one split of a template transaction. Amounts are kept as
+// GnuCash formulas, with the numeric slots holding the evaluated value when
+// GnuCash has cached one (denominator 0 otherwise).
+type TemplateSplit struct {
+	GUID          string
+	AccountGUID   string
+	Memo          *string
+	Action        *string
+	DebitFormula  string
+	CreditFormula string
+	DebitNum      int64
+	DebitDenom    int64
+	CreditNum     int64
+	CreditDenom   int64
+}
+
+// IsExhausted reports whether a scheduled transaction with a fixed number of
+// occurrences has none left
+func (sx *ScheduledTransaction) IsExhausted() bool {
+	return sx.NumOccur > 0 && sx.RemOccur <= 0
+}
+
+// NextOccurrence returns the first occurrence strictly after t that is not
+// before the start date and not after the end date. It does not consider
+// LastOccur or the remaining occurrence count.
+func (sx *ScheduledTransaction) NextOccurrence(t time.Time) (time.Time, bool) {
+	if sx.StartDate != nil {
+		dayBefore := sx.StartDate.AddDate(0, 0, -1)
+		if t.Before(dayBefore) {
+			t = dayBefore
+		}
+	}
+
+	var next time.Time
+	found := false
+	for _, r := range sx.Recurrences {
+		candidate, ok := r.NextAfter(t)
+		if ok && (!found || candidate.Before(next)) {
+			next = candidate
+			found = true
+		}
+	}
+
+	if !found || (sx.EndDate != nil && next.After(*sx.EndDate)) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+// Occurrences returns up to limit occurrences after LastOccur, on or before
+// until, honoring the remaining occurrence count
+func (sx *ScheduledTransaction) Occurrences(until time.Time, limit int) []time.Time {
+	if sx.NumOccur > 0 && sx.RemOccur < limit {
+		limit = sx.RemOccur
+	}
+
+	var cursor time.Time
+	if sx.LastOccur != nil {
+		cursor = *sx.LastOccur
+	}
+
+	var dates []time.Time
+	for len(dates) < limit {
+		next, ok := sx.NextOccurrence(cursor)
+		if !ok || next.After(until) {
+			break
+		}
+		dates = append(dates, next)
+		cursor = next
+	}
+	return dates
+}
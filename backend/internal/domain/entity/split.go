@@ -1,15 +1,30 @@
 package entity
 
-import "github.com/shopspring/decimal"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Reconcile states used by GnuCash in splits.reconcile_state
+const (
+	ReconcileStateNew        = "n"
+	ReconcileStateCleared    = "c"
+	ReconcileStateReconciled = "y"
+	ReconcileStateFrozen     = "f"
+	ReconcileStateVoided     = "v"
+)
 
 // Split represents a GnuCash split (part of a transaction)
 type Split struct {
 	GUID           string
 	TxGUID         string
 	AccountGUID    string
+	LotGUID        *string
 	Memo           *string
 	Action         *string
 	ReconcileState string
+	ReconcileDate  *time.Time
 	ValueNum       int64
 	ValueDenom     int64
 	QuantityNum    int64
@@ -18,3 +33,33 @@ type Split struct {
 	Quantity       decimal.Decimal
 	Account        *Account
 }
+
+// AccountSplit is a split together with the transaction fields needed to
+// show it in an account register. BankDescription is the statement text of
+// an imported split whose description an import rule rewrote.
+type AccountSplit struct {
+	Split           *Split
+	PostDate        time.Time
+	EnterDate       time.Time
+	Num             *string
+	Description     *string
+	BankDescription *string
+}
+
+// RegisterPosition is a split's place in an account register, which orders
+// splits by post date, entry date and split GUID
+type RegisterPosition struct {
+	PostDate  time.Time
+	EnterDate time.Time
+	SplitGUID string
+}
+
+// RegisterSplit is a row of an account register: a split with the other side
+// of its transaction
+type RegisterSplit struct {
+	AccountSplit
+	// OtherSplitCount is the number of other splits in the transaction
+	OtherSplitCount int
+	// OtherAccountGUID is set when the transaction has exactly one other split
+	OtherAccountGUID *string
+}
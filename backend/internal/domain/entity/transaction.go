@@ -1,6 +1,12 @@
 package entity
 
-import "time"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+)
 
 // Transaction represents a GnuCash transaction
 type Transaction struct {
@@ -12,4 +18,54 @@ type Transaction struct {
 	EnterDate         time.Time
 	Description       *string
 	Splits            []*Split
+	Voided            bool
+	VoidReason        *string
+	ReversedByGUID    *string
+	ReversalOfGUID    *string
+}
+
+// Version returns an opaque fingerprint of the transaction row and its splits.
+// It changes whenever any stored field changes and is used as an ETag for
+// optimistic concurrency control.
+func (t *Transaction) Version() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%s|%s|%d|%d|%s\n",
+		t.GUID, t.CurrencyGUID, StringOrEmpty(t.Num),
+		t.PostDate.Unix(), t.EnterDate.Unix(), StringOrEmpty(t.Description))
+
+	splits := make([]*Split, len(t.Splits))
+	copy(splits, t.Splits)
+	sort.Slice(splits, func(i, j int) bool { return splits[i].GUID < splits[j].GUID })
+
+	for _, s := range splits {
+		var reconcileDate int64
+		if s.ReconcileDate != nil {
+			reconcileDate = s.ReconcileDate.Unix()
+		}
+		fmt.Fprintf(h, "%s|%s|%s|%s|%s|%d|%d/%d|%d/%d\n",
+			s.GUID, s.AccountGUID, StringOrEmpty(s.Memo), StringOrEmpty(s.Action),
+			s.ReconcileState, reconcileDate,
+			s.ValueNum, s.ValueDenom, s.QuantityNum, s.QuantityDenom)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))[:32]
+}
+
+// HasReconciledSplits reports whether any split has been reconciled
+func (t *Transaction) HasReconciledSplits() bool {
+	for _, s := range t.Splits {
+		if s.ReconcileState == ReconcileStateReconciled {
+			return true
+		}
+	}
+	return false
+}
+
+// StringOrEmpty returns the value of an optional string or "" when nil;
+// GnuCash stores missing text as an empty string
+func StringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
 )
@@ -22,4 +23,35 @@ type AccountRepository interface {
 
 	// GetBalance calculates the current balance for an account
 	GetBalance(ctx context.Context, guid string) (int64, int64, error)
+
+	// GetBalanceAsOf calculates the balance for an account from transactions posted on or before asOf
+	GetBalanceAsOf(ctx context.Context, guid string, asOf time.Time) (int64, int64, error)
+
+	// GetBalanceBefore calculates the balance of the splits ordered before a register position
+	GetBalanceBefore(ctx context.Context, guid string, position entity.RegisterPosition) (int64, int64, error)
+
+	// FindRegister lists up to limit splits of an account in register order,
+	// starting after the given position or from the first split when it is nil
+	FindRegister(ctx context.Context, guid string, after *entity.RegisterPosition, limit int) ([]*entity.RegisterSplit, error)
+
+	// FindRootGUID returns the GUID of the book's root account
+	FindRootGUID(ctx context.Context) (string, error)
+
+	// CountSplits returns the number of splits posted to an account
+	CountSplits(ctx context.Context, guid string) (int64, error)
+
+	// CountTransactions returns the number of distinct transactions touching an account
+	CountTransactions(ctx context.Context, guid string) (int64, error)
+
+	// Create inserts a new account
+	Create(ctx context.Context, account *entity.Account) error
+
+	// Update writes an account's name, type, parent, code, description and flags
+	Update(ctx context.Context, account *entity.Account) error
+
+	// Delete removes an account, optionally moving its splits to reassignTo first
+	Delete(ctx context.Context, guid string, reassignTo *string) error
+
+	// Merge moves all splits and child accounts from source to target, then deletes or hides source
+	Merge(ctx context.Context, sourceGUID, targetGUID string, hideSource bool) error
 }
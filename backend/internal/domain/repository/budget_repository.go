@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+)
+
+// BudgetRepository defines the interface for budget data access
+type BudgetRepository interface {
+	// FindAll retrieves all budgets with their recurrence
+	FindAll(ctx context.Context) ([]*entity.Budget, error)
+
+	// FindByGUID retrieves a budget with its recurrence
+	FindByGUID(ctx context.Context, guid string) (*entity.Budget, error)
+
+	// FindAmounts retrieves every amount set in a budget
+	FindAmounts(ctx context.Context, budgetGUID string) ([]*entity.BudgetAmount, error)
+
+	// Create inserts a budget, its recurrence and any initial amounts atomically
+	Create(ctx context.Context, budget *entity.Budget, amounts []*entity.BudgetAmount) error
+
+	// Update writes a budget's name, description and period count, dropping
+	// amounts for periods beyond the new count
+	Update(ctx context.Context, budget *entity.Budget) error
+
+	// Delete removes a budget with its amounts, recurrence and slots
+	Delete(ctx context.Context, guid string) error
+
+	// UpdateAmounts stores the given amounts and removes the cleared ones
+	// (matched by account and period) in a single database transaction
+	UpdateAmounts(ctx context.Context, budgetGUID string, set, cleared []*entity.BudgetAmount) error
+}
@@ -13,4 +13,7 @@ type CommodityRepository interface {
 
 	// FindByGUID retrieves a commodity by its GUID
 	FindByGUID(ctx context.Context, guid string) (*entity.Commodity, error)
+
+	// FindByMnemonic retrieves commodities with the given mnemonic (e.g. USD, AAPL) across namespaces
+	FindByMnemonic(ctx context.Context, mnemonic string) ([]*entity.Commodity, error)
 }
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+)
+
+// CSVProfileRepository defines the interface for CSV mapping profile data access
+type CSVProfileRepository interface {
+	// FindAll retrieves every profile ordered by name
+	FindAll(ctx context.Context) ([]*entity.CSVProfile, error)
+
+	// FindByID retrieves a profile by its ID
+	FindByID(ctx context.Context, id int64) (*entity.CSVProfile, error)
+
+	// Create stores a new profile. It returns ErrConflict if the name is taken.
+	Create(ctx context.Context, profile *entity.CSVProfile) error
+
+	// Update replaces a profile. It returns ErrConflict if the name is taken.
+	Update(ctx context.Context, profile *entity.CSVProfile) error
+
+	// Delete removes a profile
+	Delete(ctx context.Context, id int64) error
+}
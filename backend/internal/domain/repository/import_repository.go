@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+)
+
+// ImportRepository defines the interface for statement import data access
+type ImportRepository interface {
+	// FindByOnlineIDs returns the GUIDs of the transactions whose split in the
+	// account carries one of the given online_id slots, keyed by online id
+	FindByOnlineIDs(ctx context.Context, accountGUID string, onlineIDs []string) (map[string]string, error)
+
+	// FindCounterAccountsByIBAN returns, for each counterparty IBAN, the
+	// counter account of the latest two-split transaction imported with it
+	FindCounterAccountsByIBAN(ctx context.Context, ibans []string) (map[string]string, error)
+
+	// FindCategorizedHistory lists the splits of two-split transactions
+	// posted since the given date, newest first, with their counter account.
+	// An empty accountGUID covers the book's BANK, CASH and CREDIT accounts.
+	FindCategorizedHistory(ctx context.Context, accountGUID string, since time.Time) ([]*entity.CategorizedSplit, error)
+
+	// FindAccountSplits lists the account's splits in transactions posted
+	// within the date range, with their transaction's date and description
+	// and the statement text of rewritten imports
+	FindAccountSplits(ctx context.Context, accountGUID string, start, end time.Time) ([]*entity.AccountSplit, error)
+
+	// Commit stores imported transactions in a single database transaction,
+	// skipping those whose online id is already present in the account or
+	// earlier in the batch. It returns the imports that were skipped.
+	Commit(ctx context.Context, accountGUID string, imports []*entity.ImportedTransaction) ([]*entity.ImportedTransaction, error)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+)
+
+// ImportRuleRepository defines the interface for import rule data access
+type ImportRuleRepository interface {
+	// FindAll retrieves every rule in the order rules are applied: by
+	// priority, then by ID
+	FindAll(ctx context.Context) ([]*entity.ImportRule, error)
+
+	// FindByID retrieves a rule by its ID
+	FindByID(ctx context.Context, id int64) (*entity.ImportRule, error)
+
+	// Create stores a new rule. It returns ErrConflict if the name is taken.
+	Create(ctx context.Context, rule *entity.ImportRule) error
+
+	// Update replaces a rule. It returns ErrConflict if the name is taken.
+	Update(ctx context.Context, rule *entity.ImportRule) error
+
+	// Delete removes a rule
+	Delete(ctx context.Context, id int64) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+)
+
+// LotRepository defines the interface for lot data access
+type LotRepository interface {
+	// FindByGUID retrieves a lot by its GUID
+	FindByGUID(ctx context.Context, guid string) (*entity.Lot, error)
+
+	// FindByAccount retrieves the lots of an account, oldest first
+	FindByAccount(ctx context.Context, accountGUID string) ([]*entity.Lot, error)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+)
+
+// PriceRepository defines the interface for price database access.
+// An empty currencyGUID matches prices quoted in any currency.
+type PriceRepository interface {
+	// FindLatest retrieves the most recent price for a commodity
+	FindLatest(ctx context.Context, commodityGUID, currencyGUID string) (*entity.Price, error)
+
+	// FindAsOf retrieves the most recent price for a commodity on or before the given time
+	FindAsOf(ctx context.Context, commodityGUID, currencyGUID string, asOf time.Time) (*entity.Price, error)
+
+	// FindHistory retrieves prices for a commodity between two dates, oldest first
+	FindHistory(ctx context.Context, commodityGUID, currencyGUID string, startDate, endDate *time.Time) ([]*entity.Price, error)
+
+	// FindAllAsOf retrieves the most recent price of every commodity/currency pair on or before the given time
+	FindAllAsOf(ctx context.Context, asOf time.Time) ([]*entity.Price, error)
+
+	// Create inserts a new price
+	Create(ctx context.Context, price *entity.Price) error
+}
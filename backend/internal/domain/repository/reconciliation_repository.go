@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+)
+
+// ReconciliationRepository defines the interface for account reconciliation data access
+type ReconciliationRepository interface {
+	// GetInfo reads an account's last reconcile date, reconciled balance and
+	// postponed statement
+	GetInfo(ctx context.Context, accountGUID string) (*entity.ReconcileInfo, error)
+
+	// FindUnreconciledSplits lists the account's new and cleared splits posted
+	// on or before through, oldest first
+	FindUnreconciledSplits(ctx context.Context, accountGUID string, through time.Time) ([]*entity.AccountSplit, error)
+
+	// SavePostponed records the statement being reconciled in the account's
+	// reconcile-info/postpone slots, replacing any earlier one
+	SavePostponed(ctx context.Context, accountGUID string, statement *entity.ReconcileStatement) error
+
+	// ClearPostponed removes the postponed statement
+	ClearPostponed(ctx context.Context, accountGUID string) error
+
+	// SetCleared marks the given splits of the account as cleared or new. It
+	// returns ErrConflict if any split is not a new or cleared split of the account.
+	SetCleared(ctx context.Context, accountGUID string, splitGUIDs []string, cleared bool) error
+
+	// Finish marks the cleared splits posted on or before the statement date
+	// as reconciled on that date, records it as the last reconcile date and
+	// removes the postponed statement. It returns ErrConflict if the cleared
+	// balance no longer equals the statement's ending balance.
+	Finish(ctx context.Context, accountGUID string, statement *entity.ReconcileStatement) error
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+)
+
+// ScheduledTransactionRepository defines the interface for scheduled transaction data access
+type ScheduledTransactionRepository interface {
+	// FindAll retrieves all scheduled transactions with their recurrences and templates
+	FindAll(ctx context.Context) ([]*entity.ScheduledTransaction, error)
+
+	// FindByGUID retrieves a scheduled transaction with its recurrences and templates
+	FindByGUID(ctx context.Context, guid string) (*entity.ScheduledTransaction, error)
+
+	// PostInstance stores the transactions created for one occurrence and
+	// advances the scheduled transaction's last occurrence, remaining count and
+	// instance count atomically. It returns ErrConflict if the scheduled
+	// transaction was advanced since sx was loaded.
+	PostInstance(ctx context.Context, sx *entity.ScheduledTransaction, occurrence time.Time, txs []*entity.Transaction) error
+}
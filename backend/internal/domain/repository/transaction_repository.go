@@ -4,19 +4,26 @@ import (
 	"context"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
 )
 
-// TransactionFilter defines filtering criteria for transactions
+// TransactionFilter defines filtering criteria for transactions.
+// MinAmount and MaxAmount bound the absolute quantity of a split in the
+// filtered account, or of any split when no account is given. OldestFirst
+// orders by post date ascending instead of newest first, and ExcludeTemplates
+// leaves out the template transactions of scheduled transactions.
 type TransactionFilter struct {
-	AccountGUID  *string
-	StartDate    *time.Time
-	EndDate      *time.Time
-	Description  *string
-	MinAmount    *int64
-	MaxAmount    *int64
-	Limit        int
-	Offset       int
+	AccountGUID      *string
+	StartDate        *time.Time
+	EndDate          *time.Time
+	Description      *string
+	MinAmount        *decimal.Decimal
+	MaxAmount        *decimal.Decimal
+	OldestFirst      bool
+	ExcludeTemplates bool
+	Limit            int
+	Offset           int
 }
 
 // AccountAggregate represents aggregated data for an account
@@ -28,6 +35,19 @@ type AccountAggregate struct {
 	Count       int
 }
 
+// DebitCreditTotal holds the debit and credit totals of an account's split
+// values in one transaction currency, each as an exact fraction. Credits are
+// positive.
+type DebitCreditTotal struct {
+	AccountGUID  string
+	CurrencyGUID string
+	DebitNum     int64
+	DebitDenom   int64
+	CreditNum    int64
+	CreditDenom  int64
+	SplitCount   int
+}
+
 // TransactionRepository defines the interface for transaction data access
 type TransactionRepository interface {
 	// FindAll retrieves all transactions with optional filtering
@@ -44,4 +64,43 @@ type TransactionRepository interface {
 
 	// AggregateByAccountType returns aggregated transaction data grouped by account for accounts of specified type
 	AggregateByAccountType(ctx context.Context, accountType entity.AccountType, startDate, endDate *time.Time) ([]*AccountAggregate, error)
+
+	// SumQuantityByAccount returns the signed sum of split quantities per account
+	// for transactions posted within the optional date range
+	SumQuantityByAccount(ctx context.Context, startDate, endDate *time.Time) ([]*AccountAggregate, error)
+
+	// SumDebitsCredits returns the debit and credit totals of split values per
+	// account and transaction currency for transactions posted within the
+	// optional date range
+	SumDebitsCredits(ctx context.Context, startDate, endDate *time.Time) ([]*DebitCreditTotal, error)
+
+	// SumSpendingByAccount returns the signed sum of split quantities per account
+	// for transactions posted within the date range that touch an EXPENSE account
+	// and were not created from a scheduled transaction
+	SumSpendingByAccount(ctx context.Context, startDate, endDate time.Time) ([]*AccountAggregate, error)
+
+	// Create inserts a transaction together with its splits atomically
+	Create(ctx context.Context, tx *entity.Transaction) error
+
+	// Update replaces a transaction and its split set atomically.
+	// It returns ErrConflict if the stored transaction no longer matches expectedVersion.
+	Update(ctx context.Context, tx *entity.Transaction, expectedVersion string) error
+
+	// Delete removes a transaction and its splits.
+	// It returns ErrConflict if the stored transaction no longer matches expectedVersion.
+	Delete(ctx context.Context, guid string, expectedVersion string) error
+
+	// Void zeroes a transaction's splits and records the void reason in slots.
+	// It returns ErrConflict if the transaction changed or is already voided.
+	Void(ctx context.Context, guid string, reason string, expectedVersion string) error
+
+	// Reverse stores a reversing transaction linked from the original.
+	// It returns ErrConflict if the original is voided or already reversed.
+	Reverse(ctx context.Context, originalGUID string, reversal *entity.Transaction) error
+
+	// MoveSplits moves splits from one account to another in a single database
+	// transaction, detaching them from their lots and setting reconciled
+	// splits back to not reconciled.
+	// It returns ErrConflict if any split is no longer in sourceGUID.
+	MoveSplits(ctx context.Context, splitGUIDs []string, sourceGUID, targetGUID string) error
 }
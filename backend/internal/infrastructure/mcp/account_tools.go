@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
 	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
 )
 
@@ -24,6 +25,14 @@ type AccountsBalanceParams struct {
 	GUID string `json:"guid" jsonschema:"required,Account GUID to get balance for"`
 }
 
+// AccountsMergeParams defines parameters for accounts_merge tool
+type AccountsMergeParams struct {
+	SourceGUID string `json:"source_guid" jsonschema:"required,GUID of the account to merge away"`
+	TargetGUID string `json:"target_guid" jsonschema:"required,GUID of the account that receives the splits and child accounts"`
+	DryRun     bool   `json:"dry_run,omitempty" jsonschema:"Only report what would move without changing anything"`
+	HideSource bool   `json:"hide_source,omitempty" jsonschema:"Hide the source account instead of deleting it"`
+}
+
 // handleAccountsList handles the accounts_list tool
 func (s *MCPServer) handleAccountsList(ctx context.Context, req *mcp.CallToolRequest, params *AccountsListParams) (*mcp.CallToolResult, any, error) {
 	var accountType entity.AccountType
@@ -149,4 +158,27 @@ func (s *MCPServer) handleAccountsBalance(ctx context.Context, req *mcp.CallTool
 			&mcp.TextContent{Text: string(jsonData)},
 		},
 	}, nil, nil
-}
\ No newline at end of file
+}
+// handleAccountsMerge handles the accounts_merge tool
+func (s *MCPServer) handleAccountsMerge(ctx context.Context, req *mcp.CallToolRequest, params *AccountsMergeParams) (*mcp.CallToolResult, any, error) {
+	if params.SourceGUID == "" || params.TargetGUID == "" {
+		return nil, nil, fmt.Errorf("missing required parameters: source_guid and target_guid")
+	}
+
+	result, err := s.accountService.MergeAccounts(ctx, params.SourceGUID, &dto.MergeAccountsRequest{
+		TargetGUID: params.TargetGUID,
+		DryRun:     params.DryRun,
+		HideSource: params.HideSource,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to merge accounts: %w", err)
+	}
+
+	jsonData, _ := json.MarshalIndent(result, "", "  ")
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(jsonData)},
+		},
+	}, nil, nil
+}
@@ -63,24 +63,143 @@ func (s *MCPServer) handleAnalyticsIncome(ctx context.Context, req *mcp.CallTool
 	}, nil, nil
 }
 
+// AnalyticsCashflowParams defines parameters for analytics_cashflow tool
+type AnalyticsCashflowParams struct {
+	StartDate      string            `json:"start_date,omitempty" jsonschema:"Start date in YYYY-MM-DD format (defaults to the start of the current year)"`
+	EndDate        string            `json:"end_date,omitempty" jsonschema:"End date in YYYY-MM-DD format (defaults to today)"`
+	Currency       string            `json:"currency,omitempty" jsonschema:"Report currency GUID or mnemonic (defaults to the book currency)"`
+	TypeBuckets    map[string]string `json:"type_buckets,omitempty" jsonschema:"Section per account type overriding the defaults, e.g. {\"ASSET\": \"operating\"}; sections are operating, investing and financing"`
+	AccountBuckets map[string]string `json:"account_buckets,omitempty" jsonschema:"Section per account GUID, applying to the account and its descendants"`
+}
+
 // handleAnalyticsCashflow handles the analytics_cashflow tool
-func (s *MCPServer) handleAnalyticsCashflow(ctx context.Context, req *mcp.CallToolRequest, params *AnalyticsDateRangeParams) (*mcp.CallToolResult, any, error) {
-	startDate, endDate := parseDateRange(params.StartDate, params.EndDate)
+func (s *MCPServer) handleAnalyticsCashflow(ctx context.Context, req *mcp.CallToolRequest, params *AnalyticsCashflowParams) (*mcp.CallToolResult, any, error) {
+	now := time.Now()
+	startDate := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate := now
+	if params.StartDate != "" {
+		t, err := time.Parse("2006-01-02", params.StartDate)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid start_date, use YYYY-MM-DD")
+		}
+		startDate = t
+	}
+	if params.EndDate != "" {
+		t, err := time.Parse("2006-01-02", params.EndDate)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid end_date, use YYYY-MM-DD")
+		}
+		endDate = t
+	}
 
-	result, err := s.analyticsService.GetIncomeExpense(ctx, startDate, endDate)
+	result, err := s.analyticsService.GetCashFlowStatement(ctx, startDate, endDate, params.Currency, params.TypeBuckets, params.AccountBuckets)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get cashflow: %w", err)
 	}
 
-	jsonData, _ := json.MarshalIndent(map[string]any{
-		"currency":      result.CurrencyMnemonic,
-		"total_income":  result.TotalIncome,
-		"total_expense": result.TotalExpense,
-		"net_cashflow":  result.NetTotal,
-		"data":          result.Data,
-		"start_date":    startDate.Format("2006-01-02"),
-		"end_date":      endDate.Format("2006-01-02"),
-	}, "", "  ")
+	jsonData, _ := json.MarshalIndent(result, "", "  ")
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(jsonData)},
+		},
+	}, nil, nil
+}
+
+// AnalyticsCapitalGainsParams defines parameters for analytics_capital_gains tool
+type AnalyticsCapitalGainsParams struct {
+	Year     int    `json:"year,omitempty" jsonschema:"Tax year, e.g. 2024 (defaults to the current year)"`
+	Method   string `json:"method,omitempty" jsonschema:"Cost basis method: fifo (default), lifo or average"`
+	Currency string `json:"currency,omitempty" jsonschema:"Report currency GUID or mnemonic (defaults to the book currency)"`
+}
+
+// handleAnalyticsCapitalGains handles the analytics_capital_gains tool
+func (s *MCPServer) handleAnalyticsCapitalGains(ctx context.Context, req *mcp.CallToolRequest, params *AnalyticsCapitalGainsParams) (*mcp.CallToolResult, any, error) {
+	year := params.Year
+	if year == 0 {
+		year = time.Now().Year()
+	}
+
+	result, err := s.analyticsService.GetCapitalGains(ctx, year, params.Method, params.Currency)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get capital gains: %w", err)
+	}
+
+	jsonData, _ := json.MarshalIndent(result, "", "  ")
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(jsonData)},
+		},
+	}, nil, nil
+}
+
+// AnalyticsForecastParams defines parameters for analytics_forecast tool
+type AnalyticsForecastParams struct {
+	Days           int    `json:"days,omitempty" jsonschema:"Number of days to project (defaults to 30, at most 366)"`
+	Threshold      string `json:"threshold,omitempty" jsonschema:"Flag bank accounts whose balance drops below this amount (defaults to 0)"`
+	CreditLimit    string `json:"credit_limit,omitempty" jsonschema:"Flag credit accounts that owe more than this positive amount (credit accounts are not flagged without it)"`
+	IncludeHistory bool   `json:"include_history,omitempty" jsonschema:"Also project recurring spending from the median of recent months"`
+	HistoryMonths  int    `json:"history_months,omitempty" jsonschema:"Number of past months used for the spending projection (defaults to 3)"`
+	IncludeDays    bool   `json:"include_days,omitempty" jsonschema:"Include the day-by-day balances and events (defaults to only account summaries and alerts)"`
+}
+
+// handleAnalyticsForecast handles the analytics_forecast tool
+func (s *MCPServer) handleAnalyticsForecast(ctx context.Context, req *mcp.CallToolRequest, params *AnalyticsForecastParams) (*mcp.CallToolResult, any, error) {
+	result, err := s.analyticsService.GetForecast(ctx, params.Days, params.Threshold, params.CreditLimit, params.IncludeHistory, params.HistoryMonths)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get forecast: %w", err)
+	}
+
+	if !params.IncludeDays {
+		result.Days = nil
+	}
+
+	jsonData, _ := json.MarshalIndent(result, "", "  ")
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(jsonData)},
+		},
+	}, nil, nil
+}
+
+// AnalyticsIncomeStatementParams defines parameters for analytics_income_statement tool
+type AnalyticsIncomeStatementParams struct {
+	StartDate     string `json:"start_date,omitempty" jsonschema:"Start date in YYYY-MM-DD format (defaults to the start of the current year)"`
+	EndDate       string `json:"end_date,omitempty" jsonschema:"End date in YYYY-MM-DD format (defaults to today)"`
+	Period        string `json:"period,omitempty" jsonschema:"Column length: month (default), quarter or year"`
+	Currency      string `json:"currency,omitempty" jsonschema:"Report currency GUID or mnemonic (defaults to the book currency)"`
+	IncludeHidden bool   `json:"include_hidden,omitempty" jsonschema:"Include hidden accounts"`
+	IncludeZero   bool   `json:"include_zero,omitempty" jsonschema:"Include accounts without activity"`
+}
+
+// handleAnalyticsIncomeStatement handles the analytics_income_statement tool
+func (s *MCPServer) handleAnalyticsIncomeStatement(ctx context.Context, req *mcp.CallToolRequest, params *AnalyticsIncomeStatementParams) (*mcp.CallToolResult, any, error) {
+	now := time.Now()
+	startDate := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate := now
+	if params.StartDate != "" {
+		t, err := time.Parse("2006-01-02", params.StartDate)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid start_date, use YYYY-MM-DD")
+		}
+		startDate = t
+	}
+	if params.EndDate != "" {
+		t, err := time.Parse("2006-01-02", params.EndDate)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid end_date, use YYYY-MM-DD")
+		}
+		endDate = t
+	}
+
+	result, err := s.analyticsService.GetIncomeStatement(ctx, startDate, endDate, params.Period, params.Currency, params.IncludeHidden, params.IncludeZero)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get income statement: %w", err)
+	}
+
+	jsonData, _ := json.MarshalIndent(result, "", "  ")
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
@@ -0,0 +1,140 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+)
+
+// BudgetsReportParams defines parameters for budgets_report tool
+type BudgetsReportParams struct {
+	GUID   string `json:"guid" jsonschema:"required,Budget GUID"`
+	Period *int   `json:"period,omitempty" jsonschema:"Zero-based budget period to report on (defaults to all periods; see current_period)"`
+}
+
+// BudgetsCreateParams defines parameters for budgets_create tool
+type BudgetsCreateParams struct {
+	Name                string `json:"name" jsonschema:"required,Budget name"`
+	Description         string `json:"description,omitempty" jsonschema:"Budget description"`
+	NumPeriods          int    `json:"num_periods,omitempty" jsonschema:"Number of periods (defaults to 12)"`
+	PeriodType          string `json:"period_type,omitempty" jsonschema:"Period type: month (default), week, day, year, end of month, nth weekday, last weekday"`
+	Mult                int    `json:"mult,omitempty" jsonschema:"Length of each period in period_type units (defaults to 1)"`
+	PeriodStart         string `json:"period_start,omitempty" jsonschema:"First day of the first period in YYYY-MM-DD format (defaults to January 1st of this year)"`
+	FromLastYearActuals bool   `json:"from_last_year_actuals,omitempty" jsonschema:"Fill income and expense amounts with the actuals of the same periods one year earlier"`
+}
+
+// BudgetsSetAmountsParams defines parameters for budgets_set_amounts tool
+type BudgetsSetAmountsParams struct {
+	GUID        string `json:"guid" jsonschema:"required,Budget GUID"`
+	AccountGUID string `json:"account_guid" jsonschema:"required,Account GUID"`
+	Amount      string `json:"amount" jsonschema:"required,Amount per period, positive in the account's natural direction (e.g. 400 for a 400 spending budget)"`
+	StartPeriod *int   `json:"start_period,omitempty" jsonschema:"First zero-based period to set (defaults to 0)"`
+	EndPeriod   *int   `json:"end_period,omitempty" jsonschema:"Last zero-based period to set (defaults to the last period)"`
+}
+
+// handleBudgetsList handles the budgets_list tool
+func (s *MCPServer) handleBudgetsList(ctx context.Context, req *mcp.CallToolRequest, params *struct{}) (*mcp.CallToolResult, any, error) {
+	budgets, err := s.budgetService.ListBudgets(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list budgets: %w", err)
+	}
+
+	if len(budgets) == 0 {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: "No budgets found."},
+			},
+		}, nil, nil
+	}
+
+	jsonData, _ := json.MarshalIndent(map[string]any{
+		"budgets": budgets,
+		"count":   len(budgets),
+	}, "", "  ")
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(jsonData)},
+		},
+	}, nil, nil
+}
+
+// handleBudgetsReport handles the budgets_report tool
+func (s *MCPServer) handleBudgetsReport(ctx context.Context, req *mcp.CallToolRequest, params *BudgetsReportParams) (*mcp.CallToolResult, any, error) {
+	report, err := s.analyticsService.GetBudgetReport(ctx, params.GUID, params.Period)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get budget report: %w", err)
+	}
+
+	jsonData, _ := json.MarshalIndent(report, "", "  ")
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(jsonData)},
+		},
+	}, nil, nil
+}
+
+// handleBudgetsCreate handles the budgets_create tool
+func (s *MCPServer) handleBudgetsCreate(ctx context.Context, req *mcp.CallToolRequest, params *BudgetsCreateParams) (*mcp.CallToolResult, any, error) {
+	createReq := dto.CreateBudgetRequest{
+		Name:       params.Name,
+		NumPeriods: params.NumPeriods,
+		Recurrence: &dto.RecurrenceRequest{
+			Mult:        params.Mult,
+			PeriodType:  params.PeriodType,
+			PeriodStart: params.PeriodStart,
+		},
+	}
+	if params.Description != "" {
+		createReq.Description = &params.Description
+	}
+
+	var budget *dto.BudgetResponse
+	var err error
+	if params.FromLastYearActuals {
+		budget, err = s.budgetService.CreateBudgetFromActuals(ctx, &dto.CreateBudgetFromActualsRequest{CreateBudgetRequest: createReq})
+	} else {
+		budget, err = s.budgetService.CreateBudget(ctx, &createReq)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create budget: %w", err)
+	}
+
+	jsonData, _ := json.MarshalIndent(map[string]any{
+		"budget": budget,
+	}, "", "  ")
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(jsonData)},
+		},
+	}, nil, nil
+}
+
+// handleBudgetsSetAmounts handles the budgets_set_amounts tool
+func (s *MCPServer) handleBudgetsSetAmounts(ctx context.Context, req *mcp.CallToolRequest, params *BudgetsSetAmountsParams) (*mcp.CallToolResult, any, error) {
+	amounts, err := s.budgetService.FillAmounts(ctx, params.GUID, &dto.FillBudgetAmountsRequest{
+		AccountGUID: params.AccountGUID,
+		Amount:      params.Amount,
+		StartPeriod: params.StartPeriod,
+		EndPeriod:   params.EndPeriod,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to set budget amounts: %w", err)
+	}
+
+	jsonData, _ := json.MarshalIndent(map[string]any{
+		"amounts": amounts,
+		"count":   len(amounts),
+	}, "", "  ")
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(jsonData)},
+		},
+	}, nil, nil
+}
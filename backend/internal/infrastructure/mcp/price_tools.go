@@ -0,0 +1,146 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+)
+
+// PricesLatestParams defines parameters for prices_latest tool
+type PricesLatestParams struct {
+	Commodity string `json:"commodity" jsonschema:"required,Commodity GUID or mnemonic (e.g. AAPL)"`
+	Currency  string `json:"currency,omitempty" jsonschema:"Currency GUID or mnemonic (e.g. USD); any currency if omitted"`
+}
+
+// PricesAsOfParams defines parameters for prices_as_of tool
+type PricesAsOfParams struct {
+	Commodity string `json:"commodity" jsonschema:"required,Commodity GUID or mnemonic (e.g. AAPL)"`
+	Currency  string `json:"currency,omitempty" jsonschema:"Currency GUID or mnemonic (e.g. USD); any currency if omitted"`
+	Date      string `json:"date" jsonschema:"required,Date in YYYY-MM-DD format; the latest price on or before it is returned"`
+}
+
+// PricesHistoryParams defines parameters for prices_history tool
+type PricesHistoryParams struct {
+	Commodity string `json:"commodity" jsonschema:"required,Commodity GUID or mnemonic (e.g. AAPL)"`
+	Currency  string `json:"currency,omitempty" jsonschema:"Currency GUID or mnemonic (e.g. USD); any currency if omitted"`
+	StartDate string `json:"start_date,omitempty" jsonschema:"Start date in YYYY-MM-DD format"`
+	EndDate   string `json:"end_date,omitempty" jsonschema:"End date in YYYY-MM-DD format"`
+}
+
+// PricesCreateParams defines parameters for prices_create tool
+type PricesCreateParams struct {
+	Commodity string `json:"commodity" jsonschema:"required,Commodity GUID or mnemonic (e.g. AAPL)"`
+	Currency  string `json:"currency" jsonschema:"required,Currency GUID or mnemonic (e.g. USD)"`
+	Date      string `json:"date,omitempty" jsonschema:"Date in YYYY-MM-DD format (defaults to today)"`
+	Value     string `json:"value" jsonschema:"required,Price of one unit of the commodity in the currency"`
+}
+
+// handlePricesLatest handles the prices_latest tool
+func (s *MCPServer) handlePricesLatest(ctx context.Context, req *mcp.CallToolRequest, params *PricesLatestParams) (*mcp.CallToolResult, any, error) {
+	price, err := s.priceService.GetLatest(ctx, params.Commodity, params.Currency)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get latest price: %w", err)
+	}
+
+	jsonData, _ := json.MarshalIndent(map[string]any{
+		"price": formatPrice(price),
+	}, "", "  ")
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(jsonData)},
+		},
+	}, nil, nil
+}
+
+// handlePricesAsOf handles the prices_as_of tool
+func (s *MCPServer) handlePricesAsOf(ctx context.Context, req *mcp.CallToolRequest, params *PricesAsOfParams) (*mcp.CallToolResult, any, error) {
+	price, err := s.priceService.GetAsOf(ctx, params.Commodity, params.Currency, params.Date)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get price: %w", err)
+	}
+
+	jsonData, _ := json.MarshalIndent(map[string]any{
+		"as_of": params.Date,
+		"price": formatPrice(price),
+	}, "", "  ")
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(jsonData)},
+		},
+	}, nil, nil
+}
+
+// handlePricesHistory handles the prices_history tool
+func (s *MCPServer) handlePricesHistory(ctx context.Context, req *mcp.CallToolRequest, params *PricesHistoryParams) (*mcp.CallToolResult, any, error) {
+	var startDate, endDate *time.Time
+	if params.StartDate != "" {
+		t, err := time.Parse("2006-01-02", params.StartDate)
+		if err == nil {
+			startDate = &t
+		}
+	}
+	if params.EndDate != "" {
+		t, err := time.Parse("2006-01-02", params.EndDate)
+		if err == nil {
+			endDate = &t
+		}
+	}
+
+	prices, err := s.priceService.GetHistory(ctx, params.Commodity, params.Currency, startDate, endDate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get price history: %w", err)
+	}
+
+	if len(prices) == 0 {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: "No prices found."},
+			},
+		}, nil, nil
+	}
+
+	result := make([]map[string]any, 0, len(prices))
+	for _, p := range prices {
+		result = append(result, formatPrice(p))
+	}
+
+	jsonData, _ := json.MarshalIndent(map[string]any{
+		"prices": result,
+		"count":  len(prices),
+	}, "", "  ")
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(jsonData)},
+		},
+	}, nil, nil
+}
+
+// handlePricesCreate handles the prices_create tool
+func (s *MCPServer) handlePricesCreate(ctx context.Context, req *mcp.CallToolRequest, params *PricesCreateParams) (*mcp.CallToolResult, any, error) {
+	price, err := s.priceService.CreatePrice(ctx, &dto.CreatePriceRequest{
+		Commodity: params.Commodity,
+		Currency:  params.Currency,
+		Date:      params.Date,
+		Value:     params.Value,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create price: %w", err)
+	}
+
+	jsonData, _ := json.MarshalIndent(map[string]any{
+		"price": formatPrice(price),
+	}, "", "  ")
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(jsonData)},
+		},
+	}, nil, nil
+}
@@ -0,0 +1,95 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ScheduledUpcomingParams defines parameters for scheduled_upcoming tool
+type ScheduledUpcomingParams struct {
+	GUID string `json:"guid,omitempty" jsonschema:"Scheduled transaction GUID (defaults to all enabled scheduled transactions)"`
+	Days int    `json:"days,omitempty" jsonschema:"Number of days to look ahead (defaults to 30)"`
+}
+
+// ScheduledPostDueParams defines parameters for scheduled_post_due tool
+type ScheduledPostDueParams struct {
+	GUID string `json:"guid,omitempty" jsonschema:"Scheduled transaction GUID (defaults to all enabled scheduled transactions)"`
+	AsOf string `json:"as_of,omitempty" jsonschema:"Post occurrences due on or before this date in YYYY-MM-DD format (defaults to today)"`
+}
+
+// handleScheduledList handles the scheduled_list tool
+func (s *MCPServer) handleScheduledList(ctx context.Context, req *mcp.CallToolRequest, params *struct{}) (*mcp.CallToolResult, any, error) {
+	sxs, err := s.sxService.ListScheduledTransactions(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list scheduled transactions: %w", err)
+	}
+
+	if len(sxs) == 0 {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: "No scheduled transactions found."},
+			},
+		}, nil, nil
+	}
+
+	jsonData, _ := json.MarshalIndent(map[string]any{
+		"scheduled_transactions": sxs,
+		"count":                  len(sxs),
+	}, "", "  ")
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(jsonData)},
+		},
+	}, nil, nil
+}
+
+// handleScheduledUpcoming handles the scheduled_upcoming tool
+func (s *MCPServer) handleScheduledUpcoming(ctx context.Context, req *mcp.CallToolRequest, params *ScheduledUpcomingParams) (*mcp.CallToolResult, any, error) {
+	days := params.Days
+	if days <= 0 {
+		days = 30
+	}
+
+	upcoming, err := s.sxService.GetUpcoming(ctx, params.GUID, time.Now().AddDate(0, 0, days))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get upcoming occurrences: %w", err)
+	}
+
+	jsonData, _ := json.MarshalIndent(upcoming, "", "  ")
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(jsonData)},
+		},
+	}, nil, nil
+}
+
+// handleScheduledPostDue handles the scheduled_post_due tool
+func (s *MCPServer) handleScheduledPostDue(ctx context.Context, req *mcp.CallToolRequest, params *ScheduledPostDueParams) (*mcp.CallToolResult, any, error) {
+	asOf := time.Now()
+	if params.AsOf != "" {
+		t, err := time.Parse("2006-01-02", params.AsOf)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid as_of format, use YYYY-MM-DD")
+		}
+		asOf = t
+	}
+
+	result, err := s.sxService.PostDue(ctx, params.GUID, asOf, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to post scheduled transactions: %w", err)
+	}
+
+	jsonData, _ := json.MarshalIndent(result, "", "  ")
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(jsonData)},
+		},
+	}, nil, nil
+}
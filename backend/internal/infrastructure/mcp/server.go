@@ -14,13 +14,19 @@ import (
 
 // MCPServer represents the MCP server for GnuCash
 type MCPServer struct {
-	accountRepo      repository.AccountRepository
-	transactionRepo  repository.TransactionRepository
-	commodityRepo    repository.CommodityRepository
-	analyticsService *service.AnalyticsService
-	server           *mcp.Server
-	httpServer       *http.Server
-	port             int
+	accountRepo        repository.AccountRepository
+	transactionRepo    repository.TransactionRepository
+	commodityRepo      repository.CommodityRepository
+	analyticsService   *service.AnalyticsService
+	transactionService *service.TransactionService
+	accountService     *service.AccountService
+	priceService       *service.PriceService
+	budgetService      *service.BudgetService
+	sxService          *service.ScheduledTransactionService
+	enableWrites       bool
+	server             *mcp.Server
+	httpServer         *http.Server
+	port               int
 }
 
 // NewMCPServer creates a new MCP server instance
@@ -29,6 +35,11 @@ func NewMCPServer(
 	transactionRepo repository.TransactionRepository,
 	commodityRepo repository.CommodityRepository,
 	analyticsService *service.AnalyticsService,
+	transactionService *service.TransactionService,
+	accountService *service.AccountService,
+	priceService *service.PriceService,
+	budgetService *service.BudgetService,
+	sxService *service.ScheduledTransactionService,
 ) *MCPServer {
 	port := 8081
 	if envPort := os.Getenv("MCP_PORT"); envPort != "" {
@@ -45,6 +56,9 @@ func NewMCPServer(
 		serverVersion = "1.0.0"
 	}
 
+	// Tools that change the book are opt-in because the server has no authentication
+	enableWrites := os.Getenv("MCP_ENABLE_WRITES") == "true"
+
 	// Create the MCP server
 	mcpServer := mcp.NewServer(&mcp.Implementation{
 		Name:    serverName,
@@ -52,12 +66,18 @@ func NewMCPServer(
 	}, nil)
 
 	s := &MCPServer{
-		accountRepo:      accountRepo,
-		transactionRepo:  transactionRepo,
-		commodityRepo:    commodityRepo,
-		analyticsService: analyticsService,
-		server:           mcpServer,
-		port:             port,
+		accountRepo:        accountRepo,
+		transactionRepo:    transactionRepo,
+		commodityRepo:      commodityRepo,
+		analyticsService:   analyticsService,
+		transactionService: transactionService,
+		accountService:     accountService,
+		priceService:       priceService,
+		budgetService:      budgetService,
+		sxService:          sxService,
+		enableWrites:       enableWrites,
+		server:             mcpServer,
+		port:               port,
 	}
 
 	// Register all tools
@@ -66,7 +86,8 @@ func NewMCPServer(
 	return s
 }
 
-// registerTools registers all available MCP tools
+// registerTools registers the read-only MCP tools, and the write tools when
+// they are enabled
 func (s *MCPServer) registerTools() {
 	// Account tools
 	mcp.AddTool(s.server, &mcp.Tool{
@@ -113,9 +134,24 @@ func (s *MCPServer) registerTools() {
 
 	mcp.AddTool(s.server, &mcp.Tool{
 		Name:        "analytics_cashflow",
-		Description: "Get cash flow analysis showing income and expenses over time",
+		Description: "Get a statement of cash flows: the change in bank and cash balances over a date range, split into operating, investing and financing activities by counter-account, reconciled from opening to closing cash. Sections are assigned by account type and can be overridden per type or per account",
 	}, s.handleAnalyticsCashflow)
 
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "analytics_income_statement",
+		Description: "Get a profit and loss report: income and expense account trees with signed amounts per month, quarter or year, subtotals per parent account and net income",
+	}, s.handleAnalyticsIncomeStatement)
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "analytics_capital_gains",
+		Description: "Get realized capital gains for a tax year, split into short-term and long-term, using FIFO, LIFO or average cost",
+	}, s.handleAnalyticsCapitalGains)
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "analytics_forecast",
+		Description: "Project bank, cash and credit card balances day by day for the next N days from scheduled transactions, future-dated transactions and optionally recent spending, and report when a bank account is expected to drop below a threshold or a credit card to owe more than a credit limit",
+	}, s.handleAnalyticsForecast)
+
 	// Commodity tools
 	mcp.AddTool(s.server, &mcp.Tool{
 		Name:        "commodities_list",
@@ -127,7 +163,101 @@ func (s *MCPServer) registerTools() {
 		Description: "Get detailed information about a specific commodity by GUID",
 	}, s.handleCommoditiesGet)
 
-	log.Printf("Registered %d MCP tools", 11)
+	// Price tools
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "prices_latest",
+		Description: "Get the most recent price of a commodity (e.g. a stock symbol), optionally in a given currency",
+	}, s.handlePricesLatest)
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "prices_as_of",
+		Description: "Get the price of a commodity as of a date, i.e. the latest price on or before it",
+	}, s.handlePricesAsOf)
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "prices_history",
+		Description: "List the price history of a commodity within an optional date range",
+	}, s.handlePricesHistory)
+
+	// Budget tools
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "budgets_list",
+		Description: "List GnuCash budgets with their periods and date range",
+	}, s.handleBudgetsList)
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "budgets_report",
+		Description: "Compare budgeted and actual amounts per account for a budget, optionally for one period; expense accounts that exceed their budget are flagged over_budget",
+	}, s.handleBudgetsReport)
+
+	// Scheduled transaction tools
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "scheduled_list",
+		Description: "List scheduled transactions with their recurrences, template splits and next occurrence",
+	}, s.handleScheduledList)
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "scheduled_upcoming",
+		Description: "List upcoming occurrences of scheduled transactions over the next N days; due occurrences are flagged",
+	}, s.handleScheduledUpcoming)
+
+	log.Printf("Registered %d read-only MCP tools", 21)
+
+	if s.enableWrites {
+		s.registerWriteTools()
+	}
+}
+
+// registerWriteTools registers the tools that change the book. The MCP server
+// has no authentication, so they are only registered when MCP_ENABLE_WRITES is
+// set and the server is reachable by trusted clients only.
+func (s *MCPServer) registerWriteTools() {
+	// Account tools
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "accounts_merge",
+		Description: "Merge a source account into a target account, moving all splits and child accounts. Use dry_run to preview the counts first",
+	}, s.handleAccountsMerge)
+
+	// Transaction tools
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "transactions_void",
+		Description: "Void a transaction by GUID the way GnuCash does, zeroing its splits and recording a reason. Pass the version from transactions_get; the void fails if the transaction changed since",
+	}, s.handleTransactionsVoid)
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "transactions_reverse",
+		Description: "Reverse a transaction by GUID, creating a mirror transaction on the given date",
+	}, s.handleTransactionsReverse)
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "transactions_bulk_recategorize",
+		Description: "Move the splits of one account that match a description, date range and amount range to another account, e.g. all Uber rides from Misc to Transport. Use dry_run to list the affected transactions first",
+	}, s.handleTransactionsBulkRecategorize)
+
+	// Price tools
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "prices_create",
+		Description: "Record a price for a commodity in the GnuCash price database",
+	}, s.handlePricesCreate)
+
+	// Budget tools
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "budgets_create",
+		Description: "Create a budget with a period layout, optionally filled with last year's actuals",
+	}, s.handleBudgetsCreate)
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "budgets_set_amounts",
+		Description: "Set a budget amount for an account in one period or across a range of periods",
+	}, s.handleBudgetsSetAmounts)
+
+	// Scheduled transaction tools
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name:        "scheduled_post_due",
+		Description: "Create the transactions for every due occurrence of scheduled transactions, advancing their last occurrence",
+	}, s.handleScheduledPostDue)
+
+	log.Printf("Registered %d MCP write tools; MCP_ENABLE_WRITES is set, so any client that reaches the server can change the book", 8)
 }
 
 // Start runs the MCP server with HTTP transport
@@ -145,7 +275,7 @@ func (s *MCPServer) Start(ctx context.Context) error {
 	}
 
 	log.Printf("GnuCash MCP Server starting on http://0.0.0.0%s", addr)
-	log.Printf("Available tools: accounts_*, transactions_*, analytics_*, commodities_*")
+	log.Printf("Available tools: accounts_*, transactions_*, analytics_*, commodities_*, prices_*, budgets_*, scheduled_*")
 
 	// Start the HTTP server in a goroutine
 	errChan := make(chan error, 1)
@@ -2,6 +2,7 @@ package mcp
 
 import (
 	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
 )
 
 // formatAccount converts an account to a map for JSON serialization
@@ -84,12 +85,14 @@ func formatTransaction(tx *entity.Transaction) map[string]any {
 			"quantity_denom": s.QuantityDenom,
 			"memo":           s.Memo,
 			"action":         s.Action,
+			"lot_guid":       s.LotGUID,
 			"reconcile_state": s.ReconcileState,
 		})
 	}
 
 	return map[string]any{
 		"guid":         tx.GUID,
+		"version":      tx.Version(),
 		"currency":     tx.CurrencyMnemonic,
 		"currency_guid": tx.CurrencyGUID,
 		"number":       tx.Num,
@@ -97,5 +100,24 @@ func formatTransaction(tx *entity.Transaction) map[string]any {
 		"enter_date":   tx.EnterDate.Format("2006-01-02"),
 		"description":  tx.Description,
 		"splits":       splits,
+		"voided":       tx.Voided,
+		"void_reason":  tx.VoidReason,
+		"reversed_by":  tx.ReversedByGUID,
+		"reversal_of":  tx.ReversalOfGUID,
+	}
+}
+
+// formatPrice converts a price to a map
+func formatPrice(p *entity.Price) map[string]any {
+	return map[string]any{
+		"guid":        p.GUID,
+		"commodity":   p.CommodityMnemonic,
+		"currency":    p.CurrencyMnemonic,
+		"date":        p.Date.Format("2006-01-02"),
+		"value":       gnucash.RationalToDecimal(p.ValueNum, p.ValueDenom).String(),
+		"value_num":   p.ValueNum,
+		"value_denom": p.ValueDenom,
+		"source":      p.Source,
+		"type":        p.Type,
 	}
 }
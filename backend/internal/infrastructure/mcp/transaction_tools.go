@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
 	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
 )
 
@@ -23,6 +24,32 @@ type TransactionsGetParams struct {
 	GUID string `json:"guid" jsonschema:"required,Transaction GUID to retrieve"`
 }
 
+// TransactionsVoidParams defines parameters for transactions_void tool
+type TransactionsVoidParams struct {
+	GUID    string `json:"guid" jsonschema:"required,Transaction GUID to void"`
+	Reason  string `json:"reason" jsonschema:"required,Reason for voiding the transaction"`
+	Version string `json:"version" jsonschema:"required,Version of the transaction as returned by transactions_get"`
+}
+
+// TransactionsReverseParams defines parameters for transactions_reverse tool
+type TransactionsReverseParams struct {
+	GUID     string `json:"guid" jsonschema:"required,Transaction GUID to reverse"`
+	PostDate string `json:"post_date,omitempty" jsonschema:"Date of the reversing transaction in YYYY-MM-DD format (defaults to today)"`
+}
+
+// TransactionsBulkRecategorizeParams defines parameters for transactions_bulk_recategorize tool
+type TransactionsBulkRecategorizeParams struct {
+	AccountGUID       string `json:"account_guid" jsonschema:"required,GUID of the account the splits are moved from"`
+	TargetAccountGUID string `json:"target_account_guid" jsonschema:"required,GUID of the account the splits are moved to"`
+	Description       string `json:"description,omitempty" jsonschema:"Only transactions whose description contains this text (case-insensitive)"`
+	StartDate         string `json:"start_date,omitempty" jsonschema:"Start date in YYYY-MM-DD format"`
+	EndDate           string `json:"end_date,omitempty" jsonschema:"End date in YYYY-MM-DD format"`
+	MinAmount         string `json:"min_amount,omitempty" jsonschema:"Minimum absolute split amount"`
+	MaxAmount         string `json:"max_amount,omitempty" jsonschema:"Maximum absolute split amount"`
+	IncludeReconciled bool   `json:"include_reconciled,omitempty" jsonschema:"Also move reconciled splits, setting them back to not reconciled"`
+	DryRun            bool   `json:"dry_run,omitempty" jsonschema:"List the affected transactions without moving anything"`
+}
+
 // handleTransactionsList handles the transactions_list tool
 func (s *MCPServer) handleTransactionsList(ctx context.Context, req *mcp.CallToolRequest, params *TransactionsListParams) (*mcp.CallToolResult, any, error) {
 	// Parse dates
@@ -114,3 +141,77 @@ func (s *MCPServer) handleTransactionsGet(ctx context.Context, req *mcp.CallTool
 		},
 	}, nil, nil
 }
+
+// handleTransactionsVoid handles the transactions_void tool
+func (s *MCPServer) handleTransactionsVoid(ctx context.Context, req *mcp.CallToolRequest, params *TransactionsVoidParams) (*mcp.CallToolResult, any, error) {
+	if params.GUID == "" {
+		return nil, nil, fmt.Errorf("missing required parameter: guid")
+	}
+
+	transaction, err := s.transactionService.VoidTransaction(ctx, params.GUID, params.Reason, params.Version)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to void transaction: %w", err)
+	}
+
+	jsonData, _ := json.MarshalIndent(map[string]any{
+		"transaction": formatTransaction(transaction),
+	}, "", "  ")
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(jsonData)},
+		},
+	}, nil, nil
+}
+
+// handleTransactionsReverse handles the transactions_reverse tool
+func (s *MCPServer) handleTransactionsReverse(ctx context.Context, req *mcp.CallToolRequest, params *TransactionsReverseParams) (*mcp.CallToolResult, any, error) {
+	if params.GUID == "" {
+		return nil, nil, fmt.Errorf("missing required parameter: guid")
+	}
+
+	transaction, err := s.transactionService.ReverseTransaction(ctx, params.GUID, params.PostDate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to reverse transaction: %w", err)
+	}
+
+	jsonData, _ := json.MarshalIndent(map[string]any{
+		"reversal": formatTransaction(transaction),
+	}, "", "  ")
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(jsonData)},
+		},
+	}, nil, nil
+}
+
+// handleTransactionsBulkRecategorize handles the transactions_bulk_recategorize tool
+func (s *MCPServer) handleTransactionsBulkRecategorize(ctx context.Context, req *mcp.CallToolRequest, params *TransactionsBulkRecategorizeParams) (*mcp.CallToolResult, any, error) {
+	if params.AccountGUID == "" || params.TargetAccountGUID == "" {
+		return nil, nil, fmt.Errorf("missing required parameters: account_guid and target_account_guid")
+	}
+
+	result, err := s.transactionService.BulkRecategorize(ctx, &dto.BulkRecategorizeRequest{
+		AccountGUID:       params.AccountGUID,
+		TargetAccountGUID: params.TargetAccountGUID,
+		Description:       params.Description,
+		StartDate:         params.StartDate,
+		EndDate:           params.EndDate,
+		MinAmount:         params.MinAmount,
+		MaxAmount:         params.MaxAmount,
+		IncludeReconciled: params.IncludeReconciled,
+		DryRun:            params.DryRun,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to re-categorize transactions: %w", err)
+	}
+
+	jsonData, _ := json.MarshalIndent(result, "", "  ")
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(jsonData)},
+		},
+	}, nil, nil
+}
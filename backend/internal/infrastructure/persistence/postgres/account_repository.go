@@ -2,7 +2,9 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -21,7 +23,7 @@ func NewAccountRepository(db *pgxpool.Pool) repository.AccountRepository {
 	return &AccountRepository{db: db}
 }
 
-const accountSelectColumns = `a.guid, a.name, a.account_type, a.commodity_guid, a.commodity_scu,
+const accountSelectColumns = `a.guid, a.name, a.account_type, a.commodity_guid, a.commodity_scu, a.non_std_scu,
 		       a.parent_guid, a.code, a.description, a.hidden, a.placeholder,
 		       COALESCE(c.mnemonic, '')`
 
@@ -29,13 +31,14 @@ const accountSelectColumns = `a.guid, a.name, a.account_type, a.commodity_guid,
 // for hidden and placeholder columns (GnuCash stores these as integer 0/1).
 func scanAccount(row pgx.Row) (*entity.Account, error) {
 	account := &entity.Account{}
-	var hidden, placeholder int
+	var nonStdSCU, hidden, placeholder int
 	err := row.Scan(
 		&account.GUID,
 		&account.Name,
 		&account.AccountType,
 		&account.CommodityGUID,
 		&account.CommoditySCU,
+		&nonStdSCU,
 		&account.ParentGUID,
 		&account.Code,
 		&account.Description,
@@ -46,6 +49,7 @@ func scanAccount(row pgx.Row) (*entity.Account, error) {
 	if err != nil {
 		return nil, err
 	}
+	account.NonStdSCU = nonStdSCU != 0
 	account.Hidden = hidden != 0
 	account.Placeholder = placeholder != 0
 	return account, nil
@@ -83,6 +87,9 @@ func (r *AccountRepository) FindByGUID(ctx context.Context, guid string) (*entit
 
 	account, err := scanAccount(r.db.QueryRow(ctx, query, guid))
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
 		return nil, fmt.Errorf("failed to find account: %w", err)
 	}
 
@@ -143,22 +150,329 @@ func (r *AccountRepository) FindByType(ctx context.Context, accountType entity.A
 
 // GetBalance calculates the current balance for an account
 func (r *AccountRepository) GetBalance(ctx context.Context, guid string) (int64, int64, error) {
-	// Use a fixed high-precision denominator to normalize all splits
-	const targetDenom = 100000
 	query := `
-		SELECT ROUND(COALESCE(SUM(s.quantity_num::numeric * $2 / s.quantity_denom::numeric), 0)) as total_num,
-		       $2 as denom
+		SELECT s.quantity_denom, SUM(s.quantity_num)::bigint
 		FROM splits s
 		WHERE s.account_guid = $1
+		GROUP BY s.quantity_denom
 	`
 
-	var numerator, denominator int64
-	err := r.db.QueryRow(ctx, query, guid, targetDenom).Scan(&numerator, &denominator)
+	rows, err := r.db.Query(ctx, query, guid)
 	if err != nil {
 		return 0, 0, fmt.Errorf("failed to calculate balance: %w", err)
 	}
+	return scanRationalSum(rows)
+}
 
-	return numerator, denominator, nil
+// GetBalanceAsOf calculates the balance for an account from transactions posted on or before asOf
+func (r *AccountRepository) GetBalanceAsOf(ctx context.Context, guid string, asOf time.Time) (int64, int64, error) {
+	query := `
+		SELECT s.quantity_denom, SUM(s.quantity_num)::bigint
+		FROM splits s
+		INNER JOIN transactions t ON s.tx_guid = t.guid
+		WHERE s.account_guid = $1 AND t.post_date <= $2
+		GROUP BY s.quantity_denom
+	`
+
+	rows, err := r.db.Query(ctx, query, guid, asOf)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to calculate balance: %w", err)
+	}
+	return scanRationalSum(rows)
+}
+
+// GetBalanceBefore calculates the balance of the account's splits that come
+// before a position in register order
+func (r *AccountRepository) GetBalanceBefore(ctx context.Context, guid string, position entity.RegisterPosition) (int64, int64, error) {
+	query := `
+		SELECT s.quantity_denom, SUM(s.quantity_num)::bigint
+		FROM splits s
+		INNER JOIN transactions t ON s.tx_guid = t.guid
+		WHERE s.account_guid = $1 AND (t.post_date, t.enter_date, s.guid) < ($2, $3, $4)
+		GROUP BY s.quantity_denom
+	`
+
+	rows, err := r.db.Query(ctx, query, guid, position.PostDate, position.EnterDate, position.SplitGUID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to calculate balance: %w", err)
+	}
+	return scanRationalSum(rows)
+}
+
+// FindRegister lists the account's splits ordered by post date, entry date
+// and split GUID, with the number of other splits in each transaction and the
+// other split's account when there is exactly one
+func (r *AccountRepository) FindRegister(ctx context.Context, guid string, after *entity.RegisterPosition, limit int) ([]*entity.RegisterSplit, error) {
+	query := `
+		SELECT s.guid, s.tx_guid, s.account_guid, s.lot_guid, s.memo, s.action,
+		       s.reconcile_state, s.reconcile_date, s.value_num, s.value_denom,
+		       s.quantity_num, s.quantity_denom,
+		       t.post_date, t.enter_date, t.num, t.description,
+		       o.other_count, CASE WHEN o.other_count = 1 THEN o.other_account END
+		FROM splits s
+		INNER JOIN transactions t ON s.tx_guid = t.guid
+		CROSS JOIN LATERAL (
+			SELECT COUNT(*)::int AS other_count, MIN(os.account_guid) AS other_account
+			FROM splits os
+			WHERE os.tx_guid = s.tx_guid AND os.guid <> s.guid
+		) o
+		WHERE s.account_guid = $1
+	`
+	args := []interface{}{guid}
+	if after != nil {
+		query += ` AND (t.post_date, t.enter_date, s.guid) > ($2, $3, $4)`
+		args = append(args, after.PostDate, after.EnterDate, after.SplitGUID)
+	}
+	query += fmt.Sprintf(` ORDER BY t.post_date, t.enter_date, s.guid LIMIT $%d`, len(args)+1)
+	args = append(args, limit)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query register: %w", err)
+	}
+	defer rows.Close()
+
+	var splits []*entity.RegisterSplit
+	for rows.Next() {
+		row := &entity.RegisterSplit{AccountSplit: entity.AccountSplit{Split: &entity.Split{}}}
+		err := rows.Scan(
+			&row.Split.GUID,
+			&row.Split.TxGUID,
+			&row.Split.AccountGUID,
+			&row.Split.LotGUID,
+			&row.Split.Memo,
+			&row.Split.Action,
+			&row.Split.ReconcileState,
+			&row.Split.ReconcileDate,
+			&row.Split.ValueNum,
+			&row.Split.ValueDenom,
+			&row.Split.QuantityNum,
+			&row.Split.QuantityDenom,
+			&row.PostDate,
+			&row.EnterDate,
+			&row.Num,
+			&row.Description,
+			&row.OtherSplitCount,
+			&row.OtherAccountGUID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan split: %w", err)
+		}
+		splits = append(splits, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating splits: %w", err)
+	}
+
+	return splits, nil
+}
+
+// FindRootGUID returns the GUID of the book's root account (not the template root)
+func (r *AccountRepository) FindRootGUID(ctx context.Context) (string, error) {
+	var guid string
+	err := r.db.QueryRow(ctx, `SELECT root_account_guid FROM books LIMIT 1`).Scan(&guid)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", repository.ErrNotFound
+		}
+		return "", fmt.Errorf("failed to find root account: %w", err)
+	}
+	return guid, nil
+}
+
+// CountSplits returns the number of splits posted to an account
+func (r *AccountRepository) CountSplits(ctx context.Context, guid string) (int64, error) {
+	var count int64
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM splits WHERE account_guid = $1`, guid).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count splits: %w", err)
+	}
+	return count, nil
+}
+
+// CountTransactions returns the number of distinct transactions with a split in an account
+func (r *AccountRepository) CountTransactions(ctx context.Context, guid string) (int64, error) {
+	var count int64
+	err := r.db.QueryRow(ctx, `SELECT COUNT(DISTINCT tx_guid) FROM splits WHERE account_guid = $1`, guid).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count transactions: %w", err)
+	}
+	return count, nil
+}
+
+// Create inserts a new account
+func (r *AccountRepository) Create(ctx context.Context, account *entity.Account) error {
+	query := `
+		INSERT INTO accounts (guid, name, account_type, commodity_guid, commodity_scu, non_std_scu,
+		                      parent_guid, code, description, hidden, placeholder)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		account.GUID,
+		account.Name,
+		account.AccountType,
+		account.CommodityGUID,
+		account.CommoditySCU,
+		boolToInt(account.NonStdSCU),
+		account.ParentGUID,
+		account.Code,
+		account.Description,
+		boolToInt(account.Hidden),
+		boolToInt(account.Placeholder),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create account: %w", err)
+	}
+
+	return nil
+}
+
+// Update writes the editable fields of an account, including its parent
+func (r *AccountRepository) Update(ctx context.Context, account *entity.Account) error {
+	query := `
+		UPDATE accounts
+		SET name = $2, account_type = $3, parent_guid = $4, code = $5, description = $6,
+		    hidden = $7, placeholder = $8
+		WHERE guid = $1
+	`
+
+	tag, err := r.db.Exec(ctx, query,
+		account.GUID,
+		account.Name,
+		account.AccountType,
+		account.ParentGUID,
+		account.Code,
+		account.Description,
+		boolToInt(account.Hidden),
+		boolToInt(account.Placeholder),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update account: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes an account and its slots. If reassignTo is set, the account's
+// splits are first moved to that account in the same database transaction.
+func (r *AccountRepository) Delete(ctx context.Context, guid string, reassignTo *string) error {
+	dbTx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	if reassignTo != nil {
+		if _, err := dbTx.Exec(ctx, `UPDATE splits SET account_guid = $2 WHERE account_guid = $1`, guid, *reassignTo); err != nil {
+			return fmt.Errorf("failed to reassign splits: %w", err)
+		}
+		if _, err := dbTx.Exec(ctx, `UPDATE lots SET account_guid = $2 WHERE account_guid = $1`, guid, *reassignTo); err != nil {
+			return fmt.Errorf("failed to reassign lots: %w", err)
+		}
+	} else if err := deleteAccountLots(ctx, dbTx, guid); err != nil {
+		return err
+	}
+
+	if err := deleteSlots(ctx, dbTx, []string{guid}); err != nil {
+		return err
+	}
+
+	tag, err := dbTx.Exec(ctx, `DELETE FROM accounts WHERE guid = $1`, guid)
+	if err != nil {
+		return fmt.Errorf("failed to delete account: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Merge moves every split and child account of source to target in a single
+// database transaction, then deletes the source account or, if hideSource is
+// set, keeps it as a hidden empty account
+func (r *AccountRepository) Merge(ctx context.Context, sourceGUID, targetGUID string, hideSource bool) error {
+	dbTx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	if _, err := dbTx.Exec(ctx, `UPDATE splits SET account_guid = $2 WHERE account_guid = $1`, sourceGUID, targetGUID); err != nil {
+		return fmt.Errorf("failed to move splits: %w", err)
+	}
+	if _, err := dbTx.Exec(ctx, `UPDATE lots SET account_guid = $2 WHERE account_guid = $1`, sourceGUID, targetGUID); err != nil {
+		return fmt.Errorf("failed to move lots: %w", err)
+	}
+	if _, err := dbTx.Exec(ctx, `UPDATE accounts SET parent_guid = $2 WHERE parent_guid = $1`, sourceGUID, targetGUID); err != nil {
+		return fmt.Errorf("failed to move child accounts: %w", err)
+	}
+
+	if hideSource {
+		_, err = dbTx.Exec(ctx, `UPDATE accounts SET hidden = 1 WHERE guid = $1`, sourceGUID)
+		if err != nil {
+			return fmt.Errorf("failed to hide account: %w", err)
+		}
+	} else {
+		if err := deleteSlots(ctx, dbTx, []string{sourceGUID}); err != nil {
+			return err
+		}
+		if _, err := dbTx.Exec(ctx, `DELETE FROM accounts WHERE guid = $1`, sourceGUID); err != nil {
+			return fmt.Errorf("failed to delete account: %w", err)
+		}
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// deleteAccountLots removes the (necessarily empty) lots of an account and their slots
+func deleteAccountLots(ctx context.Context, q querier, accountGUID string) error {
+	rows, err := q.Query(ctx, `SELECT guid FROM lots WHERE account_guid = $1`, accountGUID)
+	if err != nil {
+		return fmt.Errorf("failed to query lots: %w", err)
+	}
+	var lotGUIDs []string
+	for rows.Next() {
+		var guid string
+		if err := rows.Scan(&guid); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan lot: %w", err)
+		}
+		lotGUIDs = append(lotGUIDs, guid)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating lots: %w", err)
+	}
+
+	if err := deleteSlots(ctx, q, lotGUIDs); err != nil {
+		return err
+	}
+	if _, err := q.Exec(ctx, `DELETE FROM lots WHERE account_guid = $1`, accountGUID); err != nil {
+		return fmt.Errorf("failed to delete lots: %w", err)
+	}
+	return nil
+}
+
+// boolToInt converts a bool to the 0/1 integer GnuCash stores for flags
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
 }
 
 // GetBalanceWithChildren calculates the balance including child accounts
@@ -170,7 +484,6 @@ func (r *AccountRepository) GetBalanceWithChildren(ctx context.Context, guid str
 	}
 
 	// Recursive CTE to get all child accounts
-	const targetDenom = 100000
 	query := `
 		WITH RECURSIVE account_tree AS (
 			SELECT guid FROM accounts WHERE guid = $1
@@ -178,17 +491,20 @@ func (r *AccountRepository) GetBalanceWithChildren(ctx context.Context, guid str
 			SELECT a.guid FROM accounts a
 			INNER JOIN account_tree at ON a.parent_guid = at.guid
 		)
-		SELECT ROUND(COALESCE(SUM(s.quantity_num::numeric * $2 / s.quantity_denom::numeric), 0)) as total_num,
-		       $2 as denom
+		SELECT s.quantity_denom, SUM(s.quantity_num)::bigint
 		FROM splits s
 		WHERE s.account_guid IN (SELECT guid FROM account_tree)
+		GROUP BY s.quantity_denom
 	`
 
-	var numerator, denominator int64
-	err = r.db.QueryRow(ctx, query, guid, targetDenom).Scan(&numerator, &denominator)
+	rows, err := r.db.Query(ctx, query, guid)
 	if err != nil {
 		return 0, 0, fmt.Errorf("failed to calculate balance with children: %w", err)
 	}
+	numerator, denominator, err := scanRationalSum(rows)
+	if err != nil {
+		return 0, 0, err
+	}
 
 	// Normalize the sign based on account type
 	numerator = gnucash.NormalizeSign(numerator, account.IsDebitAccount())
@@ -0,0 +1,283 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+)
+
+// BudgetRepository implements repository.BudgetRepository for PostgreSQL
+type BudgetRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewBudgetRepository creates a new PostgreSQL budget repository
+func NewBudgetRepository(db *pgxpool.Pool) repository.BudgetRepository {
+	return &BudgetRepository{db: db}
+}
+
+const budgetSelectQuery = `
+	SELECT b.guid, b.name, b.description, b.num_periods,
+	       r.id, r.recurrence_mult, r.recurrence_period_type, r.recurrence_period_start, r.recurrence_weekend_adjust
+	FROM budgets b
+	LEFT JOIN LATERAL (
+		SELECT * FROM recurrences WHERE obj_guid = b.guid ORDER BY id LIMIT 1
+	) r ON true
+`
+
+// scanBudget scans a row into a Budget entity
+func scanBudget(row pgx.Row) (*entity.Budget, error) {
+	b := &entity.Budget{}
+	var (
+		recurrenceID  *int
+		mult          *int
+		periodType    *string
+		periodStart   *time.Time
+		weekendAdjust *string
+	)
+	err := row.Scan(
+		&b.GUID,
+		&b.Name,
+		&b.Description,
+		&b.NumPeriods,
+		&recurrenceID,
+		&mult,
+		&periodType,
+		&periodStart,
+		&weekendAdjust,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if recurrenceID != nil {
+		b.Recurrence = &entity.Recurrence{
+			ID:            *recurrenceID,
+			ObjGUID:       b.GUID,
+			Mult:          *mult,
+			PeriodType:    *periodType,
+			PeriodStart:   *periodStart,
+			WeekendAdjust: *weekendAdjust,
+		}
+	}
+
+	return b, nil
+}
+
+// FindAll retrieves all budgets with their recurrence
+func (r *BudgetRepository) FindAll(ctx context.Context) ([]*entity.Budget, error) {
+	rows, err := r.db.Query(ctx, budgetSelectQuery+` ORDER BY b.name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query budgets: %w", err)
+	}
+	defer rows.Close()
+
+	var budgets []*entity.Budget
+	for rows.Next() {
+		b, err := scanBudget(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan budget: %w", err)
+		}
+		budgets = append(budgets, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating budgets: %w", err)
+	}
+
+	return budgets, nil
+}
+
+// FindByGUID retrieves a budget with its recurrence
+func (r *BudgetRepository) FindByGUID(ctx context.Context, guid string) (*entity.Budget, error) {
+	b, err := scanBudget(r.db.QueryRow(ctx, budgetSelectQuery+` WHERE b.guid = $1`, guid))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to find budget: %w", err)
+	}
+
+	return b, nil
+}
+
+// FindAmounts retrieves every amount set in a budget
+func (r *BudgetRepository) FindAmounts(ctx context.Context, budgetGUID string) ([]*entity.BudgetAmount, error) {
+	query := `
+		SELECT id, budget_guid, account_guid, period_num, amount_num, amount_denom
+		FROM budget_amounts
+		WHERE budget_guid = $1
+		ORDER BY account_guid, period_num
+	`
+
+	rows, err := r.db.Query(ctx, query, budgetGUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query budget amounts: %w", err)
+	}
+	defer rows.Close()
+
+	var amounts []*entity.BudgetAmount
+	for rows.Next() {
+		a := &entity.BudgetAmount{}
+		err := rows.Scan(&a.ID, &a.BudgetGUID, &a.AccountGUID, &a.PeriodNum, &a.AmountNum, &a.AmountDenom)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan budget amount: %w", err)
+		}
+		amounts = append(amounts, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating budget amounts: %w", err)
+	}
+
+	return amounts, nil
+}
+
+// Create inserts a budget, its recurrence and any initial amounts atomically
+func (r *BudgetRepository) Create(ctx context.Context, budget *entity.Budget, amounts []*entity.BudgetAmount) error {
+	dbTx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	query := `INSERT INTO budgets (guid, name, description, num_periods) VALUES ($1, $2, $3, $4)`
+	if _, err := dbTx.Exec(ctx, query, budget.GUID, budget.Name, budget.Description, budget.NumPeriods); err != nil {
+		return fmt.Errorf("failed to insert budget: %w", err)
+	}
+
+	budget.Recurrence.ObjGUID = budget.GUID
+	if err := insertRecurrence(ctx, dbTx, budget.Recurrence); err != nil {
+		return err
+	}
+
+	for _, a := range amounts {
+		a.BudgetGUID = budget.GUID
+		if err := insertBudgetAmount(ctx, dbTx, a); err != nil {
+			return err
+		}
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Update writes a budget's name, description and period count, dropping
+// amounts for periods beyond the new count
+func (r *BudgetRepository) Update(ctx context.Context, budget *entity.Budget) error {
+	dbTx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	query := `UPDATE budgets SET name = $2, description = $3, num_periods = $4 WHERE guid = $1`
+	tag, err := dbTx.Exec(ctx, query, budget.GUID, budget.Name, budget.Description, budget.NumPeriods)
+	if err != nil {
+		return fmt.Errorf("failed to update budget: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+
+	_, err = dbTx.Exec(ctx, `DELETE FROM budget_amounts WHERE budget_guid = $1 AND period_num >= $2`, budget.GUID, budget.NumPeriods)
+	if err != nil {
+		return fmt.Errorf("failed to trim budget amounts: %w", err)
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a budget with its amounts, recurrence and slots
+func (r *BudgetRepository) Delete(ctx context.Context, guid string) error {
+	dbTx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	if _, err := dbTx.Exec(ctx, `DELETE FROM budget_amounts WHERE budget_guid = $1`, guid); err != nil {
+		return fmt.Errorf("failed to delete budget amounts: %w", err)
+	}
+	if err := deleteRecurrences(ctx, dbTx, guid); err != nil {
+		return err
+	}
+	if err := deleteSlots(ctx, dbTx, []string{guid}); err != nil {
+		return err
+	}
+
+	tag, err := dbTx.Exec(ctx, `DELETE FROM budgets WHERE guid = $1`, guid)
+	if err != nil {
+		return fmt.Errorf("failed to delete budget: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateAmounts stores the given amounts and removes the cleared ones
+// (matched by account and period) in a single database transaction
+func (r *BudgetRepository) UpdateAmounts(ctx context.Context, budgetGUID string, set, cleared []*entity.BudgetAmount) error {
+	dbTx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	deleteQuery := `DELETE FROM budget_amounts WHERE budget_guid = $1 AND account_guid = $2 AND period_num = $3`
+	for _, a := range cleared {
+		if _, err := dbTx.Exec(ctx, deleteQuery, budgetGUID, a.AccountGUID, a.PeriodNum); err != nil {
+			return fmt.Errorf("failed to clear budget amount: %w", err)
+		}
+	}
+
+	for _, a := range set {
+		if _, err := dbTx.Exec(ctx, deleteQuery, budgetGUID, a.AccountGUID, a.PeriodNum); err != nil {
+			return fmt.Errorf("failed to replace budget amount: %w", err)
+		}
+		a.BudgetGUID = budgetGUID
+		if err := insertBudgetAmount(ctx, dbTx, a); err != nil {
+			return err
+		}
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// insertBudgetAmount stores one budget amount row
+func insertBudgetAmount(ctx context.Context, q querier, a *entity.BudgetAmount) error {
+	query := `
+		INSERT INTO budget_amounts (budget_guid, account_guid, period_num, amount_num, amount_denom)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+
+	err := q.QueryRow(ctx, query, a.BudgetGUID, a.AccountGUID, a.PeriodNum, a.AmountNum, a.AmountDenom).Scan(&a.ID)
+	if err != nil {
+		return fmt.Errorf("failed to insert budget amount: %w", err)
+	}
+	return nil
+}
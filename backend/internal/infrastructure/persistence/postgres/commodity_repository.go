@@ -2,8 +2,10 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
 	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
@@ -58,8 +60,41 @@ func (r *CommodityRepository) FindByGUID(ctx context.Context, guid string) (*ent
 	c := &entity.Commodity{}
 	err := r.db.QueryRow(ctx, query, guid).Scan(&c.GUID, &c.Namespace, &c.Mnemonic, &c.Fullname, &c.Fraction)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
 		return nil, fmt.Errorf("failed to find commodity: %w", err)
 	}
 
 	return c, nil
 }
+
+// FindByMnemonic retrieves commodities with the given mnemonic, excluding the template namespace
+func (r *CommodityRepository) FindByMnemonic(ctx context.Context, mnemonic string) ([]*entity.Commodity, error) {
+	query := `SELECT guid, namespace, mnemonic, fullname, fraction
+	          FROM commodities
+	          WHERE UPPER(mnemonic) = UPPER($1) AND namespace <> 'template'
+	          ORDER BY namespace`
+
+	rows, err := r.db.Query(ctx, query, mnemonic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query commodities: %w", err)
+	}
+	defer rows.Close()
+
+	var commodities []*entity.Commodity
+	for rows.Next() {
+		c := &entity.Commodity{}
+		err := rows.Scan(&c.GUID, &c.Namespace, &c.Mnemonic, &c.Fullname, &c.Fraction)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan commodity: %w", err)
+		}
+		commodities = append(commodities, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating commodities: %w", err)
+	}
+
+	return commodities, nil
+}
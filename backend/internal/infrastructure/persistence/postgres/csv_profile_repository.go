@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+)
+
+// pgUniqueViolation is the PostgreSQL error code for a unique constraint violation
+const pgUniqueViolation = "23505"
+
+// CSVProfileRepository implements repository.CSVProfileRepository for PostgreSQL
+type CSVProfileRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewCSVProfileRepository creates a new PostgreSQL CSV profile repository
+func NewCSVProfileRepository(db *pgxpool.Pool) repository.CSVProfileRepository {
+	return &CSVProfileRepository{db: db}
+}
+
+const csvProfileColumns = `
+	id, name, account_guid, counter_account_guid, delimiter, has_header, skip_rows,
+	date_column, date_format, amount_column, debit_column, credit_column,
+	sign_convention, decimal_separator, description_column, memo_column,
+	created_at, updated_at
+`
+
+// scanCSVProfile reads a profile row selected with csvProfileColumns
+func scanCSVProfile(row pgx.Row) (*entity.CSVProfile, error) {
+	p := &entity.CSVProfile{}
+	err := row.Scan(
+		&p.ID,
+		&p.Name,
+		&p.AccountGUID,
+		&p.CounterAccountGUID,
+		&p.Delimiter,
+		&p.HasHeader,
+		&p.SkipRows,
+		&p.DateColumn,
+		&p.DateFormat,
+		&p.AmountColumn,
+		&p.DebitColumn,
+		&p.CreditColumn,
+		&p.SignConvention,
+		&p.DecimalSeparator,
+		&p.DescriptionColumn,
+		&p.MemoColumn,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	)
+	return p, err
+}
+
+// FindAll retrieves every profile ordered by name
+func (r *CSVProfileRepository) FindAll(ctx context.Context) ([]*entity.CSVProfile, error) {
+	query := `SELECT ` + csvProfileColumns + ` FROM app_csv_profiles ORDER BY name`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query CSV profiles: %w", err)
+	}
+	defer rows.Close()
+
+	var profiles []*entity.CSVProfile
+	for rows.Next() {
+		profile, err := scanCSVProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan CSV profile: %w", err)
+		}
+		profiles = append(profiles, profile)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating CSV profiles: %w", err)
+	}
+
+	return profiles, nil
+}
+
+// FindByID retrieves a profile by its ID
+func (r *CSVProfileRepository) FindByID(ctx context.Context, id int64) (*entity.CSVProfile, error) {
+	query := `SELECT ` + csvProfileColumns + ` FROM app_csv_profiles WHERE id = $1`
+
+	profile, err := scanCSVProfile(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to find CSV profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+// Create stores a new profile
+func (r *CSVProfileRepository) Create(ctx context.Context, p *entity.CSVProfile) error {
+	query := `
+		INSERT INTO app_csv_profiles (
+			name, account_guid, counter_account_guid, delimiter, has_header, skip_rows,
+			date_column, date_format, amount_column, debit_column, credit_column,
+			sign_convention, decimal_separator, description_column, memo_column,
+			created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		p.Name, p.AccountGUID, p.CounterAccountGUID, p.Delimiter, p.HasHeader, p.SkipRows,
+		p.DateColumn, p.DateFormat, p.AmountColumn, p.DebitColumn, p.CreditColumn,
+		p.SignConvention, p.DecimalSeparator, p.DescriptionColumn, p.MemoColumn,
+	).Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return repository.ErrConflict
+		}
+		return fmt.Errorf("failed to create CSV profile: %w", err)
+	}
+
+	return nil
+}
+
+// Update replaces a profile
+func (r *CSVProfileRepository) Update(ctx context.Context, p *entity.CSVProfile) error {
+	query := `
+		UPDATE app_csv_profiles
+		SET name = $2, account_guid = $3, counter_account_guid = $4, delimiter = $5,
+		    has_header = $6, skip_rows = $7, date_column = $8, date_format = $9,
+		    amount_column = $10, debit_column = $11, credit_column = $12,
+		    sign_convention = $13, decimal_separator = $14, description_column = $15,
+		    memo_column = $16, updated_at = NOW()
+		WHERE id = $1
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		p.ID, p.Name, p.AccountGUID, p.CounterAccountGUID, p.Delimiter, p.HasHeader, p.SkipRows,
+		p.DateColumn, p.DateFormat, p.AmountColumn, p.DebitColumn, p.CreditColumn,
+		p.SignConvention, p.DecimalSeparator, p.DescriptionColumn, p.MemoColumn,
+	).Scan(&p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.ErrNotFound
+		}
+		if isUniqueViolation(err) {
+			return repository.ErrConflict
+		}
+		return fmt.Errorf("failed to update CSV profile: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a profile
+func (r *CSVProfileRepository) Delete(ctx context.Context, id int64) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM app_csv_profiles WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete CSV profile: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// isUniqueViolation reports whether err is a unique constraint violation
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation
+}
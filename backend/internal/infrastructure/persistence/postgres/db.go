@@ -69,5 +69,56 @@ func InitializeAppTables(ctx context.Context, pool *pgxpool.Pool) error {
 		return fmt.Errorf("failed to create index: %w", err)
 	}
 
+	// Create CSV import mapping profiles table
+	_, err = pool.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS app_csv_profiles (
+			id SERIAL PRIMARY KEY,
+			name VARCHAR(255) UNIQUE NOT NULL,
+			account_guid VARCHAR(32) NOT NULL,
+			counter_account_guid VARCHAR(32),
+			delimiter VARCHAR(4) NOT NULL DEFAULT ',',
+			has_header BOOLEAN NOT NULL DEFAULT TRUE,
+			skip_rows INTEGER NOT NULL DEFAULT 0,
+			date_column VARCHAR(255) NOT NULL,
+			date_format VARCHAR(64) NOT NULL DEFAULT 'YYYY-MM-DD',
+			amount_column VARCHAR(255) NOT NULL DEFAULT '',
+			debit_column VARCHAR(255) NOT NULL DEFAULT '',
+			credit_column VARCHAR(255) NOT NULL DEFAULT '',
+			sign_convention VARCHAR(32) NOT NULL DEFAULT 'deposits_positive',
+			decimal_separator VARCHAR(1) NOT NULL DEFAULT '.',
+			description_column VARCHAR(255) NOT NULL DEFAULT '',
+			memo_column VARCHAR(255) NOT NULL DEFAULT '',
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create app_csv_profiles table: %w", err)
+	}
+
+	// Create import rules table
+	_, err = pool.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS app_import_rules (
+			id SERIAL PRIMARY KEY,
+			name VARCHAR(255) UNIQUE NOT NULL,
+			priority INTEGER NOT NULL DEFAULT 0,
+			enabled BOOLEAN NOT NULL DEFAULT TRUE,
+			description_pattern TEXT NOT NULL DEFAULT '',
+			memo_pattern TEXT NOT NULL DEFAULT '',
+			min_amount NUMERIC,
+			max_amount NUMERIC,
+			source_account_guid VARCHAR(32),
+			counterparty VARCHAR(255) NOT NULL DEFAULT '',
+			destination_account_guid VARCHAR(32),
+			payee_rewrite TEXT,
+			memo TEXT,
+			created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+			updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create app_import_rules table: %w", err)
+	}
+
 	return nil
 }
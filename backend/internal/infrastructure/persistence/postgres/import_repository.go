@@ -0,0 +1,304 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+)
+
+// slotOnlineID is the split slot in which GnuCash keeps the bank's
+// identifier of an imported statement line
+const slotOnlineID = "online_id"
+
+// slotCounterpartyIBAN is the split slot holding the other party's IBAN of
+// an imported statement line
+const slotCounterpartyIBAN = "counterparty_iban"
+
+// slotBankDescription is the split slot holding the statement text of an
+// imported line whose description an import rule rewrote
+const slotBankDescription = "bank_description"
+
+// ImportRepository implements repository.ImportRepository for PostgreSQL
+type ImportRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewImportRepository creates a new PostgreSQL import repository
+func NewImportRepository(db *pgxpool.Pool) repository.ImportRepository {
+	return &ImportRepository{db: db}
+}
+
+// FindByOnlineIDs returns the GUIDs of the transactions whose split in the
+// account carries one of the given online_id slots, keyed by online id
+func (r *ImportRepository) FindByOnlineIDs(ctx context.Context, accountGUID string, onlineIDs []string) (map[string]string, error) {
+	return findByOnlineIDs(ctx, r.db, accountGUID, onlineIDs)
+}
+
+// FindCounterAccountsByIBAN returns, for each counterparty IBAN, the
+// counter account of the latest two-split transaction imported with it
+func (r *ImportRepository) FindCounterAccountsByIBAN(ctx context.Context, ibans []string) (map[string]string, error) {
+	found := make(map[string]string)
+	if len(ibans) == 0 {
+		return found, nil
+	}
+
+	query := `
+		SELECT DISTINCT ON (sl.string_val) sl.string_val, other.account_guid
+		FROM slots sl
+		INNER JOIN splits s ON sl.obj_guid = s.guid
+		INNER JOIN transactions t ON s.tx_guid = t.guid
+		INNER JOIN splits other ON other.tx_guid = s.tx_guid AND other.guid <> s.guid
+		WHERE sl.name = $1 AND sl.string_val = ANY($2)
+		  AND (SELECT COUNT(*) FROM splits c WHERE c.tx_guid = s.tx_guid) = 2
+		ORDER BY sl.string_val, t.post_date DESC, t.enter_date DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, slotCounterpartyIBAN, ibans)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query counterparty IBANs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var iban, accountGUID string
+		if err := rows.Scan(&iban, &accountGUID); err != nil {
+			return nil, fmt.Errorf("failed to scan counterparty IBAN: %w", err)
+		}
+		found[iban] = accountGUID
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating counterparty IBANs: %w", err)
+	}
+
+	return found, nil
+}
+
+// FindCategorizedHistory lists the splits of two-split transactions posted
+// since the given date, newest first, with their counter account. An empty
+// accountGUID covers the book's BANK, CASH and CREDIT accounts.
+func (r *ImportRepository) FindCategorizedHistory(ctx context.Context, accountGUID string, since time.Time) ([]*entity.CategorizedSplit, error) {
+	query := `
+		WITH RECURSIVE book_accounts AS (
+			SELECT guid FROM accounts WHERE guid = (SELECT root_account_guid FROM books LIMIT 1)
+			UNION ALL
+			SELECT a.guid FROM accounts a
+			INNER JOIN book_accounts b ON a.parent_guid = b.guid
+		)
+		SELECT s.guid, s.tx_guid, s.account_guid, s.value_num, s.value_denom,
+		       s.quantity_num, s.quantity_denom, t.post_date, COALESCE(t.description, ''),
+		       other.account_guid
+		FROM splits s
+		INNER JOIN transactions t ON s.tx_guid = t.guid
+		INNER JOIN accounts a ON s.account_guid = a.guid
+		INNER JOIN splits other ON other.tx_guid = s.tx_guid AND other.guid <> s.guid
+		WHERE t.post_date >= $1
+		  AND (SELECT COUNT(*) FROM splits c WHERE c.tx_guid = s.tx_guid) = 2
+		  AND (
+		    s.account_guid = $2
+		    OR ($2 = '' AND a.account_type IN ('BANK', 'CASH', 'CREDIT')
+		        AND s.account_guid IN (SELECT guid FROM book_accounts))
+		  )
+		ORDER BY t.post_date DESC, s.guid
+	`
+
+	rows, err := r.db.Query(ctx, query, since, accountGUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query categorized history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []*entity.CategorizedSplit
+	for rows.Next() {
+		row := &entity.CategorizedSplit{Split: &entity.Split{}}
+		err := rows.Scan(
+			&row.Split.GUID,
+			&row.Split.TxGUID,
+			&row.Split.AccountGUID,
+			&row.Split.ValueNum,
+			&row.Split.ValueDenom,
+			&row.Split.QuantityNum,
+			&row.Split.QuantityDenom,
+			&row.PostDate,
+			&row.Description,
+			&row.CounterAccountGUID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan split: %w", err)
+		}
+		history = append(history, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating splits: %w", err)
+	}
+
+	return history, nil
+}
+
+// FindAccountSplits lists the account's splits in transactions posted
+// within the date range, with their transaction's date and description and
+// the statement text of rewritten imports
+func (r *ImportRepository) FindAccountSplits(ctx context.Context, accountGUID string, start, end time.Time) ([]*entity.AccountSplit, error) {
+	query := `
+		SELECT s.guid, s.tx_guid, s.account_guid, s.quantity_num, s.quantity_denom,
+		       t.post_date, t.enter_date, t.num, t.description, sl.string_val
+		FROM splits s
+		INNER JOIN transactions t ON s.tx_guid = t.guid
+		LEFT JOIN slots sl ON sl.obj_guid = s.guid AND sl.name = $4
+		WHERE s.account_guid = $1 AND t.post_date >= $2 AND t.post_date <= $3
+		ORDER BY t.post_date, s.guid
+	`
+
+	rows, err := r.db.Query(ctx, query, accountGUID, start, end, slotBankDescription)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query account splits: %w", err)
+	}
+	defer rows.Close()
+
+	var splits []*entity.AccountSplit
+	for rows.Next() {
+		row := &entity.AccountSplit{Split: &entity.Split{}}
+		err := rows.Scan(
+			&row.Split.GUID,
+			&row.Split.TxGUID,
+			&row.Split.AccountGUID,
+			&row.Split.QuantityNum,
+			&row.Split.QuantityDenom,
+			&row.PostDate,
+			&row.EnterDate,
+			&row.Num,
+			&row.Description,
+			&row.BankDescription,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan split: %w", err)
+		}
+		splits = append(splits, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating splits: %w", err)
+	}
+
+	return splits, nil
+}
+
+// Commit stores imported transactions in a single database transaction,
+// skipping those whose online id is already present in the account or
+// earlier in the batch
+func (r *ImportRepository) Commit(ctx context.Context, accountGUID string, imports []*entity.ImportedTransaction) ([]*entity.ImportedTransaction, error) {
+	dbTx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	// Lock the account so concurrent imports cannot both add the same line
+	var locked string
+	if err := dbTx.QueryRow(ctx, `SELECT guid FROM accounts WHERE guid = $1 FOR UPDATE`, accountGUID).Scan(&locked); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to lock account: %w", err)
+	}
+
+	onlineIDs := make([]string, 0, len(imports))
+	for _, imp := range imports {
+		if imp.OnlineID != "" {
+			onlineIDs = append(onlineIDs, imp.OnlineID)
+		}
+	}
+	existing, err := findByOnlineIDs(ctx, dbTx, accountGUID, onlineIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var skipped []*entity.ImportedTransaction
+	seen := make(map[string]bool, len(onlineIDs))
+	for _, imp := range imports {
+		if imp.OnlineID != "" {
+			if _, ok := existing[imp.OnlineID]; ok || seen[imp.OnlineID] {
+				skipped = append(skipped, imp)
+				continue
+			}
+			seen[imp.OnlineID] = true
+		}
+
+		tx := imp.Transaction
+		if err := insertTransaction(ctx, dbTx, tx); err != nil {
+			return nil, err
+		}
+		for _, split := range tx.Splits {
+			split.TxGUID = tx.GUID
+			if err := insertSplit(ctx, dbTx, split); err != nil {
+				return nil, err
+			}
+			if split.AccountGUID != accountGUID {
+				continue
+			}
+			if imp.OnlineID != "" {
+				if err := setStringSlot(ctx, dbTx, split.GUID, slotOnlineID, imp.OnlineID); err != nil {
+					return nil, err
+				}
+			}
+			if imp.CounterpartyIBAN != "" {
+				if err := setStringSlot(ctx, dbTx, split.GUID, slotCounterpartyIBAN, imp.CounterpartyIBAN); err != nil {
+					return nil, err
+				}
+			}
+			if imp.BankDescription != "" {
+				if err := setStringSlot(ctx, dbTx, split.GUID, slotBankDescription, imp.BankDescription); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return skipped, nil
+}
+
+// findByOnlineIDs looks up online_id split slots within an account
+func findByOnlineIDs(ctx context.Context, q querier, accountGUID string, onlineIDs []string) (map[string]string, error) {
+	found := make(map[string]string)
+	if len(onlineIDs) == 0 {
+		return found, nil
+	}
+
+	query := `
+		SELECT sl.string_val, s.tx_guid
+		FROM slots sl
+		INNER JOIN splits s ON sl.obj_guid = s.guid
+		WHERE s.account_guid = $1 AND sl.name = $2 AND sl.string_val = ANY($3)
+	`
+
+	rows, err := q.Query(ctx, query, accountGUID, slotOnlineID, onlineIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query online ids: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var onlineID, txGUID string
+		if err := rows.Scan(&onlineID, &txGUID); err != nil {
+			return nil, fmt.Errorf("failed to scan online id: %w", err)
+		}
+		found[onlineID] = txGUID
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating online ids: %w", err)
+	}
+
+	return found, nil
+}
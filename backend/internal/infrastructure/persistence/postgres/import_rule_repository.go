@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+)
+
+// ImportRuleRepository implements repository.ImportRuleRepository for PostgreSQL
+type ImportRuleRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewImportRuleRepository creates a new PostgreSQL import rule repository
+func NewImportRuleRepository(db *pgxpool.Pool) repository.ImportRuleRepository {
+	return &ImportRuleRepository{db: db}
+}
+
+// Amounts are NUMERIC columns exchanged as text so they keep their exact value
+const importRuleColumns = `
+	id, name, priority, enabled, description_pattern, memo_pattern,
+	min_amount::text, max_amount::text, source_account_guid, counterparty,
+	destination_account_guid, payee_rewrite, memo, created_at, updated_at
+`
+
+// scanImportRule reads a rule row selected with importRuleColumns
+func scanImportRule(row pgx.Row) (*entity.ImportRule, error) {
+	rule := &entity.ImportRule{}
+	var minAmount, maxAmount *string
+	err := row.Scan(
+		&rule.ID,
+		&rule.Name,
+		&rule.Priority,
+		&rule.Enabled,
+		&rule.DescriptionPattern,
+		&rule.MemoPattern,
+		&minAmount,
+		&maxAmount,
+		&rule.SourceAccountGUID,
+		&rule.Counterparty,
+		&rule.DestinationAccountGUID,
+		&rule.PayeeRewrite,
+		&rule.Memo,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule.MinAmount, err = optionalDecimal(minAmount); err != nil {
+		return nil, err
+	}
+	if rule.MaxAmount, err = optionalDecimal(maxAmount); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// FindAll retrieves every rule in the order rules are applied
+func (r *ImportRuleRepository) FindAll(ctx context.Context) ([]*entity.ImportRule, error) {
+	query := `SELECT ` + importRuleColumns + ` FROM app_import_rules ORDER BY priority, id`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query import rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []*entity.ImportRule
+	for rows.Next() {
+		rule, err := scanImportRule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan import rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating import rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+// FindByID retrieves a rule by its ID
+func (r *ImportRuleRepository) FindByID(ctx context.Context, id int64) (*entity.ImportRule, error) {
+	query := `SELECT ` + importRuleColumns + ` FROM app_import_rules WHERE id = $1`
+
+	rule, err := scanImportRule(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to find import rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// Create stores a new rule
+func (r *ImportRuleRepository) Create(ctx context.Context, rule *entity.ImportRule) error {
+	query := `
+		INSERT INTO app_import_rules (
+			name, priority, enabled, description_pattern, memo_pattern,
+			min_amount, max_amount, source_account_guid, counterparty,
+			destination_account_guid, payee_rewrite, memo, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, CAST($6::text AS NUMERIC), CAST($7::text AS NUMERIC),
+		        $8, $9, $10, $11, $12, NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		rule.Name, rule.Priority, rule.Enabled, rule.DescriptionPattern, rule.MemoPattern,
+		decimalText(rule.MinAmount), decimalText(rule.MaxAmount), rule.SourceAccountGUID, rule.Counterparty,
+		rule.DestinationAccountGUID, rule.PayeeRewrite, rule.Memo,
+	).Scan(&rule.ID, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return repository.ErrConflict
+		}
+		return fmt.Errorf("failed to create import rule: %w", err)
+	}
+
+	return nil
+}
+
+// Update replaces a rule
+func (r *ImportRuleRepository) Update(ctx context.Context, rule *entity.ImportRule) error {
+	query := `
+		UPDATE app_import_rules
+		SET name = $2, priority = $3, enabled = $4, description_pattern = $5,
+		    memo_pattern = $6, min_amount = CAST($7::text AS NUMERIC),
+		    max_amount = CAST($8::text AS NUMERIC), source_account_guid = $9,
+		    counterparty = $10, destination_account_guid = $11, payee_rewrite = $12,
+		    memo = $13, updated_at = NOW()
+		WHERE id = $1
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		rule.ID, rule.Name, rule.Priority, rule.Enabled, rule.DescriptionPattern,
+		rule.MemoPattern, decimalText(rule.MinAmount), decimalText(rule.MaxAmount), rule.SourceAccountGUID,
+		rule.Counterparty, rule.DestinationAccountGUID, rule.PayeeRewrite, rule.Memo,
+	).Scan(&rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.ErrNotFound
+		}
+		if isUniqueViolation(err) {
+			return repository.ErrConflict
+		}
+		return fmt.Errorf("failed to update import rule: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a rule
+func (r *ImportRuleRepository) Delete(ctx context.Context, id int64) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM app_import_rules WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete import rule: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// decimalText returns an optional decimal as text for a NUMERIC parameter
+func decimalText(d *decimal.Decimal) *string {
+	if d == nil {
+		return nil
+	}
+	s := d.String()
+	return &s
+}
+
+// optionalDecimal parses an optional NUMERIC value read as text
+func optionalDecimal(s *string) (*decimal.Decimal, error) {
+	if s == nil {
+		return nil, nil
+	}
+	d, err := decimal.NewFromString(*s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount %q: %w", *s, err)
+	}
+	return &d, nil
+}
@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+)
+
+// LotRepository implements repository.LotRepository for PostgreSQL
+type LotRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewLotRepository creates a new PostgreSQL lot repository
+func NewLotRepository(db *pgxpool.Pool) repository.LotRepository {
+	return &LotRepository{db: db}
+}
+
+// lotSelectQuery selects lots with their title and notes slots and the
+// share balance of their splits, normalized to a fixed denominator
+const lotSelectQuery = `
+	SELECT l.guid, l.account_guid, l.is_closed,
+	       (SELECT string_val FROM slots WHERE obj_guid = l.guid AND name = 'title' LIMIT 1),
+	       (SELECT string_val FROM slots WHERE obj_guid = l.guid AND name = 'notes' LIMIT 1),
+	       ROUND(COALESCE(SUM(s.quantity_num::numeric * 100000 / s.quantity_denom::numeric), 0))::bigint,
+	       COUNT(s.guid),
+	       MIN(t.post_date)
+	FROM lots l
+	LEFT JOIN splits s ON s.lot_guid = l.guid
+	LEFT JOIN transactions t ON s.tx_guid = t.guid
+`
+
+// scanLot scans a row into a Lot entity
+func scanLot(row pgx.Row) (*entity.Lot, error) {
+	lot := &entity.Lot{QuantityDenom: 100000}
+	var isClosed int
+	err := row.Scan(
+		&lot.GUID,
+		&lot.AccountGUID,
+		&isClosed,
+		&lot.Title,
+		&lot.Notes,
+		&lot.QuantityNum,
+		&lot.SplitCount,
+		&lot.OpenedDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	lot.IsClosed = isClosed != 0
+	return lot, nil
+}
+
+// FindByGUID retrieves a lot by its GUID
+func (r *LotRepository) FindByGUID(ctx context.Context, guid string) (*entity.Lot, error) {
+	query := lotSelectQuery + ` WHERE l.guid = $1 GROUP BY l.guid, l.account_guid, l.is_closed`
+
+	lot, err := scanLot(r.db.QueryRow(ctx, query, guid))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to find lot: %w", err)
+	}
+
+	return lot, nil
+}
+
+// FindByAccount retrieves the lots of an account, oldest first
+func (r *LotRepository) FindByAccount(ctx context.Context, accountGUID string) ([]*entity.Lot, error) {
+	query := lotSelectQuery + `
+		WHERE l.account_guid = $1
+		GROUP BY l.guid, l.account_guid, l.is_closed
+		ORDER BY MIN(t.post_date) ASC NULLS LAST, l.guid
+	`
+
+	rows, err := r.db.Query(ctx, query, accountGUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query lots: %w", err)
+	}
+	defer rows.Close()
+
+	var lots []*entity.Lot
+	for rows.Next() {
+		lot, err := scanLot(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan lot: %w", err)
+		}
+		lots = append(lots, lot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating lots: %w", err)
+	}
+
+	return lots, nil
+}
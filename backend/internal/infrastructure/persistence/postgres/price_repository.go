@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+)
+
+// PriceRepository implements repository.PriceRepository for PostgreSQL
+type PriceRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPriceRepository creates a new PostgreSQL price repository
+func NewPriceRepository(db *pgxpool.Pool) repository.PriceRepository {
+	return &PriceRepository{db: db}
+}
+
+const priceSelectQuery = `
+	SELECT p.guid, p.commodity_guid, COALESCE(cm.mnemonic, ''), p.currency_guid, COALESCE(cu.mnemonic, ''),
+	       p.date, COALESCE(p.source, ''), COALESCE(p.type, ''), p.value_num, p.value_denom
+	FROM prices p
+	LEFT JOIN commodities cm ON p.commodity_guid = cm.guid
+	LEFT JOIN commodities cu ON p.currency_guid = cu.guid
+	WHERE p.commodity_guid = $1 AND ($2::text = '' OR p.currency_guid = $2::text)
+`
+
+// scanPrice scans a row into a Price entity
+func scanPrice(row pgx.Row) (*entity.Price, error) {
+	p := &entity.Price{}
+	err := row.Scan(
+		&p.GUID,
+		&p.CommodityGUID,
+		&p.CommodityMnemonic,
+		&p.CurrencyGUID,
+		&p.CurrencyMnemonic,
+		&p.Date,
+		&p.Source,
+		&p.Type,
+		&p.ValueNum,
+		&p.ValueDenom,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// FindLatest retrieves the most recent price for a commodity
+func (r *PriceRepository) FindLatest(ctx context.Context, commodityGUID, currencyGUID string) (*entity.Price, error) {
+	query := priceSelectQuery + ` ORDER BY p.date DESC LIMIT 1`
+
+	price, err := scanPrice(r.db.QueryRow(ctx, query, commodityGUID, currencyGUID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to find latest price: %w", err)
+	}
+
+	return price, nil
+}
+
+// FindAsOf retrieves the most recent price for a commodity on or before the given time
+func (r *PriceRepository) FindAsOf(ctx context.Context, commodityGUID, currencyGUID string, asOf time.Time) (*entity.Price, error) {
+	query := priceSelectQuery + ` AND p.date <= $3 ORDER BY p.date DESC LIMIT 1`
+
+	price, err := scanPrice(r.db.QueryRow(ctx, query, commodityGUID, currencyGUID, asOf))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to find price: %w", err)
+	}
+
+	return price, nil
+}
+
+// FindHistory retrieves prices for a commodity between two dates, oldest first
+func (r *PriceRepository) FindHistory(ctx context.Context, commodityGUID, currencyGUID string, startDate, endDate *time.Time) ([]*entity.Price, error) {
+	query := priceSelectQuery
+	args := []interface{}{commodityGUID, currencyGUID}
+	argPos := 3
+
+	if startDate != nil {
+		query += fmt.Sprintf(" AND p.date >= $%d", argPos)
+		args = append(args, *startDate)
+		argPos++
+	}
+
+	if endDate != nil {
+		query += fmt.Sprintf(" AND p.date <= $%d", argPos)
+		args = append(args, *endDate)
+	}
+
+	query += " ORDER BY p.date ASC"
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prices: %w", err)
+	}
+	defer rows.Close()
+
+	var prices []*entity.Price
+	for rows.Next() {
+		price, err := scanPrice(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan price: %w", err)
+		}
+		prices = append(prices, price)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating prices: %w", err)
+	}
+
+	return prices, nil
+}
+
+// FindAllAsOf retrieves the most recent price of every commodity/currency pair on or before the given time
+func (r *PriceRepository) FindAllAsOf(ctx context.Context, asOf time.Time) ([]*entity.Price, error) {
+	query := `
+		SELECT DISTINCT ON (p.commodity_guid, p.currency_guid)
+		       p.guid, p.commodity_guid, COALESCE(cm.mnemonic, ''), p.currency_guid, COALESCE(cu.mnemonic, ''),
+		       p.date, COALESCE(p.source, ''), COALESCE(p.type, ''), p.value_num, p.value_denom
+		FROM prices p
+		LEFT JOIN commodities cm ON p.commodity_guid = cm.guid
+		LEFT JOIN commodities cu ON p.currency_guid = cu.guid
+		WHERE p.date <= $1 AND p.value_num <> 0 AND p.value_denom <> 0
+		ORDER BY p.commodity_guid, p.currency_guid, p.date DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, asOf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prices: %w", err)
+	}
+	defer rows.Close()
+
+	var prices []*entity.Price
+	for rows.Next() {
+		price, err := scanPrice(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan price: %w", err)
+		}
+		prices = append(prices, price)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating prices: %w", err)
+	}
+
+	return prices, nil
+}
+
+// Create inserts a new price
+func (r *PriceRepository) Create(ctx context.Context, price *entity.Price) error {
+	query := `
+		INSERT INTO prices (guid, commodity_guid, currency_guid, date, source, type, value_num, value_denom)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		price.GUID,
+		price.CommodityGUID,
+		price.CurrencyGUID,
+		price.Date,
+		price.Source,
+		price.Type,
+		price.ValueNum,
+		price.ValueDenom,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create price: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,322 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
+)
+
+// GnuCash slot names for account reconciliation state
+const (
+	slotReconcileInfo     = "reconcile-info"
+	slotReconcileLastDate = "reconcile-info/last-date"
+	slotReconcilePostpone = "reconcile-info/postpone"
+	slotPostponeDate      = "reconcile-info/postpone/date"
+	slotPostponeBalance   = "reconcile-info/postpone/balance"
+)
+
+// ReconciliationRepository implements repository.ReconciliationRepository for PostgreSQL
+type ReconciliationRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewReconciliationRepository creates a new PostgreSQL reconciliation repository
+func NewReconciliationRepository(db *pgxpool.Pool) repository.ReconciliationRepository {
+	return &ReconciliationRepository{db: db}
+}
+
+// GetInfo reads an account's last reconcile date, reconciled balance and
+// postponed statement
+func (r *ReconciliationRepository) GetInfo(ctx context.Context, accountGUID string) (*entity.ReconcileInfo, error) {
+	info := &entity.ReconcileInfo{AccountGUID: accountGUID}
+
+	var reconciled string
+	query := `
+		SELECT COALESCE(SUM(quantity_num::numeric / quantity_denom::numeric), 0)::text
+		FROM splits
+		WHERE account_guid = $1 AND reconcile_state = $2
+	`
+	if err := r.db.QueryRow(ctx, query, accountGUID, entity.ReconcileStateReconciled).Scan(&reconciled); err != nil {
+		return nil, fmt.Errorf("failed to calculate reconciled balance: %w", err)
+	}
+	balance, err := decimal.NewFromString(reconciled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse reconciled balance: %w", err)
+	}
+	info.ReconciledBalance = balance
+
+	frameGUID, err := findFrameSlot(ctx, r.db, accountGUID, slotReconcileInfo)
+	if err != nil || frameGUID == "" {
+		return info, err
+	}
+
+	lastDate, err := getInt64Slot(ctx, r.db, frameGUID, slotReconcileLastDate)
+	if err != nil {
+		return nil, err
+	}
+	if lastDate != nil {
+		t := time.Unix(*lastDate, 0).UTC()
+		info.LastDate = &t
+	}
+
+	postponeGUID, err := findFrameSlot(ctx, r.db, frameGUID, slotReconcilePostpone)
+	if err != nil || postponeGUID == "" {
+		return info, err
+	}
+
+	postponeDate, err := getInt64Slot(ctx, r.db, postponeGUID, slotPostponeDate)
+	if err != nil {
+		return nil, err
+	}
+	num, denom, ok, err := getNumericSlot(ctx, r.db, postponeGUID, slotPostponeBalance)
+	if err != nil {
+		return nil, err
+	}
+	if postponeDate != nil && ok {
+		info.Postponed = &entity.ReconcileStatement{
+			Date:          time.Unix(*postponeDate, 0).UTC(),
+			EndingBalance: gnucash.RationalToDecimal(num, denom),
+		}
+	}
+
+	return info, nil
+}
+
+// FindUnreconciledSplits lists the account's new and cleared splits posted
+// on or before through, oldest first
+func (r *ReconciliationRepository) FindUnreconciledSplits(ctx context.Context, accountGUID string, through time.Time) ([]*entity.AccountSplit, error) {
+	query := `
+		SELECT s.guid, s.tx_guid, s.account_guid, s.lot_guid, s.memo, s.action,
+		       s.reconcile_state, s.reconcile_date, s.value_num, s.value_denom,
+		       s.quantity_num, s.quantity_denom,
+		       t.post_date, t.enter_date, t.num, t.description
+		FROM splits s
+		INNER JOIN transactions t ON s.tx_guid = t.guid
+		WHERE s.account_guid = $1 AND s.reconcile_state IN ($2, $3) AND t.post_date <= $4
+		ORDER BY t.post_date, t.enter_date, s.guid
+	`
+
+	rows, err := r.db.Query(ctx, query, accountGUID, entity.ReconcileStateNew, entity.ReconcileStateCleared, through)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unreconciled splits: %w", err)
+	}
+	defer rows.Close()
+
+	var splits []*entity.AccountSplit
+	for rows.Next() {
+		row := &entity.AccountSplit{Split: &entity.Split{}}
+		err := rows.Scan(
+			&row.Split.GUID,
+			&row.Split.TxGUID,
+			&row.Split.AccountGUID,
+			&row.Split.LotGUID,
+			&row.Split.Memo,
+			&row.Split.Action,
+			&row.Split.ReconcileState,
+			&row.Split.ReconcileDate,
+			&row.Split.ValueNum,
+			&row.Split.ValueDenom,
+			&row.Split.QuantityNum,
+			&row.Split.QuantityDenom,
+			&row.PostDate,
+			&row.EnterDate,
+			&row.Num,
+			&row.Description,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan split: %w", err)
+		}
+		splits = append(splits, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating splits: %w", err)
+	}
+
+	return splits, nil
+}
+
+// SavePostponed records the statement being reconciled in the account's
+// reconcile-info/postpone slots, replacing any earlier one
+func (r *ReconciliationRepository) SavePostponed(ctx context.Context, accountGUID string, statement *entity.ReconcileStatement) error {
+	dbTx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	frameGUID, err := ensureFrameSlot(ctx, dbTx, accountGUID, slotReconcileInfo)
+	if err != nil {
+		return err
+	}
+	postponeGUID, err := ensureFrameSlot(ctx, dbTx, frameGUID, slotReconcilePostpone)
+	if err != nil {
+		return err
+	}
+
+	if err := setInt64Slot(ctx, dbTx, postponeGUID, slotPostponeDate, statement.Date.Unix()); err != nil {
+		return err
+	}
+	num, denom := balanceToRational(statement.EndingBalance)
+	if err := setNumericSlot(ctx, dbTx, postponeGUID, slotPostponeBalance, num, denom); err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ClearPostponed removes the postponed statement
+func (r *ReconciliationRepository) ClearPostponed(ctx context.Context, accountGUID string) error {
+	dbTx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	if err := clearPostponed(ctx, dbTx, accountGUID); err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// SetCleared marks the given splits of the account as cleared or new
+func (r *ReconciliationRepository) SetCleared(ctx context.Context, accountGUID string, splitGUIDs []string, cleared bool) error {
+	state := entity.ReconcileStateNew
+	if cleared {
+		state = entity.ReconcileStateCleared
+	}
+
+	dbTx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	// Share-lock the account so a toggle cannot interleave with Finish
+	if _, err := dbTx.Exec(ctx, `SELECT guid FROM accounts WHERE guid = $1 FOR SHARE`, accountGUID); err != nil {
+		return fmt.Errorf("failed to lock account: %w", err)
+	}
+
+	query := `
+		UPDATE splits SET reconcile_state = $3
+		WHERE guid = ANY($1) AND account_guid = $2 AND reconcile_state IN ($4, $5)
+	`
+	tag, err := dbTx.Exec(ctx, query, splitGUIDs, accountGUID, state, entity.ReconcileStateNew, entity.ReconcileStateCleared)
+	if err != nil {
+		return fmt.Errorf("failed to update reconcile state: %w", err)
+	}
+	if tag.RowsAffected() != int64(len(splitGUIDs)) {
+		return repository.ErrConflict
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Finish marks the cleared splits posted on or before the statement date as
+// reconciled on that date, records it as the last reconcile date and removes
+// the postponed statement
+func (r *ReconciliationRepository) Finish(ctx context.Context, accountGUID string, statement *entity.ReconcileStatement) error {
+	dbTx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	// Lock the account so concurrent toggles wait for the reconciliation
+	var locked string
+	if err := dbTx.QueryRow(ctx, `SELECT guid FROM accounts WHERE guid = $1 FOR UPDATE`, accountGUID).Scan(&locked); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.ErrNotFound
+		}
+		return fmt.Errorf("failed to lock account: %w", err)
+	}
+
+	var balanced bool
+	query := `
+		SELECT COALESCE(SUM(s.quantity_num::numeric / s.quantity_denom::numeric), 0) = $5::text::numeric
+		FROM splits s
+		INNER JOIN transactions t ON s.tx_guid = t.guid
+		WHERE s.account_guid = $1
+		  AND (s.reconcile_state = $2 OR (s.reconcile_state = $3 AND t.post_date <= $4))
+	`
+	err = dbTx.QueryRow(ctx, query,
+		accountGUID,
+		entity.ReconcileStateReconciled,
+		entity.ReconcileStateCleared,
+		statement.Date,
+		statement.EndingBalance.String(),
+	).Scan(&balanced)
+	if err != nil {
+		return fmt.Errorf("failed to calculate cleared balance: %w", err)
+	}
+	if !balanced {
+		return repository.ErrConflict
+	}
+
+	update := `
+		UPDATE splits s SET reconcile_state = $2, reconcile_date = $4
+		FROM transactions t
+		WHERE s.tx_guid = t.guid AND s.account_guid = $1 AND s.reconcile_state = $3 AND t.post_date <= $4
+	`
+	_, err = dbTx.Exec(ctx, update, accountGUID, entity.ReconcileStateReconciled, entity.ReconcileStateCleared, statement.Date)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile splits: %w", err)
+	}
+
+	frameGUID, err := ensureFrameSlot(ctx, dbTx, accountGUID, slotReconcileInfo)
+	if err != nil {
+		return err
+	}
+	if err := setInt64Slot(ctx, dbTx, frameGUID, slotReconcileLastDate, statement.Date.Unix()); err != nil {
+		return err
+	}
+	if err := clearPostponed(ctx, dbTx, accountGUID); err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// clearPostponed removes the reconcile-info/postpone frame of an account
+func clearPostponed(ctx context.Context, q querier, accountGUID string) error {
+	frameGUID, err := findFrameSlot(ctx, q, accountGUID, slotReconcileInfo)
+	if err != nil || frameGUID == "" {
+		return err
+	}
+	return deleteFrameSlot(ctx, q, frameGUID, slotReconcilePostpone)
+}
+
+// balanceToRational converts a balance to the smallest power-of-ten
+// denominator that represents it exactly
+func balanceToRational(d decimal.Decimal) (int64, int64) {
+	denom := int64(1)
+	for exp := d.Exponent(); exp < 0; exp++ {
+		denom *= 10
+	}
+	return d.Mul(decimal.NewFromInt(denom)).IntPart(), denom
+}
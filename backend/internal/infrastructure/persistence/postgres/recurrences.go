@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+)
+
+// findRecurrences loads the recurrences of the given objects, keyed by object GUID
+func findRecurrences(ctx context.Context, q querier, objGUIDs []string) (map[string][]*entity.Recurrence, error) {
+	query := `
+		SELECT id, obj_guid, recurrence_mult, recurrence_period_type,
+		       recurrence_period_start, recurrence_weekend_adjust
+		FROM recurrences
+		WHERE obj_guid = ANY($1)
+		ORDER BY id
+	`
+
+	rows, err := q.Query(ctx, query, objGUIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recurrences: %w", err)
+	}
+	defer rows.Close()
+
+	recurrences := make(map[string][]*entity.Recurrence)
+	for rows.Next() {
+		r := &entity.Recurrence{}
+		if err := rows.Scan(&r.ID, &r.ObjGUID, &r.Mult, &r.PeriodType, &r.PeriodStart, &r.WeekendAdjust); err != nil {
+			return nil, fmt.Errorf("failed to scan recurrence: %w", err)
+		}
+		recurrences[r.ObjGUID] = append(recurrences[r.ObjGUID], r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating recurrences: %w", err)
+	}
+
+	return recurrences, nil
+}
+
+// insertRecurrence stores a recurrence for its object
+func insertRecurrence(ctx context.Context, q querier, r *entity.Recurrence) error {
+	query := `
+		INSERT INTO recurrences (obj_guid, recurrence_mult, recurrence_period_type,
+		                         recurrence_period_start, recurrence_weekend_adjust)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+
+	weekendAdjust := r.WeekendAdjust
+	if weekendAdjust == "" {
+		weekendAdjust = entity.WeekendAdjustNone
+	}
+
+	err := q.QueryRow(ctx, query,
+		r.ObjGUID,
+		r.Mult,
+		r.PeriodType,
+		r.PeriodStart,
+		weekendAdjust,
+	).Scan(&r.ID)
+	if err != nil {
+		return fmt.Errorf("failed to insert recurrence: %w", err)
+	}
+
+	return nil
+}
+
+// deleteRecurrences removes every recurrence attached to an object
+func deleteRecurrences(ctx context.Context, q querier, objGUID string) error {
+	if _, err := q.Exec(ctx, `DELETE FROM recurrences WHERE obj_guid = $1`, objGUID); err != nil {
+		return fmt.Errorf("failed to delete recurrences: %w", err)
+	}
+	return nil
+}
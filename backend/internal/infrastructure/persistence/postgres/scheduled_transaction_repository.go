@@ -0,0 +1,294 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+)
+
+// ScheduledTransactionRepository implements repository.ScheduledTransactionRepository for PostgreSQL
+type ScheduledTransactionRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewScheduledTransactionRepository creates a new PostgreSQL scheduled transaction repository
+func NewScheduledTransactionRepository(db *pgxpool.Pool) repository.ScheduledTransactionRepository {
+	return &ScheduledTransactionRepository{db: db}
+}
+
+const scheduledTransactionSelectQuery = `
+	SELECT guid, name, enabled, start_date, end_date, last_occur, num_occur, rem_occur,
+	       auto_create, auto_notify, adv_creation, adv_notify, instance_count, template_act_guid
+	FROM schedxactions
+`
+
+// scanScheduledTransaction scans a row into a ScheduledTransaction entity
+func scanScheduledTransaction(row pgx.Row) (*entity.ScheduledTransaction, error) {
+	sx := &entity.ScheduledTransaction{}
+	var enabled, autoCreate, autoNotify int
+	err := row.Scan(
+		&sx.GUID,
+		&sx.Name,
+		&enabled,
+		&sx.StartDate,
+		&sx.EndDate,
+		&sx.LastOccur,
+		&sx.NumOccur,
+		&sx.RemOccur,
+		&autoCreate,
+		&autoNotify,
+		&sx.AdvanceCreateDays,
+		&sx.AdvanceNotifyDays,
+		&sx.InstanceCount,
+		&sx.TemplateAccountGUID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sx.Enabled = enabled != 0
+	sx.AutoCreate = autoCreate != 0
+	sx.AutoNotify = autoNotify != 0
+	return sx, nil
+}
+
+// FindAll retrieves all scheduled transactions with their recurrences and templates
+func (r *ScheduledTransactionRepository) FindAll(ctx context.Context) ([]*entity.ScheduledTransaction, error) {
+	rows, err := r.db.Query(ctx, scheduledTransactionSelectQuery+` ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scheduled transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var sxs []*entity.ScheduledTransaction
+	for rows.Next() {
+		sx, err := scanScheduledTransaction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan scheduled transaction: %w", err)
+		}
+		sxs = append(sxs, sx)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating scheduled transactions: %w", err)
+	}
+
+	if err := r.loadDetails(ctx, sxs); err != nil {
+		return nil, err
+	}
+
+	return sxs, nil
+}
+
+// FindByGUID retrieves a scheduled transaction with its recurrences and templates
+func (r *ScheduledTransactionRepository) FindByGUID(ctx context.Context, guid string) (*entity.ScheduledTransaction, error) {
+	sx, err := scanScheduledTransaction(r.db.QueryRow(ctx, scheduledTransactionSelectQuery+` WHERE guid = $1`, guid))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to find scheduled transaction: %w", err)
+	}
+
+	if err := r.loadDetails(ctx, []*entity.ScheduledTransaction{sx}); err != nil {
+		return nil, err
+	}
+
+	return sx, nil
+}
+
+// loadDetails attaches recurrences and template transactions to scheduled transactions
+func (r *ScheduledTransactionRepository) loadDetails(ctx context.Context, sxs []*entity.ScheduledTransaction) error {
+	if len(sxs) == 0 {
+		return nil
+	}
+
+	guids := make([]string, len(sxs))
+	templateAccounts := make([]string, len(sxs))
+	for i, sx := range sxs {
+		guids[i] = sx.GUID
+		templateAccounts[i] = sx.TemplateAccountGUID
+	}
+
+	recurrences, err := findRecurrences(ctx, r.db, guids)
+	if err != nil {
+		return err
+	}
+
+	templates, err := r.findTemplates(ctx, templateAccounts)
+	if err != nil {
+		return err
+	}
+
+	for _, sx := range sxs {
+		sx.Recurrences = recurrences[sx.GUID]
+		sx.Templates = templates[sx.TemplateAccountGUID]
+	}
+
+	return nil
+}
+
+// findTemplates loads the template transactions posted to the given template
+// accounts, keyed by template account GUID. Each template split names its
+// real account and amount formulas in the sched-xaction slot frame.
+func (r *ScheduledTransactionRepository) findTemplates(ctx context.Context, templateAccounts []string) (map[string][]*entity.TemplateTransaction, error) {
+	query := `
+		SELECT s.account_guid, t.guid, t.currency_guid, t.num, t.description,
+		       s.guid, s.memo, s.action,
+		       MAX(f.guid_val) FILTER (WHERE f.name = 'sched-xaction/account'),
+		       MAX(f.string_val) FILTER (WHERE f.name = 'sched-xaction/debit-formula'),
+		       MAX(f.string_val) FILTER (WHERE f.name = 'sched-xaction/credit-formula'),
+		       MAX(f.numeric_val_num) FILTER (WHERE f.name = 'sched-xaction/debit-numeric'),
+		       MAX(f.numeric_val_denom) FILTER (WHERE f.name = 'sched-xaction/debit-numeric'),
+		       MAX(f.numeric_val_num) FILTER (WHERE f.name = 'sched-xaction/credit-numeric'),
+		       MAX(f.numeric_val_denom) FILTER (WHERE f.name = 'sched-xaction/credit-numeric')
+		FROM splits s
+		INNER JOIN transactions t ON t.guid = s.tx_guid
+		LEFT JOIN slots fr ON fr.obj_guid = s.guid AND fr.name = 'sched-xaction' AND fr.slot_type = $2
+		LEFT JOIN slots f ON f.obj_guid = fr.guid_val
+		WHERE s.account_guid = ANY($1)
+		GROUP BY s.account_guid, t.guid, t.currency_guid, t.num, t.description, t.post_date,
+		         s.guid, s.memo, s.action
+		ORDER BY t.post_date, t.guid, s.guid
+	`
+
+	rows, err := r.db.Query(ctx, query, templateAccounts, slotTypeFrame)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query template transactions: %w", err)
+	}
+	defer rows.Close()
+
+	templates := make(map[string][]*entity.TemplateTransaction)
+	byGUID := make(map[string]*entity.TemplateTransaction)
+	for rows.Next() {
+		var (
+			templateAccount string
+			tx              entity.TemplateTransaction
+			split           entity.TemplateSplit
+			accountGUID     *string
+			debitFormula    *string
+			creditFormula   *string
+			debitNum        *int64
+			debitDenom      *int64
+			creditNum       *int64
+			creditDenom     *int64
+		)
+		err := rows.Scan(
+			&templateAccount,
+			&tx.GUID,
+			&tx.CurrencyGUID,
+			&tx.Num,
+			&tx.Description,
+			&split.GUID,
+			&split.Memo,
+			&split.Action,
+			&accountGUID,
+			&debitFormula,
+			&creditFormula,
+			&debitNum,
+			&debitDenom,
+			&creditNum,
+			&creditDenom,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan template split: %w", err)
+		}
+
+		split.AccountGUID = entity.StringOrEmpty(accountGUID)
+		split.DebitFormula = entity.StringOrEmpty(debitFormula)
+		split.CreditFormula = entity.StringOrEmpty(creditFormula)
+		if debitNum != nil && debitDenom != nil {
+			split.DebitNum, split.DebitDenom = *debitNum, *debitDenom
+		}
+		if creditNum != nil && creditDenom != nil {
+			split.CreditNum, split.CreditDenom = *creditNum, *creditDenom
+		}
+
+		template, ok := byGUID[tx.GUID]
+		if !ok {
+			template = &tx
+			byGUID[tx.GUID] = template
+			templates[templateAccount] = append(templates[templateAccount], template)
+		}
+		template.Splits = append(template.Splits, &split)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating template splits: %w", err)
+	}
+
+	return templates, nil
+}
+
+// PostInstance stores the transactions created for one occurrence and
+// advances the scheduled transaction in a single database transaction. Each
+// transaction is linked back through the from-sched-xaction slot, as GnuCash does.
+func (r *ScheduledTransactionRepository) PostInstance(ctx context.Context, sx *entity.ScheduledTransaction, occurrence time.Time, txs []*entity.Transaction) error {
+	dbTx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	var (
+		lastOccur *time.Time
+		numOccur  int
+		remOccur  int
+	)
+	query := `SELECT last_occur, num_occur, rem_occur FROM schedxactions WHERE guid = $1 FOR UPDATE`
+	if err := dbTx.QueryRow(ctx, query, sx.GUID).Scan(&lastOccur, &numOccur, &remOccur); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.ErrNotFound
+		}
+		return fmt.Errorf("failed to lock scheduled transaction: %w", err)
+	}
+	if !sameDate(lastOccur, sx.LastOccur) || (numOccur > 0 && remOccur <= 0) {
+		return repository.ErrConflict
+	}
+
+	for _, tx := range txs {
+		if err := insertTransaction(ctx, dbTx, tx); err != nil {
+			return err
+		}
+		for _, split := range tx.Splits {
+			split.TxGUID = tx.GUID
+			if err := insertSplit(ctx, dbTx, split); err != nil {
+				return err
+			}
+		}
+		if err := setGUIDSlot(ctx, dbTx, tx.GUID, "from-sched-xaction", sx.GUID); err != nil {
+			return err
+		}
+	}
+
+	update := `
+		UPDATE schedxactions
+		SET last_occur = $2,
+		    rem_occur = CASE WHEN num_occur > 0 THEN rem_occur - 1 ELSE rem_occur END,
+		    instance_count = instance_count + 1
+		WHERE guid = $1
+	`
+	if _, err := dbTx.Exec(ctx, update, sx.GUID, occurrence); err != nil {
+		return fmt.Errorf("failed to advance scheduled transaction: %w", err)
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// sameDate reports whether two optional dates fall on the same day
+func sameDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Format("2006-01-02") == b.Format("2006-01-02")
+}
@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
+)
+
+// GnuCash slot_type values for the slots (KVP) table
+const (
+	slotTypeInt64   = 1
+	slotTypeDouble  = 2
+	slotTypeNumeric = 3
+	slotTypeString  = 4
+	slotTypeGUID    = 5
+	slotTypeTime64  = 6
+	slotTypeFrame   = 9
+	slotTypeGDate   = 10
+)
+
+// deleteSlots removes every slot attached to the given objects, including the
+// contents of nested frames
+func deleteSlots(ctx context.Context, q querier, objGUIDs []string) error {
+	if len(objGUIDs) == 0 {
+		return nil
+	}
+
+	query := `
+		WITH RECURSIVE doomed AS (
+			SELECT id, slot_type, guid_val FROM slots WHERE obj_guid = ANY($1)
+			UNION ALL
+			SELECT s.id, s.slot_type, s.guid_val FROM slots s
+			INNER JOIN doomed d ON d.slot_type = $2 AND s.obj_guid = d.guid_val
+		)
+		DELETE FROM slots WHERE id IN (SELECT id FROM doomed)
+	`
+
+	if _, err := q.Exec(ctx, query, objGUIDs, slotTypeFrame); err != nil {
+		return fmt.Errorf("failed to delete slots: %w", err)
+	}
+
+	return nil
+}
+
+// deleteSlot removes a single top-level slot from an object
+func deleteSlot(ctx context.Context, q querier, objGUID, name string) error {
+	if _, err := q.Exec(ctx, `DELETE FROM slots WHERE obj_guid = $1 AND name = $2`, objGUID, name); err != nil {
+		return fmt.Errorf("failed to delete slot %s: %w", name, err)
+	}
+	return nil
+}
+
+// setStringSlot stores a string-valued slot, replacing any existing value
+func setStringSlot(ctx context.Context, q querier, objGUID, name, value string) error {
+	if err := deleteSlot(ctx, q, objGUID, name); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO slots (obj_guid, name, slot_type, string_val) VALUES ($1, $2, $3, $4)`
+	if _, err := q.Exec(ctx, query, objGUID, name, slotTypeString, value); err != nil {
+		return fmt.Errorf("failed to set slot %s: %w", name, err)
+	}
+	return nil
+}
+
+// setGUIDSlot stores a GUID-valued slot, replacing any existing value
+func setGUIDSlot(ctx context.Context, q querier, objGUID, name, value string) error {
+	if err := deleteSlot(ctx, q, objGUID, name); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO slots (obj_guid, name, slot_type, guid_val) VALUES ($1, $2, $3, $4)`
+	if _, err := q.Exec(ctx, query, objGUID, name, slotTypeGUID, value); err != nil {
+		return fmt.Errorf("failed to set slot %s: %w", name, err)
+	}
+	return nil
+}
+
+// setNumericSlot stores a rational-valued slot, replacing any existing value
+func setNumericSlot(ctx context.Context, q querier, objGUID, name string, num, denom int64) error {
+	if err := deleteSlot(ctx, q, objGUID, name); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO slots (obj_guid, name, slot_type, numeric_val_num, numeric_val_denom) VALUES ($1, $2, $3, $4, $5)`
+	if _, err := q.Exec(ctx, query, objGUID, name, slotTypeNumeric, num, denom); err != nil {
+		return fmt.Errorf("failed to set slot %s: %w", name, err)
+	}
+	return nil
+}
+
+// getStringSlot reads a string-valued slot, returning nil if it is not set
+func getStringSlot(ctx context.Context, q querier, objGUID, name string) (*string, error) {
+	query := `SELECT string_val FROM slots WHERE obj_guid = $1 AND name = $2 LIMIT 1`
+
+	var value *string
+	err := q.QueryRow(ctx, query, objGUID, name).Scan(&value)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read slot %s: %w", name, err)
+	}
+	return value, nil
+}
+
+// findFrameSlot returns the GUID that holds the contents of a frame slot, or
+// an empty string if the frame is not set
+func findFrameSlot(ctx context.Context, q querier, objGUID, name string) (string, error) {
+	query := `SELECT guid_val FROM slots WHERE obj_guid = $1 AND name = $2 AND slot_type = $3 LIMIT 1`
+
+	var frameGUID *string
+	err := q.QueryRow(ctx, query, objGUID, name, slotTypeFrame).Scan(&frameGUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read slot %s: %w", name, err)
+	}
+	return entity.StringOrEmpty(frameGUID), nil
+}
+
+// ensureFrameSlot returns the GUID that holds the contents of a frame slot,
+// creating the frame if it does not exist yet
+func ensureFrameSlot(ctx context.Context, q querier, objGUID, name string) (string, error) {
+	frameGUID, err := findFrameSlot(ctx, q, objGUID, name)
+	if err != nil || frameGUID != "" {
+		return frameGUID, err
+	}
+
+	frameGUID = gnucash.NewGUID()
+	query := `INSERT INTO slots (obj_guid, name, slot_type, guid_val) VALUES ($1, $2, $3, $4)`
+	if _, err := q.Exec(ctx, query, objGUID, name, slotTypeFrame, frameGUID); err != nil {
+		return "", fmt.Errorf("failed to create slot frame %s: %w", name, err)
+	}
+	return frameGUID, nil
+}
+
+// deleteFrameSlot removes a frame slot together with its contents
+func deleteFrameSlot(ctx context.Context, q querier, objGUID, name string) error {
+	frameGUID, err := findFrameSlot(ctx, q, objGUID, name)
+	if err != nil {
+		return err
+	}
+	if frameGUID != "" {
+		if err := deleteSlots(ctx, q, []string{frameGUID}); err != nil {
+			return err
+		}
+	}
+	return deleteSlot(ctx, q, objGUID, name)
+}
+
+// setInt64Slot stores an integer-valued slot, replacing any existing value
+func setInt64Slot(ctx context.Context, q querier, objGUID, name string, value int64) error {
+	if err := deleteSlot(ctx, q, objGUID, name); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO slots (obj_guid, name, slot_type, int64_val) VALUES ($1, $2, $3, $4)`
+	if _, err := q.Exec(ctx, query, objGUID, name, slotTypeInt64, value); err != nil {
+		return fmt.Errorf("failed to set slot %s: %w", name, err)
+	}
+	return nil
+}
+
+// getInt64Slot reads an integer-valued slot, returning nil if it is not set
+func getInt64Slot(ctx context.Context, q querier, objGUID, name string) (*int64, error) {
+	query := `SELECT int64_val FROM slots WHERE obj_guid = $1 AND name = $2 LIMIT 1`
+
+	var value *int64
+	err := q.QueryRow(ctx, query, objGUID, name).Scan(&value)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read slot %s: %w", name, err)
+	}
+	return value, nil
+}
+
+// getNumericSlot reads a rational-valued slot; ok is false if it is not set
+func getNumericSlot(ctx context.Context, q querier, objGUID, name string) (num, denom int64, ok bool, err error) {
+	query := `SELECT numeric_val_num, numeric_val_denom FROM slots WHERE obj_guid = $1 AND name = $2 LIMIT 1`
+
+	var n, d *int64
+	err = q.QueryRow(ctx, query, objGUID, name).Scan(&n, &d)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, 0, false, nil
+		}
+		return 0, 0, false, fmt.Errorf("failed to read slot %s: %w", name, err)
+	}
+	if n == nil || d == nil || *d == 0 {
+		return 0, 0, false, nil
+	}
+	return *n, *d, true, nil
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
+)
+
+// errSumOverflow is returned when an exact sum does not fit in an int64 fraction
+var errSumOverflow = errors.New("sum does not fit in a 64-bit fraction")
+
+// Amounts are summed per denominator in SQL and the partial sums added exactly
+// in Go, so no amount is rounded to a common denominator
+
+// scanRationalSum reads rows of a denominator and the sum of the numerators
+// over it, and adds them exactly
+func scanRationalSum(rows pgx.Rows) (int64, int64, error) {
+	defer rows.Close()
+
+	var sum gnucash.RationalSum
+	for rows.Next() {
+		var denom, num int64
+		if err := rows.Scan(&denom, &num); err != nil {
+			return 0, 0, fmt.Errorf("failed to scan sum: %w", err)
+		}
+		sum.Add(num, denom)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, 0, fmt.Errorf("error iterating sums: %w", err)
+	}
+
+	num, denom, ok := sum.Rational()
+	if !ok {
+		return 0, 0, errSumOverflow
+	}
+	return num, denom, nil
+}
+
+// scanAccountSums reads rows of account GUID, account name, denominator, sum
+// of the numerators over it and the account's count, grouped by account and
+// denominator, and adds each account's sums exactly. The count is the same on
+// every row of an account. Accounts keep the order of their first row.
+func scanAccountSums(rows pgx.Rows) ([]*repository.AccountAggregate, error) {
+	defer rows.Close()
+
+	var aggregates []*repository.AccountAggregate
+	sums := make(map[string]*gnucash.RationalSum)
+	for rows.Next() {
+		agg := &repository.AccountAggregate{}
+		var denom, num int64
+		if err := rows.Scan(&agg.AccountGUID, &agg.AccountName, &denom, &num, &agg.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan aggregate: %w", err)
+		}
+
+		sum, ok := sums[agg.AccountGUID]
+		if !ok {
+			sum = &gnucash.RationalSum{}
+			sums[agg.AccountGUID] = sum
+			aggregates = append(aggregates, agg)
+		}
+		sum.Add(num, denom)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating aggregates: %w", err)
+	}
+
+	for _, agg := range aggregates {
+		num, denom, ok := sums[agg.AccountGUID].Rational()
+		if !ok {
+			return nil, fmt.Errorf("account %s: %w", agg.AccountGUID, errSumOverflow)
+		}
+		agg.TotalAmount, agg.Denominator = num, denom
+	}
+
+	return aggregates, nil
+}
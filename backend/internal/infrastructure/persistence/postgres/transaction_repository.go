@@ -2,13 +2,17 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
 	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
 )
 
 // TransactionRepository implements repository.TransactionRepository for PostgreSQL
@@ -16,6 +20,12 @@ type TransactionRepository struct {
 	db *pgxpool.Pool
 }
 
+// transactionSlotColumns selects the void and reversal markers GnuCash keeps in
+// the slots table for a transaction aliased as t
+const transactionSlotColumns = `(SELECT sl.string_val FROM slots sl WHERE sl.obj_guid = t.guid AND sl.name = 'void-reason' LIMIT 1),
+		       (SELECT sl.guid_val FROM slots sl WHERE sl.obj_guid = t.guid AND sl.name = 'reversed-by' LIMIT 1),
+		       (SELECT sl.obj_guid FROM slots sl WHERE sl.guid_val = t.guid AND sl.name = 'reversed-by' LIMIT 1)`
+
 // NewTransactionRepository creates a new PostgreSQL transaction repository
 func NewTransactionRepository(db *pgxpool.Pool) repository.TransactionRepository {
 	return &TransactionRepository{db: db}
@@ -24,49 +34,24 @@ func NewTransactionRepository(db *pgxpool.Pool) repository.TransactionRepository
 // FindAll retrieves all transactions with optional filtering
 func (r *TransactionRepository) FindAll(ctx context.Context, filter *repository.TransactionFilter) ([]*entity.Transaction, error) {
 	query := `
-		SELECT DISTINCT t.guid, t.currency_guid, COALESCE(c.mnemonic, ''), t.num, t.post_date, t.enter_date, t.description
+		SELECT DISTINCT t.guid, t.currency_guid, COALESCE(c.mnemonic, ''), t.num, t.post_date, t.enter_date, t.description,
+		       ` + transactionSlotColumns + `
 		FROM transactions t
 		LEFT JOIN commodities c ON t.currency_guid = c.guid
 	`
 
-	var conditions []string
-	var args []interface{}
-	argPos := 1
-
-	if filter != nil {
-		if filter.AccountGUID != nil {
-			conditions = append(conditions, fmt.Sprintf(`EXISTS (
-				SELECT 1 FROM splits s WHERE s.tx_guid = t.guid AND s.account_guid = $%d
-			)`, argPos))
-			args = append(args, *filter.AccountGUID)
-			argPos++
-		}
-
-		if filter.StartDate != nil {
-			conditions = append(conditions, fmt.Sprintf("t.post_date >= $%d", argPos))
-			args = append(args, *filter.StartDate)
-			argPos++
-		}
-
-		if filter.EndDate != nil {
-			conditions = append(conditions, fmt.Sprintf("t.post_date <= $%d", argPos))
-			args = append(args, *filter.EndDate)
-			argPos++
-		}
-
-		if filter.Description != nil {
-			conditions = append(conditions, fmt.Sprintf("t.description ILIKE $%d", argPos))
-			args = append(args, "%"+*filter.Description+"%")
-			argPos++
-		}
-	}
-
+	conditions, args := transactionConditions(filter)
 	if len(conditions) > 0 {
 		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	query += " ORDER BY t.post_date DESC, t.enter_date DESC"
+	if filter != nil && filter.OldestFirst {
+		query += " ORDER BY t.post_date, t.enter_date"
+	} else {
+		query += " ORDER BY t.post_date DESC, t.enter_date DESC"
+	}
 
+	argPos := len(args) + 1
 	if filter != nil {
 		if filter.Limit > 0 {
 			query += fmt.Sprintf(" LIMIT $%d", argPos)
@@ -97,13 +82,17 @@ func (r *TransactionRepository) FindAll(ctx context.Context, filter *repository.
 			&tx.PostDate,
 			&tx.EnterDate,
 			&tx.Description,
+			&tx.VoidReason,
+			&tx.ReversedByGUID,
+			&tx.ReversalOfGUID,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan transaction: %w", err)
 		}
+		tx.Voided = tx.VoidReason != nil
 
 		// Load splits for this transaction
-		splits, err := r.loadSplitsForTransaction(ctx, tx.GUID)
+		splits, err := loadSplitsForTransaction(ctx, r.db, tx.GUID)
 		if err != nil {
 			return nil, err
 		}
@@ -117,15 +106,25 @@ func (r *TransactionRepository) FindAll(ctx context.Context, filter *repository.
 
 // FindByGUID retrieves a transaction by its GUID
 func (r *TransactionRepository) FindByGUID(ctx context.Context, guid string) (*entity.Transaction, error) {
+	return findTransaction(ctx, r.db, guid, false)
+}
+
+// findTransaction loads a transaction with its splits. When forUpdate is set the
+// transaction row is locked until the surrounding database transaction ends.
+func findTransaction(ctx context.Context, q querier, guid string, forUpdate bool) (*entity.Transaction, error) {
 	query := `
-		SELECT t.guid, t.currency_guid, COALESCE(c.mnemonic, ''), t.num, t.post_date, t.enter_date, t.description
+		SELECT t.guid, t.currency_guid, COALESCE(c.mnemonic, ''), t.num, t.post_date, t.enter_date, t.description,
+		       ` + transactionSlotColumns + `
 		FROM transactions t
 		LEFT JOIN commodities c ON t.currency_guid = c.guid
 		WHERE t.guid = $1
 	`
+	if forUpdate {
+		query += " FOR UPDATE OF t"
+	}
 
 	tx := &entity.Transaction{}
-	err := r.db.QueryRow(ctx, query, guid).Scan(
+	err := q.QueryRow(ctx, query, guid).Scan(
 		&tx.GUID,
 		&tx.CurrencyGUID,
 		&tx.CurrencyMnemonic,
@@ -133,13 +132,20 @@ func (r *TransactionRepository) FindByGUID(ctx context.Context, guid string) (*e
 		&tx.PostDate,
 		&tx.EnterDate,
 		&tx.Description,
+		&tx.VoidReason,
+		&tx.ReversedByGUID,
+		&tx.ReversalOfGUID,
 	)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
 		return nil, fmt.Errorf("failed to find transaction: %w", err)
 	}
+	tx.Voided = tx.VoidReason != nil
 
 	// Load splits
-	splits, err := r.loadSplitsForTransaction(ctx, guid)
+	splits, err := loadSplitsForTransaction(ctx, q, guid)
 	if err != nil {
 		return nil, err
 	}
@@ -162,68 +168,229 @@ func (r *TransactionRepository) FindByAccount(ctx context.Context, accountGUID s
 func (r *TransactionRepository) Count(ctx context.Context, filter *repository.TransactionFilter) (int64, error) {
 	query := `SELECT COUNT(DISTINCT t.guid) FROM transactions t`
 
+	conditions, args := transactionConditions(filter)
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var count int64
+	err := r.db.QueryRow(ctx, query, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count transactions: %w", err)
+	}
+
+	return count, nil
+}
+
+// transactionConditions builds the WHERE conditions and arguments of a
+// transaction filter for a transactions table aliased as t
+func transactionConditions(filter *repository.TransactionFilter) ([]string, []interface{}) {
 	var conditions []string
 	var args []interface{}
-	argPos := 1
+	if filter == nil {
+		return conditions, args
+	}
 
-	if filter != nil {
-		if filter.AccountGUID != nil {
-			conditions = append(conditions, fmt.Sprintf(`EXISTS (
-				SELECT 1 FROM splits s WHERE s.tx_guid = t.guid AND s.account_guid = $%d
-			)`, argPos))
-			args = append(args, *filter.AccountGUID)
-			argPos++
-		}
+	// The account and amount bounds apply to the same split
+	var splitConditions []string
+	if filter.AccountGUID != nil {
+		args = append(args, *filter.AccountGUID)
+		splitConditions = append(splitConditions, fmt.Sprintf("s.account_guid = $%d", len(args)))
+	}
+	if filter.MinAmount != nil {
+		args = append(args, filter.MinAmount.String())
+		splitConditions = append(splitConditions, fmt.Sprintf(
+			"ABS(s.quantity_num::numeric / s.quantity_denom) >= CAST($%d::text AS NUMERIC)", len(args)))
+	}
+	if filter.MaxAmount != nil {
+		args = append(args, filter.MaxAmount.String())
+		splitConditions = append(splitConditions, fmt.Sprintf(
+			"ABS(s.quantity_num::numeric / s.quantity_denom) <= CAST($%d::text AS NUMERIC)", len(args)))
+	}
+	if len(splitConditions) > 0 {
+		conditions = append(conditions, `EXISTS (
+			SELECT 1 FROM splits s WHERE s.tx_guid = t.guid AND `+strings.Join(splitConditions, " AND ")+`
+		)`)
+	}
 
-		if filter.StartDate != nil {
-			conditions = append(conditions, fmt.Sprintf("t.post_date >= $%d", argPos))
-			args = append(args, *filter.StartDate)
-			argPos++
-		}
+	if filter.StartDate != nil {
+		args = append(args, *filter.StartDate)
+		conditions = append(conditions, fmt.Sprintf("t.post_date >= $%d", len(args)))
+	}
 
-		if filter.EndDate != nil {
-			conditions = append(conditions, fmt.Sprintf("t.post_date <= $%d", argPos))
-			args = append(args, *filter.EndDate)
-			argPos++
-		}
+	if filter.EndDate != nil {
+		args = append(args, *filter.EndDate)
+		conditions = append(conditions, fmt.Sprintf("t.post_date <= $%d", len(args)))
+	}
+
+	if filter.Description != nil {
+		args = append(args, "%"+*filter.Description+"%")
+		conditions = append(conditions, fmt.Sprintf("t.description ILIKE $%d", len(args)))
+	}
+
+	// Template splits are posted to the children of the book's template root
+	if filter.ExcludeTemplates {
+		conditions = append(conditions, `NOT EXISTS (
+			SELECT 1 FROM splits ts
+			INNER JOIN accounts ta ON ts.account_guid = ta.guid
+			INNER JOIN books b ON ta.parent_guid = b.root_template_guid
+			WHERE ts.tx_guid = t.guid
+		)`)
+	}
+
+	return conditions, args
+}
+
+// AggregateByAccountType returns aggregated transaction data grouped by account for accounts of specified type
+func (r *TransactionRepository) AggregateByAccountType(ctx context.Context, accountType entity.AccountType, startDate, endDate *time.Time) ([]*repository.AccountAggregate, error) {
+	matched := `
+		SELECT s.account_guid, s.tx_guid, s.value_num, s.value_denom
+		FROM splits s
+		INNER JOIN transactions t ON s.tx_guid = t.guid
+		INNER JOIN accounts a ON s.account_guid = a.guid
+		WHERE a.account_type = $1
+	`
+
+	args := []interface{}{accountType}
+	argPos := 2
+
+	if startDate != nil {
+		matched += fmt.Sprintf(" AND t.post_date >= $%d", argPos)
+		args = append(args, *startDate)
+		argPos++
+	}
+
+	if endDate != nil {
+		matched += fmt.Sprintf(" AND t.post_date <= $%d", argPos)
+		args = append(args, *endDate)
+	}
+
+	query := `
+		WITH matched AS (` + matched + `)
+		SELECT m.account_guid, a.name, m.value_denom, SUM(ABS(m.value_num))::bigint,
+		       (SELECT COUNT(DISTINCT c.tx_guid) FROM matched c WHERE c.account_guid = m.account_guid)
+		FROM matched m
+		INNER JOIN accounts a ON m.account_guid = a.guid
+		GROUP BY m.account_guid, a.name, m.value_denom
+	`
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate by account type: %w", err)
+	}
+
+	sums, err := scanAccountSums(rows)
+	if err != nil {
+		return nil, err
+	}
 
-		if filter.Description != nil {
-			conditions = append(conditions, fmt.Sprintf("t.description ILIKE $%d", argPos))
-			args = append(args, "%"+*filter.Description+"%")
+	aggregates := make([]*repository.AccountAggregate, 0, len(sums))
+	for _, agg := range sums {
+		if agg.TotalAmount != 0 {
+			aggregates = append(aggregates, agg)
 		}
 	}
+	sort.SliceStable(aggregates, func(i, j int) bool {
+		a := gnucash.RationalToDecimal(aggregates[i].TotalAmount, aggregates[i].Denominator)
+		b := gnucash.RationalToDecimal(aggregates[j].TotalAmount, aggregates[j].Denominator)
+		return a.GreaterThan(b)
+	})
 
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
+	return aggregates, nil
+}
+
+// SumQuantityByAccount returns the signed sum of split quantities per account
+// for transactions posted within the optional date range
+func (r *TransactionRepository) SumQuantityByAccount(ctx context.Context, startDate, endDate *time.Time) ([]*repository.AccountAggregate, error) {
+	matched := `
+		SELECT s.account_guid, s.tx_guid, s.quantity_num, s.quantity_denom
+		FROM splits s
+		INNER JOIN transactions t ON s.tx_guid = t.guid
+		WHERE 1 = 1
+	`
+
+	var args []interface{}
+	argPos := 1
+
+	if startDate != nil {
+		matched += fmt.Sprintf(" AND t.post_date >= $%d", argPos)
+		args = append(args, *startDate)
+		argPos++
 	}
 
-	var count int64
-	err := r.db.QueryRow(ctx, query, args...).Scan(&count)
+	if endDate != nil {
+		matched += fmt.Sprintf(" AND t.post_date <= $%d", argPos)
+		args = append(args, *endDate)
+	}
+
+	query := `
+		WITH matched AS (` + matched + `)
+		SELECT m.account_guid, a.name, m.quantity_denom, SUM(m.quantity_num)::bigint,
+		       (SELECT COUNT(DISTINCT c.tx_guid) FROM matched c WHERE c.account_guid = m.account_guid)
+		FROM matched m
+		INNER JOIN accounts a ON m.account_guid = a.guid
+		GROUP BY m.account_guid, a.name, m.quantity_denom
+	`
+
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-		return 0, fmt.Errorf("failed to count transactions: %w", err)
+		return nil, fmt.Errorf("failed to sum quantities by account: %w", err)
 	}
 
-	return count, nil
+	return scanAccountSums(rows)
 }
 
-// AggregateByAccountType returns aggregated transaction data grouped by account for accounts of specified type
-func (r *TransactionRepository) AggregateByAccountType(ctx context.Context, accountType entity.AccountType, startDate, endDate *time.Time) ([]*repository.AccountAggregate, error) {
-	const targetDenom = 100000
+// SumSpendingByAccount returns the signed sum of split quantities per account
+// for transactions posted within the date range that touch an EXPENSE account
+// and were not created from a scheduled transaction
+func (r *TransactionRepository) SumSpendingByAccount(ctx context.Context, startDate, endDate time.Time) ([]*repository.AccountAggregate, error) {
+	query := `
+		WITH matched AS (
+			SELECT s.account_guid, s.tx_guid, s.quantity_num, s.quantity_denom
+			FROM splits s
+			INNER JOIN transactions t ON s.tx_guid = t.guid
+			INNER JOIN accounts a ON s.account_guid = a.guid
+			WHERE t.post_date >= $1 AND t.post_date <= $2
+			  AND a.account_type <> 'EXPENSE'
+			  AND EXISTS (
+				SELECT 1 FROM splits es
+				INNER JOIN accounts ea ON es.account_guid = ea.guid
+				WHERE es.tx_guid = t.guid AND ea.account_type = 'EXPENSE'
+			  )
+			  AND NOT EXISTS (
+				SELECT 1 FROM slots sl WHERE sl.obj_guid = t.guid AND sl.name = 'from-sched-xaction'
+			  )
+		)
+		SELECT m.account_guid, a.name, m.quantity_denom, SUM(m.quantity_num)::bigint,
+		       (SELECT COUNT(DISTINCT c.tx_guid) FROM matched c WHERE c.account_guid = m.account_guid)
+		FROM matched m
+		INNER JOIN accounts a ON m.account_guid = a.guid
+		GROUP BY m.account_guid, a.name, m.quantity_denom
+	`
+
+	rows, err := r.db.Query(ctx, query, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum spending by account: %w", err)
+	}
+
+	return scanAccountSums(rows)
+}
+
+// SumDebitsCredits returns the debit and credit totals of split values per
+// account and transaction currency for transactions posted within the optional date range
+func (r *TransactionRepository) SumDebitsCredits(ctx context.Context, startDate, endDate *time.Time) ([]*repository.DebitCreditTotal, error) {
 	query := `
-		SELECT
-			a.guid as account_guid,
-			a.name as account_name,
-			ROUND(COALESCE(SUM(ABS(s.value_num::numeric * $1 / s.value_denom::numeric)), 0)) as total_num,
-			$1 as denom,
-			COUNT(DISTINCT t.guid) as tx_count
-		FROM accounts a
-		LEFT JOIN splits s ON s.account_guid = a.guid
-		LEFT JOIN transactions t ON s.tx_guid = t.guid
-		WHERE a.account_type = $2
+		SELECT s.account_guid, t.currency_guid, s.value_denom,
+		       COALESCE(SUM(s.value_num) FILTER (WHERE s.value_num > 0), 0)::bigint,
+		       COALESCE(-SUM(s.value_num) FILTER (WHERE s.value_num < 0), 0)::bigint,
+		       COUNT(*)
+		FROM splits s
+		INNER JOIN transactions t ON s.tx_guid = t.guid
+		WHERE 1 = 1
 	`
 
-	args := []interface{}{targetDenom, accountType}
-	argPos := 3
+	var args []interface{}
+	argPos := 1
 
 	if startDate != nil {
 		query += fmt.Sprintf(" AND t.post_date >= $%d", argPos)
@@ -236,42 +403,431 @@ func (r *TransactionRepository) AggregateByAccountType(ctx context.Context, acco
 		args = append(args, *endDate)
 	}
 
-	query += `
-		GROUP BY a.guid, a.name
-		HAVING SUM(ABS(s.value_num)) > 0
-		ORDER BY total_num DESC
-	`
+	query += " GROUP BY s.account_guid, t.currency_guid, s.value_denom"
 
 	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to aggregate by account type: %w", err)
+		return nil, fmt.Errorf("failed to sum debits and credits: %w", err)
 	}
 	defer rows.Close()
 
-	var aggregates []*repository.AccountAggregate
+	type key struct{ account, currency string }
+	type sums struct {
+		debit, credit gnucash.RationalSum
+		count         int
+	}
+	var order []key
+	byKey := make(map[key]*sums)
 	for rows.Next() {
-		agg := &repository.AccountAggregate{}
-		err := rows.Scan(
-			&agg.AccountGUID,
-			&agg.AccountName,
-			&agg.TotalAmount,
-			&agg.Denominator,
-			&agg.Count,
-		)
+		var k key
+		var denom, debit, credit int64
+		var count int
+		if err := rows.Scan(&k.account, &k.currency, &denom, &debit, &credit, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan debits and credits: %w", err)
+		}
+
+		sum, ok := byKey[k]
+		if !ok {
+			sum = &sums{}
+			byKey[k] = sum
+			order = append(order, k)
+		}
+		sum.debit.Add(debit, denom)
+		sum.credit.Add(credit, denom)
+		sum.count += count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating debits and credits: %w", err)
+	}
+
+	totals := make([]*repository.DebitCreditTotal, 0, len(order))
+	for _, k := range order {
+		sum := byKey[k]
+		total := &repository.DebitCreditTotal{AccountGUID: k.account, CurrencyGUID: k.currency, SplitCount: sum.count}
+		var ok bool
+		if total.DebitNum, total.DebitDenom, ok = sum.debit.Rational(); !ok {
+			return nil, fmt.Errorf("account %s: %w", k.account, errSumOverflow)
+		}
+		if total.CreditNum, total.CreditDenom, ok = sum.credit.Rational(); !ok {
+			return nil, fmt.Errorf("account %s: %w", k.account, errSumOverflow)
+		}
+		totals = append(totals, total)
+	}
+
+	return totals, nil
+}
+
+// Create inserts a transaction and all of its splits in a single database transaction
+func (r *TransactionRepository) Create(ctx context.Context, tx *entity.Transaction) error {
+	dbTx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	if err := insertTransaction(ctx, dbTx, tx); err != nil {
+		return err
+	}
+
+	for _, split := range tx.Splits {
+		split.TxGUID = tx.GUID
+		if err := insertSplit(ctx, dbTx, split); err != nil {
+			return err
+		}
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Update replaces a transaction's fields and split set, provided the stored
+// transaction still matches expectedVersion. Splits whose GUIDs are kept are
+// updated in place, missing ones are deleted and new ones are inserted.
+func (r *TransactionRepository) Update(ctx context.Context, tx *entity.Transaction, expectedVersion string) error {
+	dbTx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	current, err := findTransaction(ctx, dbTx, tx.GUID, true)
+	if err != nil {
+		return err
+	}
+	if current.Version() != expectedVersion {
+		return repository.ErrConflict
+	}
+
+	query := `
+		UPDATE transactions
+		SET currency_guid = $2, num = $3, post_date = $4, description = $5
+		WHERE guid = $1
+	`
+	_, err = dbTx.Exec(ctx, query,
+		tx.GUID,
+		tx.CurrencyGUID,
+		entity.StringOrEmpty(tx.Num),
+		tx.PostDate,
+		entity.StringOrEmpty(tx.Description),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update transaction: %w", err)
+	}
+
+	kept := make(map[string]bool, len(tx.Splits))
+	for _, split := range tx.Splits {
+		kept[split.GUID] = true
+	}
+
+	var removed []string
+	existing := make(map[string]bool, len(current.Splits))
+	for _, split := range current.Splits {
+		existing[split.GUID] = true
+		if !kept[split.GUID] {
+			removed = append(removed, split.GUID)
+		}
+	}
+
+	if len(removed) > 0 {
+		if err := deleteSlots(ctx, dbTx, removed); err != nil {
+			return err
+		}
+		if _, err := dbTx.Exec(ctx, `DELETE FROM splits WHERE guid = ANY($1)`, removed); err != nil {
+			return fmt.Errorf("failed to delete splits: %w", err)
+		}
+	}
+
+	for _, split := range tx.Splits {
+		split.TxGUID = tx.GUID
+		if existing[split.GUID] {
+			err = updateSplit(ctx, dbTx, split)
+		} else {
+			err = insertSplit(ctx, dbTx, split)
+		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan aggregate: %w", err)
+			return err
 		}
-		aggregates = append(aggregates, agg)
 	}
 
-	return aggregates, nil
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a transaction, its splits and their slots, provided the stored
+// transaction still matches expectedVersion
+func (r *TransactionRepository) Delete(ctx context.Context, guid string, expectedVersion string) error {
+	dbTx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	current, err := findTransaction(ctx, dbTx, guid, true)
+	if err != nil {
+		return err
+	}
+	if current.Version() != expectedVersion {
+		return repository.ErrConflict
+	}
+
+	objGUIDs := []string{guid}
+	for _, split := range current.Splits {
+		objGUIDs = append(objGUIDs, split.GUID)
+	}
+	if err := deleteSlots(ctx, dbTx, objGUIDs); err != nil {
+		return err
+	}
+
+	if _, err := dbTx.Exec(ctx, `DELETE FROM splits WHERE tx_guid = $1`, guid); err != nil {
+		return fmt.Errorf("failed to delete splits: %w", err)
+	}
+	if _, err := dbTx.Exec(ctx, `DELETE FROM transactions WHERE guid = $1`, guid); err != nil {
+		return fmt.Errorf("failed to delete transaction: %w", err)
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Void zeroes a transaction's splits the way GnuCash does: the original amounts
+// are kept in void-former-amount/void-former-value split slots, the reason is
+// recorded in the notes and void-reason slots, and the transaction is marked read-only
+func (r *TransactionRepository) Void(ctx context.Context, guid string, reason string, expectedVersion string) error {
+	dbTx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	current, err := findTransaction(ctx, dbTx, guid, true)
+	if err != nil {
+		return err
+	}
+	if current.Version() != expectedVersion || current.Voided {
+		return repository.ErrConflict
+	}
+
+	notes, err := getStringSlot(ctx, dbTx, guid, "notes")
+	if err != nil {
+		return err
+	}
+	if notes != nil {
+		if err := setStringSlot(ctx, dbTx, guid, "void-former-notes", *notes); err != nil {
+			return err
+		}
+	}
+
+	voidTime := time.Now().UTC().Format("2006-01-02 15:04:05.000000 -0700")
+	if err := setStringSlot(ctx, dbTx, guid, "notes", reason); err != nil {
+		return err
+	}
+	if err := setStringSlot(ctx, dbTx, guid, "void-reason", reason); err != nil {
+		return err
+	}
+	if err := setStringSlot(ctx, dbTx, guid, "void-time", voidTime); err != nil {
+		return err
+	}
+	if err := setStringSlot(ctx, dbTx, guid, "trans-read-only", "Transaction Voided"); err != nil {
+		return err
+	}
+
+	for _, split := range current.Splits {
+		if err := setNumericSlot(ctx, dbTx, split.GUID, "void-former-amount", split.QuantityNum, split.QuantityDenom); err != nil {
+			return err
+		}
+		if err := setNumericSlot(ctx, dbTx, split.GUID, "void-former-value", split.ValueNum, split.ValueDenom); err != nil {
+			return err
+		}
+
+		query := `
+			UPDATE splits
+			SET value_num = 0, quantity_num = 0, reconcile_state = $2
+			WHERE guid = $1
+		`
+		if _, err := dbTx.Exec(ctx, query, split.GUID, entity.ReconcileStateVoided); err != nil {
+			return fmt.Errorf("failed to void split: %w", err)
+		}
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Reverse stores a reversing transaction and links it from the original through
+// the reversed-by slot, as GnuCash does
+func (r *TransactionRepository) Reverse(ctx context.Context, originalGUID string, reversal *entity.Transaction) error {
+	dbTx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	original, err := findTransaction(ctx, dbTx, originalGUID, true)
+	if err != nil {
+		return err
+	}
+	if original.ReversedByGUID != nil || original.Voided {
+		return repository.ErrConflict
+	}
+
+	if err := insertTransaction(ctx, dbTx, reversal); err != nil {
+		return err
+	}
+	for _, split := range reversal.Splits {
+		split.TxGUID = reversal.GUID
+		if err := insertSplit(ctx, dbTx, split); err != nil {
+			return err
+		}
+	}
+
+	if err := setGUIDSlot(ctx, dbTx, originalGUID, "reversed-by", reversal.GUID); err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// MoveSplits moves splits to another account and detaches them from their
+// lots, which belong to the old account. Reconciled splits go back to not
+// reconciled, since the target account's reconciliation never included them.
+// Every split must still be in the source account, otherwise nothing is moved.
+func (r *TransactionRepository) MoveSplits(ctx context.Context, splitGUIDs []string, sourceGUID, targetGUID string) error {
+	dbTx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback(ctx)
+
+	query := `
+		UPDATE splits
+		SET account_guid = $3, lot_guid = NULL,
+			reconcile_state = CASE WHEN reconcile_state = $4 THEN $5 ELSE reconcile_state END,
+			reconcile_date = CASE WHEN reconcile_state = $4 THEN NULL ELSE reconcile_date END
+		WHERE guid = ANY($1) AND account_guid = $2
+	`
+	tag, err := dbTx.Exec(ctx, query, splitGUIDs, sourceGUID, targetGUID,
+		entity.ReconcileStateReconciled, entity.ReconcileStateNew)
+	if err != nil {
+		return fmt.Errorf("failed to move splits: %w", err)
+	}
+	if tag.RowsAffected() != int64(len(splitGUIDs)) {
+		return repository.ErrConflict
+	}
+
+	if err := dbTx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// insertTransaction writes a row into the GnuCash transactions table
+func insertTransaction(ctx context.Context, q querier, tx *entity.Transaction) error {
+	query := `
+		INSERT INTO transactions (guid, currency_guid, num, post_date, enter_date, description)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := q.Exec(ctx, query,
+		tx.GUID,
+		tx.CurrencyGUID,
+		entity.StringOrEmpty(tx.Num),
+		tx.PostDate,
+		tx.EnterDate,
+		entity.StringOrEmpty(tx.Description),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert transaction: %w", err)
+	}
+
+	return nil
+}
+
+// insertSplit writes a row into the GnuCash splits table
+func insertSplit(ctx context.Context, q querier, split *entity.Split) error {
+	query := `
+		INSERT INTO splits (guid, tx_guid, account_guid, memo, action, reconcile_state, reconcile_date,
+		                    value_num, value_denom, quantity_num, quantity_denom, lot_guid)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	reconcileState := split.ReconcileState
+	if reconcileState == "" {
+		reconcileState = entity.ReconcileStateNew
+	}
+
+	_, err := q.Exec(ctx, query,
+		split.GUID,
+		split.TxGUID,
+		split.AccountGUID,
+		entity.StringOrEmpty(split.Memo),
+		entity.StringOrEmpty(split.Action),
+		reconcileState,
+		split.ReconcileDate,
+		split.ValueNum,
+		split.ValueDenom,
+		split.QuantityNum,
+		split.QuantityDenom,
+		split.LotGUID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert split: %w", err)
+	}
+
+	return nil
+}
+
+// updateSplit rewrites an existing row in the GnuCash splits table
+func updateSplit(ctx context.Context, q querier, split *entity.Split) error {
+	query := `
+		UPDATE splits
+		SET account_guid = $2, memo = $3, action = $4, reconcile_state = $5, reconcile_date = $6,
+		    value_num = $7, value_denom = $8, quantity_num = $9, quantity_denom = $10,
+		    lot_guid = CASE WHEN account_guid = $2 THEN lot_guid ELSE NULL END
+		WHERE guid = $1 AND tx_guid = $11
+	`
+
+	_, err := q.Exec(ctx, query,
+		split.GUID,
+		split.AccountGUID,
+		entity.StringOrEmpty(split.Memo),
+		entity.StringOrEmpty(split.Action),
+		split.ReconcileState,
+		split.ReconcileDate,
+		split.ValueNum,
+		split.ValueDenom,
+		split.QuantityNum,
+		split.QuantityDenom,
+		split.TxGUID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update split: %w", err)
+	}
+
+	return nil
 }
 
 // loadSplitsForTransaction loads splits for a transaction
-func (r *TransactionRepository) loadSplitsForTransaction(ctx context.Context, txGUID string) ([]*entity.Split, error) {
+func loadSplitsForTransaction(ctx context.Context, q querier, txGUID string) ([]*entity.Split, error) {
 	query := `
-		SELECT s.guid, s.tx_guid, s.account_guid, s.memo, s.action,
-		       s.reconcile_state, s.value_num, s.value_denom,
+		SELECT s.guid, s.tx_guid, s.account_guid, s.lot_guid, s.memo, s.action,
+		       s.reconcile_state, s.reconcile_date, s.value_num, s.value_denom,
 		       s.quantity_num, s.quantity_denom,
 		       a.name as account_name, a.account_type
 		FROM splits s
@@ -280,7 +836,7 @@ func (r *TransactionRepository) loadSplitsForTransaction(ctx context.Context, tx
 		ORDER BY s.value_num DESC
 	`
 
-	rows, err := r.db.Query(ctx, query, txGUID)
+	rows, err := q.Query(ctx, query, txGUID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query splits: %w", err)
 	}
@@ -295,9 +851,11 @@ func (r *TransactionRepository) loadSplitsForTransaction(ctx context.Context, tx
 			&split.GUID,
 			&split.TxGUID,
 			&split.AccountGUID,
+			&split.LotGUID,
 			&split.Memo,
 			&split.Action,
 			&split.ReconcileState,
+			&split.ReconcileDate,
 			&split.ValueNum,
 			&split.ValueDenom,
 			&split.QuantityNum,
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/application/service"
 	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
 	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
 	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
@@ -13,15 +14,19 @@ import (
 
 // AccountHandler handles account-related HTTP requests
 type AccountHandler struct {
-	accountRepo   repository.AccountRepository
-	commodityRepo repository.CommodityRepository
+	accountRepo    repository.AccountRepository
+	commodityRepo  repository.CommodityRepository
+	lotRepo        repository.LotRepository
+	accountService *service.AccountService
 }
 
 // NewAccountHandler creates a new account handler
-func NewAccountHandler(accountRepo repository.AccountRepository, commodityRepo repository.CommodityRepository) *AccountHandler {
+func NewAccountHandler(accountRepo repository.AccountRepository, commodityRepo repository.CommodityRepository, lotRepo repository.LotRepository, accountService *service.AccountService) *AccountHandler {
 	return &AccountHandler{
-		accountRepo:   accountRepo,
-		commodityRepo: commodityRepo,
+		accountRepo:    accountRepo,
+		commodityRepo:  commodityRepo,
+		lotRepo:        lotRepo,
+		accountService: accountService,
 	}
 }
 
@@ -116,6 +121,158 @@ func (h *AccountHandler) GetAccountBalance(c *gin.Context) {
 	})
 }
 
+// GetAccountLots retrieves the lots of an account
+func (h *AccountHandler) GetAccountLots(c *gin.Context) {
+	guid := c.Param("guid")
+
+	if _, err := h.accountRepo.FindByGUID(c.Request.Context(), guid); err != nil {
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "Not Found",
+			Message: "Account not found",
+			Code:    http.StatusNotFound,
+		})
+		return
+	}
+
+	lots, err := h.lotRepo.FindByAccount(c.Request.Context(), guid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Internal Server Error",
+			Message: "Failed to retrieve lots",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	response := make([]dto.LotResponse, len(lots))
+	for i, lot := range lots {
+		response[i] = dto.LotResponse{
+			GUID:        lot.GUID,
+			AccountGUID: lot.AccountGUID,
+			Title:       lot.Title,
+			Notes:       lot.Notes,
+			IsClosed:    lot.IsClosed,
+			Quantity:    gnucash.FormatAmount(lot.QuantityNum, lot.QuantityDenom),
+			SplitCount:  lot.SplitCount,
+			OpenedDate:  lot.OpenedDate,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetAccountRegister retrieves a page of an account's splits oldest first with
+// their counter-account and running balance
+func (h *AccountHandler) GetAccountRegister(c *gin.Context) {
+	limit, ok := parseIntQuery(c, "limit")
+	if !ok {
+		return
+	}
+
+	response, err := h.accountService.GetRegister(c.Request.Context(), c.Param("guid"), c.Query("cursor"), limit)
+	if err != nil {
+		respondServiceError(c, err, "Failed to retrieve register")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateAccount creates a new account
+func (h *AccountHandler) CreateAccount(c *gin.Context) {
+	var req dto.CreateAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	account, err := h.accountService.CreateAccount(c.Request.Context(), &req)
+	if err != nil {
+		respondServiceError(c, err, "Failed to create account")
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.toAccountResponse(account))
+}
+
+// UpdateAccount renames an account or changes its flags
+func (h *AccountHandler) UpdateAccount(c *gin.Context) {
+	var req dto.UpdateAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	account, err := h.accountService.UpdateAccount(c.Request.Context(), c.Param("guid"), &req)
+	if err != nil {
+		respondServiceError(c, err, "Failed to update account")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.toAccountResponse(account))
+}
+
+// MoveAccount reparents an account
+func (h *AccountHandler) MoveAccount(c *gin.Context) {
+	var req dto.MoveAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	account, err := h.accountService.MoveAccount(c.Request.Context(), c.Param("guid"), req.ParentGUID)
+	if err != nil {
+		respondServiceError(c, err, "Failed to move account")
+		return
+	}
+
+	c.JSON(http.StatusOK, h.toAccountResponse(account))
+}
+
+// DeleteAccount deletes an account, moving its splits to reassign_to if given
+func (h *AccountHandler) DeleteAccount(c *gin.Context) {
+	err := h.accountService.DeleteAccount(c.Request.Context(), c.Param("guid"), c.Query("reassign_to"))
+	if err != nil {
+		respondServiceError(c, err, "Failed to delete account")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// MergeAccount merges an account into a target account
+func (h *AccountHandler) MergeAccount(c *gin.Context) {
+	var req dto.MergeAccountsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	response, err := h.accountService.MergeAccounts(c.Request.Context(), c.Param("guid"), &req)
+	if err != nil {
+		respondServiceError(c, err, "Failed to merge accounts")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // toAccountResponse converts an entity.Account to dto.AccountResponse
 func (h *AccountHandler) toAccountResponse(account *entity.Account) dto.AccountResponse {
 	return dto.AccountResponse{
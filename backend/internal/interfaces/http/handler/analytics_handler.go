@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"encoding/json"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -123,17 +125,308 @@ func (h *AnalyticsHandler) GetCategoryBreakdown(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-// GetNetWorth returns current net worth
+// GetNetWorth returns net worth as of a date, converted to a report currency
 func (h *AnalyticsHandler) GetNetWorth(c *gin.Context) {
-	response, err := h.analyticsService.GetNetWorth(c.Request.Context())
+	asOf, ok := parseAsOf(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.analyticsService.GetNetWorth(c.Request.Context(), c.Query("currency"), asOf)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
-			Error:   "Internal Server Error",
-			Message: "Failed to calculate net worth",
-			Code:    http.StatusInternalServerError,
-		})
+		respondServiceError(c, err, "Failed to calculate net worth")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetBalanceSheet returns assets, liabilities and equity as account trees as of a date
+func (h *AnalyticsHandler) GetBalanceSheet(c *gin.Context) {
+	asOf, ok := parseAsOf(c)
+	if !ok {
+		return
+	}
+	includeHidden := c.Query("include_hidden") == "true"
+	includeZero := c.Query("include_zero") == "true"
+
+	response, err := h.analyticsService.GetBalanceSheet(c.Request.Context(), c.Query("currency"), asOf, includeHidden, includeZero)
+	if err != nil {
+		respondServiceError(c, err, "Failed to calculate balance sheet")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetIncomeStatement returns the income and expense trees with one column per
+// period, as JSON or, with format=csv, as a spreadsheet
+func (h *AnalyticsHandler) GetIncomeStatement(c *gin.Context) {
+	now := time.Now()
+	startDate, ok := parseDateQuery(c, "start_date", time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.UTC))
+	if !ok {
+		return
+	}
+	endDate, ok := parseDateQuery(c, "end_date", now)
+	if !ok {
+		return
+	}
+	includeHidden := c.Query("include_hidden") == "true"
+	includeZero := c.Query("include_zero") == "true"
+
+	response, err := h.analyticsService.GetIncomeStatement(c.Request.Context(), startDate, endDate, c.Query("period"), c.Query("currency"), includeHidden, includeZero)
+	if err != nil {
+		respondServiceError(c, err, "Failed to calculate income statement")
 		return
 	}
 
+	if wantsCSV(c) {
+		respondCSV(c, "income-statement-"+response.StartDate+"-"+response.EndDate+".csv", incomeStatementCSV(response))
+		return
+	}
 	c.JSON(http.StatusOK, response)
 }
+
+// GetCashFlowStatement returns the change in bank and cash balances over a
+// period split into operating, investing and financing activities
+func (h *AnalyticsHandler) GetCashFlowStatement(c *gin.Context) {
+	now := time.Now()
+	startDate, ok := parseDateQuery(c, "start_date", time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.UTC))
+	if !ok {
+		return
+	}
+	endDate, ok := parseDateQuery(c, "end_date", now)
+	if !ok {
+		return
+	}
+	typeBuckets, ok := parseMapQuery(c, "type_buckets")
+	if !ok {
+		return
+	}
+	accountBuckets, ok := parseMapQuery(c, "account_buckets")
+	if !ok {
+		return
+	}
+
+	response, err := h.analyticsService.GetCashFlowStatement(c.Request.Context(), startDate, endDate, c.Query("currency"), typeBuckets, accountBuckets)
+	if err != nil {
+		respondServiceError(c, err, "Failed to calculate cash flow statement")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetTrialBalance returns each account's debit and credit totals for a period
+// with a per-currency balance check, as JSON or, with format=csv, as a spreadsheet
+func (h *AnalyticsHandler) GetTrialBalance(c *gin.Context) {
+	startDate, ok := parseDateQuery(c, "start_date", time.Time{})
+	if !ok {
+		return
+	}
+	endDate, ok := parseDateQuery(c, "end_date", time.Now())
+	if !ok {
+		return
+	}
+
+	response, err := h.analyticsService.GetTrialBalance(c.Request.Context(), optionalDate(startDate), endDate)
+	if err != nil {
+		respondServiceError(c, err, "Failed to calculate trial balance")
+		return
+	}
+
+	if wantsCSV(c) {
+		respondCSV(c, "trial-balance-"+response.EndDate+".csv", trialBalanceCSV(response))
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetGeneralJournal returns transactions with their splits oldest first, as
+// JSON or, with format=csv, as a spreadsheet. JSON pages default to 100
+// transactions while the CSV export has every transaction unless limited.
+func (h *AnalyticsHandler) GetGeneralJournal(c *gin.Context) {
+	startDate, ok := parseDateQuery(c, "start_date", time.Time{})
+	if !ok {
+		return
+	}
+	endDate, ok := parseDateQuery(c, "end_date", time.Time{})
+	if !ok {
+		return
+	}
+	limit, ok := parseIntQuery(c, "limit")
+	if !ok {
+		return
+	}
+	offset, ok := parseIntQuery(c, "offset")
+	if !ok {
+		return
+	}
+	csvExport := wantsCSV(c)
+	if c.Query("limit") == "" && !csvExport {
+		limit = 100
+	}
+
+	response, err := h.analyticsService.GetGeneralJournal(c.Request.Context(), optionalDate(startDate), optionalDate(endDate), limit, offset)
+	if err != nil {
+		respondServiceError(c, err, "Failed to load general journal")
+		return
+	}
+
+	if csvExport {
+		respondCSV(c, "general-journal.csv", generalJournalCSV(response))
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetHoldings returns investment holdings with market values as of a date
+func (h *AnalyticsHandler) GetHoldings(c *gin.Context) {
+	asOf, ok := parseAsOf(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.analyticsService.GetHoldings(c.Request.Context(), c.Query("currency"), asOf)
+	if err != nil {
+		respondServiceError(c, err, "Failed to calculate holdings")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetCapitalGains returns realized capital gains for a tax year
+func (h *AnalyticsHandler) GetCapitalGains(c *gin.Context) {
+	year := time.Now().Year()
+	if yearStr := c.Query("year"); yearStr != "" {
+		var err error
+		year, err = strconv.Atoi(yearStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Bad Request",
+				Message: "Invalid year",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+	}
+
+	response, err := h.analyticsService.GetCapitalGains(c.Request.Context(), year, c.Query("method"), c.Query("currency"))
+	if err != nil {
+		respondServiceError(c, err, "Failed to calculate capital gains")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetForecast returns a day-by-day projection of bank, cash and credit balances
+func (h *AnalyticsHandler) GetForecast(c *gin.Context) {
+	days, ok := parseIntQuery(c, "days")
+	if !ok {
+		return
+	}
+	historyMonths, ok := parseIntQuery(c, "history_months")
+	if !ok {
+		return
+	}
+	includeHistory := c.Query("include_history") == "true"
+
+	response, err := h.analyticsService.GetForecast(c.Request.Context(), days, c.Query("threshold"), c.Query("credit_limit"), includeHistory, historyMonths)
+	if err != nil {
+		respondServiceError(c, err, "Failed to calculate forecast")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parseIntQuery reads an optional integer query parameter, defaulting to 0.
+// It writes a 400 response and returns false when the value is malformed.
+func parseIntQuery(c *gin.Context, name string) (int, bool) {
+	str := c.Query(name)
+	if str == "" {
+		return 0, true
+	}
+
+	value, err := strconv.Atoi(str)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: "Invalid " + name,
+			Code:    http.StatusBadRequest,
+		})
+		return 0, false
+	}
+	return value, true
+}
+
+// parseDateQuery reads an optional YYYY-MM-DD query parameter, defaulting to def.
+// It writes a 400 response and returns false when the date is malformed.
+func parseDateQuery(c *gin.Context, name string, def time.Time) (time.Time, bool) {
+	str := c.Query(name)
+	if str == "" {
+		return def, true
+	}
+
+	date, err := time.Parse("2006-01-02", str)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: "Invalid " + name + " format. Use YYYY-MM-DD",
+			Code:    http.StatusBadRequest,
+		})
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// parseMapQuery reads an optional query parameter holding a JSON object of
+// strings. It writes a 400 response and returns false when it is malformed.
+func parseMapQuery(c *gin.Context, name string) (map[string]string, bool) {
+	str := c.Query(name)
+	if str == "" {
+		return nil, true
+	}
+
+	var values map[string]string
+	if err := json.Unmarshal([]byte(str), &values); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: "Invalid " + name + ", use a JSON object of strings",
+			Code:    http.StatusBadRequest,
+		})
+		return nil, false
+	}
+	return values, true
+}
+
+// optionalDate returns nil for the zero date of an omitted query parameter
+func optionalDate(date time.Time) *time.Time {
+	if date.IsZero() {
+		return nil
+	}
+	return &date
+}
+
+// parseAsOf reads the optional as_of query parameter, defaulting to today.
+// It writes a 400 response and returns false when the date is malformed.
+func parseAsOf(c *gin.Context) (time.Time, bool) {
+	asOfStr := c.Query("as_of")
+	if asOfStr == "" {
+		return time.Now(), true
+	}
+
+	asOf, err := time.Parse("2006-01-02", asOfStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: "Invalid as_of format. Use YYYY-MM-DD",
+			Code:    http.StatusBadRequest,
+		})
+		return time.Time{}, false
+	}
+
+	return asOf, true
+}
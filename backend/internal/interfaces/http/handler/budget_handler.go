@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/application/service"
+)
+
+// BudgetHandler handles budget-related HTTP requests
+type BudgetHandler struct {
+	budgetService    *service.BudgetService
+	analyticsService *service.AnalyticsService
+}
+
+// NewBudgetHandler creates a new budget handler
+func NewBudgetHandler(budgetService *service.BudgetService, analyticsService *service.AnalyticsService) *BudgetHandler {
+	return &BudgetHandler{
+		budgetService:    budgetService,
+		analyticsService: analyticsService,
+	}
+}
+
+// GetBudgets retrieves all budgets
+func (h *BudgetHandler) GetBudgets(c *gin.Context) {
+	budgets, err := h.budgetService.ListBudgets(c.Request.Context())
+	if err != nil {
+		respondServiceError(c, err, "Failed to retrieve budgets")
+		return
+	}
+
+	c.JSON(http.StatusOK, budgets)
+}
+
+// GetBudget retrieves a specific budget by GUID
+func (h *BudgetHandler) GetBudget(c *gin.Context) {
+	budget, err := h.budgetService.GetBudget(c.Request.Context(), c.Param("guid"))
+	if err != nil {
+		respondServiceError(c, err, "Failed to retrieve budget")
+		return
+	}
+
+	c.JSON(http.StatusOK, budget)
+}
+
+// GetBudgetReport returns budgeted, actual and variance amounts per account and period
+func (h *BudgetHandler) GetBudgetReport(c *gin.Context) {
+	var period *int
+	if periodStr := c.Query("period"); periodStr != "" {
+		p, err := strconv.Atoi(periodStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Bad Request",
+				Message: "Invalid period",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		period = &p
+	}
+
+	report, err := h.analyticsService.GetBudgetReport(c.Request.Context(), c.Param("guid"), period)
+	if err != nil {
+		respondServiceError(c, err, "Failed to calculate budget report")
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// CreateBudget creates a new budget
+func (h *BudgetHandler) CreateBudget(c *gin.Context) {
+	var req dto.CreateBudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	budget, err := h.budgetService.CreateBudget(c.Request.Context(), &req)
+	if err != nil {
+		respondServiceError(c, err, "Failed to create budget")
+		return
+	}
+
+	c.JSON(http.StatusCreated, budget)
+}
+
+// CreateBudgetFromActuals creates a budget filled with last year's actuals
+func (h *BudgetHandler) CreateBudgetFromActuals(c *gin.Context) {
+	var req dto.CreateBudgetFromActualsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	budget, err := h.budgetService.CreateBudgetFromActuals(c.Request.Context(), &req)
+	if err != nil {
+		respondServiceError(c, err, "Failed to create budget")
+		return
+	}
+
+	c.JSON(http.StatusCreated, budget)
+}
+
+// UpdateBudget updates a budget's name, description or number of periods
+func (h *BudgetHandler) UpdateBudget(c *gin.Context) {
+	var req dto.UpdateBudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	budget, err := h.budgetService.UpdateBudget(c.Request.Context(), c.Param("guid"), &req)
+	if err != nil {
+		respondServiceError(c, err, "Failed to update budget")
+		return
+	}
+
+	c.JSON(http.StatusOK, budget)
+}
+
+// DeleteBudget deletes a budget
+func (h *BudgetHandler) DeleteBudget(c *gin.Context) {
+	if err := h.budgetService.DeleteBudget(c.Request.Context(), c.Param("guid")); err != nil {
+		respondServiceError(c, err, "Failed to delete budget")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetBudgetAmounts retrieves every amount set in a budget
+func (h *BudgetHandler) GetBudgetAmounts(c *gin.Context) {
+	amounts, err := h.budgetService.GetAmounts(c.Request.Context(), c.Param("guid"))
+	if err != nil {
+		respondServiceError(c, err, "Failed to retrieve budget amounts")
+		return
+	}
+
+	c.JSON(http.StatusOK, amounts)
+}
+
+// SetBudgetAmounts sets or clears individual budget amounts
+func (h *BudgetHandler) SetBudgetAmounts(c *gin.Context) {
+	var req dto.SetBudgetAmountsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	amounts, err := h.budgetService.SetAmounts(c.Request.Context(), c.Param("guid"), &req)
+	if err != nil {
+		respondServiceError(c, err, "Failed to update budget amounts")
+		return
+	}
+
+	c.JSON(http.StatusOK, amounts)
+}
+
+// FillBudgetAmounts sets one amount for an account across a range of periods
+func (h *BudgetHandler) FillBudgetAmounts(c *gin.Context) {
+	var req dto.FillBudgetAmountsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	amounts, err := h.budgetService.FillAmounts(c.Request.Context(), c.Param("guid"), &req)
+	if err != nil {
+		respondServiceError(c, err, "Failed to update budget amounts")
+		return
+	}
+
+	c.JSON(http.StatusOK, amounts)
+}
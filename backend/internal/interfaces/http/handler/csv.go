@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"encoding/csv"
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/pkg/logger"
+)
+
+// wantsCSV reports whether the request asks for format=csv
+func wantsCSV(c *gin.Context) bool {
+	return c.Query("format") == "csv"
+}
+
+// csvNumber matches a plain signed amount, which spreadsheets read as a
+// number rather than a formula
+var csvNumber = regexp.MustCompile(`^[-+]?[0-9]+(\.[0-9]+)?$`)
+
+// respondCSV writes rows as a CSV attachment with the given file name. Cells
+// a spreadsheet would run as a formula are neutralised.
+func respondCSV(c *gin.Context, filename string, rows [][]string) {
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Status(http.StatusOK)
+
+	for _, row := range rows {
+		for i, cell := range row {
+			row[i] = csvSafeCell(cell)
+		}
+	}
+
+	w := csv.NewWriter(c.Writer)
+	if err := w.WriteAll(rows); err != nil {
+		logger.Error("Failed to write CSV", "error", err, "path", c.FullPath())
+	}
+}
+
+// csvSafeCell prefixes a cell starting with =, +, -, @, tab or carriage
+// return with a quote so spreadsheets show it as text. Amounts are kept.
+func csvSafeCell(cell string) string {
+	if cell == "" || csvNumber.MatchString(cell) {
+		return cell
+	}
+	switch cell[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + cell
+	}
+	return cell
+}
+
+// incomeStatementCSV lays out an income statement with one row per account,
+// named by its full path, and a total row after each parent's children
+func incomeStatementCSV(report *dto.IncomeStatementResponse) [][]string {
+	header := []string{"Account"}
+	for _, p := range report.Periods {
+		header = append(header, p.Label)
+	}
+	header = append(header, "Total")
+	rows := [][]string{header}
+
+	row := func(label string, amounts []string, total string) {
+		rows = append(rows, append(append([]string{label}, amounts...), total))
+	}
+	var walk func(path string, nodes []dto.IncomeStatementNode)
+	walk = func(path string, nodes []dto.IncomeStatementNode) {
+		for _, node := range nodes {
+			name := node.AccountName
+			if path != "" {
+				name = path + ":" + node.AccountName
+			}
+			row(name, node.Amounts, node.Total)
+			if len(node.Children) > 0 {
+				walk(name, node.Children)
+				row("Total "+name, node.Subtotals, node.SubtotalTotal)
+			}
+		}
+	}
+
+	walk("", report.Income.Accounts)
+	row("Total Income", report.Income.Totals, report.Income.Total)
+	walk("", report.Expense.Accounts)
+	row("Total Expense", report.Expense.Totals, report.Expense.Total)
+	row("Net Income", report.NetIncome, report.TotalNetIncome)
+
+	return rows
+}
+
+// trialBalanceCSV lays out a trial balance with one row per account and
+// currency followed by each currency's totals
+func trialBalanceCSV(report *dto.TrialBalanceResponse) [][]string {
+	rows := [][]string{{"Account", "Type", "Currency", "Debit", "Credit", "Balance Debit", "Balance Credit"}}
+	for _, row := range report.Rows {
+		rows = append(rows, []string{row.AccountName, row.AccountType, row.Currency, row.Debit, row.Credit, row.BalanceDebit, row.BalanceCredit})
+	}
+	for _, total := range report.Totals {
+		rows = append(rows, []string{"Total", "", total.Currency, total.Debit, total.Credit, total.BalanceDebit, total.BalanceCredit})
+	}
+	return rows
+}
+
+// generalJournalCSV lays out a journal with one row per split, repeating the
+// transaction's date, number and description on each
+func generalJournalCSV(report *dto.GeneralJournalResponse) [][]string {
+	rows := [][]string{{"Date", "Num", "Description", "Transaction", "Account", "Memo", "Currency", "Debit", "Credit", "Quantity", "Reconciled"}}
+	for _, entry := range report.Entries {
+		for _, split := range entry.Splits {
+			rows = append(rows, []string{
+				entry.PostDate, entry.Num, entry.Description, entry.TransactionGUID,
+				split.AccountName, split.Memo, entry.Currency, split.Debit, split.Credit, split.Quantity, split.ReconcileState,
+			})
+		}
+	}
+	return rows
+}
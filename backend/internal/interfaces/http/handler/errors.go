@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/application/service"
+	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
+	"github.com/udai-kiran/agentic-cash/pkg/logger"
+)
+
+// respondServiceError maps service and repository errors to HTTP error responses.
+// Unexpected errors are logged and reported with the given fallback message.
+func respondServiceError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrValidation):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+	case errors.Is(err, repository.ErrNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   "Not Found",
+			Message: err.Error(),
+			Code:    http.StatusNotFound,
+		})
+	case errors.Is(err, repository.ErrConflict), errors.Is(err, service.ErrReconciled):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   "Conflict",
+			Message: err.Error(),
+			Code:    http.StatusConflict,
+		})
+	default:
+		logger.Error(fallback, "error", err, "path", c.FullPath())
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "Internal Server Error",
+			Message: fallback,
+			Code:    http.StatusInternalServerError,
+		})
+	}
+}
+
+// versionFromIfMatch extracts the entity tag from the If-Match request header
+func versionFromIfMatch(c *gin.Context) string {
+	tag := strings.TrimSpace(c.GetHeader("If-Match"))
+	tag = strings.TrimPrefix(tag, "W/")
+	return strings.Trim(tag, `"`)
+}
+
+// setETag sets the ETag response header to the given version
+func setETag(c *gin.Context, version string) {
+	c.Header("ETag", `"`+version+`"`)
+}
@@ -0,0 +1,392 @@
+package handler
+
+import (
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/application/service"
+)
+
+// ImportHandler handles statement import HTTP requests
+type ImportHandler struct {
+	importService *service.ImportService
+}
+
+// NewImportHandler creates a new import handler
+func NewImportHandler(importService *service.ImportService) *ImportHandler {
+	return &ImportHandler{
+		importService: importService,
+	}
+}
+
+// PreviewOFX parses an uploaded OFX or QFX file and returns the proposed entries
+func (h *ImportHandler) PreviewOFX(c *gin.Context) {
+	var req dto.ImportPreviewRequest
+	file, ok := bindImportUpload(c, &req)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	preview, err := h.importService.PreviewOFX(c.Request.Context(), &req, file)
+	if err != nil {
+		respondServiceError(c, err, "Failed to preview import")
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// PreviewCAMT053 parses an uploaded ISO 20022 camt.053 statement and returns
+// the proposed entries
+func (h *ImportHandler) PreviewCAMT053(c *gin.Context) {
+	var req dto.ImportPreviewRequest
+	file, ok := bindImportUpload(c, &req)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	preview, err := h.importService.PreviewCAMT053(c.Request.Context(), &req, file)
+	if err != nil {
+		respondServiceError(c, err, "Failed to preview import")
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// PreviewMT940 parses an uploaded SWIFT MT940 statement and returns the
+// proposed entries
+func (h *ImportHandler) PreviewMT940(c *gin.Context) {
+	var req dto.ImportPreviewRequest
+	file, ok := bindImportUpload(c, &req)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	preview, err := h.importService.PreviewMT940(c.Request.Context(), &req, file)
+	if err != nil {
+		respondServiceError(c, err, "Failed to preview import")
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// PreviewCSV reads an uploaded CSV file with a mapping profile and returns
+// the proposed entries and row errors
+func (h *ImportHandler) PreviewCSV(c *gin.Context) {
+	var req dto.CSVPreviewRequest
+	file, ok := bindImportUpload(c, &req)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	preview, err := h.importService.PreviewCSV(c.Request.Context(), &req, file)
+	if err != nil {
+		respondServiceError(c, err, "Failed to preview import")
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// PreviewQIF parses an uploaded QIF file and returns the proposed entries with
+// their categories mapped to accounts
+func (h *ImportHandler) PreviewQIF(c *gin.Context) {
+	var req dto.QIFPreviewRequest
+	file, ok := bindImportUpload(c, &req)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	preview, err := h.importService.PreviewQIF(c.Request.Context(), &req, file)
+	if err != nil {
+		respondServiceError(c, err, "Failed to preview import")
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// Commit creates transactions for the reviewed entries of a preview
+func (h *ImportHandler) Commit(c *gin.Context) {
+	var req dto.ImportCommitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	result, err := h.importService.Commit(c.Request.Context(), &req)
+	if err != nil {
+		respondServiceError(c, err, "Failed to commit import")
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetCSVProfiles lists the CSV mapping profiles
+func (h *ImportHandler) GetCSVProfiles(c *gin.Context) {
+	profiles, err := h.importService.ListCSVProfiles(c.Request.Context())
+	if err != nil {
+		respondServiceError(c, err, "Failed to retrieve CSV profiles")
+		return
+	}
+
+	c.JSON(http.StatusOK, profiles)
+}
+
+// GetCSVProfile returns one CSV mapping profile
+func (h *ImportHandler) GetCSVProfile(c *gin.Context) {
+	id, ok := profileID(c)
+	if !ok {
+		return
+	}
+
+	profile, err := h.importService.GetCSVProfile(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err, "Failed to retrieve CSV profile")
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// CreateCSVProfile creates a CSV mapping profile
+func (h *ImportHandler) CreateCSVProfile(c *gin.Context) {
+	var req dto.CSVProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	profile, err := h.importService.CreateCSVProfile(c.Request.Context(), &req)
+	if err != nil {
+		respondServiceError(c, err, "Failed to create CSV profile")
+		return
+	}
+
+	c.JSON(http.StatusCreated, profile)
+}
+
+// UpdateCSVProfile replaces a CSV mapping profile
+func (h *ImportHandler) UpdateCSVProfile(c *gin.Context) {
+	id, ok := profileID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CSVProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	profile, err := h.importService.UpdateCSVProfile(c.Request.Context(), id, &req)
+	if err != nil {
+		respondServiceError(c, err, "Failed to update CSV profile")
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// DeleteCSVProfile removes a CSV mapping profile
+func (h *ImportHandler) DeleteCSVProfile(c *gin.Context) {
+	id, ok := profileID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.importService.DeleteCSVProfile(c.Request.Context(), id); err != nil {
+		respondServiceError(c, err, "Failed to delete CSV profile")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetRules lists the import rules in the order they apply
+func (h *ImportHandler) GetRules(c *gin.Context) {
+	rules, err := h.importService.ListRules(c.Request.Context())
+	if err != nil {
+		respondServiceError(c, err, "Failed to retrieve import rules")
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+// GetRule returns one import rule
+func (h *ImportHandler) GetRule(c *gin.Context) {
+	id, ok := ruleID(c)
+	if !ok {
+		return
+	}
+
+	rule, err := h.importService.GetRule(c.Request.Context(), id)
+	if err != nil {
+		respondServiceError(c, err, "Failed to retrieve import rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// CreateRule creates an import rule
+func (h *ImportHandler) CreateRule(c *gin.Context) {
+	var req dto.ImportRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	rule, err := h.importService.CreateRule(c.Request.Context(), &req)
+	if err != nil {
+		respondServiceError(c, err, "Failed to create import rule")
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// UpdateRule replaces an import rule
+func (h *ImportHandler) UpdateRule(c *gin.Context) {
+	id, ok := ruleID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ImportRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	rule, err := h.importService.UpdateRule(c.Request.Context(), id, &req)
+	if err != nil {
+		respondServiceError(c, err, "Failed to update import rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// DeleteRule removes an import rule
+func (h *ImportHandler) DeleteRule(c *gin.Context) {
+	id, ok := ruleID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.importService.DeleteRule(c.Request.Context(), id); err != nil {
+		respondServiceError(c, err, "Failed to delete import rule")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SuggestRules proposes import rules learned from past transactions
+func (h *ImportHandler) SuggestRules(c *gin.Context) {
+	var req dto.RuleSuggestionRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	suggestions, err := h.importService.SuggestRules(c.Request.Context(), &req)
+	if err != nil {
+		respondServiceError(c, err, "Failed to suggest import rules")
+		return
+	}
+
+	c.JSON(http.StatusOK, suggestions)
+}
+
+// profileID parses the :id path parameter, writing a 400 response if it is invalid
+func profileID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: "invalid profile id",
+			Code:    http.StatusBadRequest,
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+// ruleID parses the :id path parameter, writing a 400 response if it is invalid
+func ruleID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id < 1 {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: "invalid rule id",
+			Code:    http.StatusBadRequest,
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+// bindImportUpload binds the form fields of a multipart import request and
+// opens its "file" part. It writes the error response and reports false when
+// the request is invalid. Uploads are bounded by the router's request size
+// limit.
+func bindImportUpload(c *gin.Context, req any) (io.ReadCloser, bool) {
+	var header *multipart.FileHeader
+	err := c.ShouldBind(req)
+	if err == nil {
+		header, err = c.FormFile("file")
+	}
+	var file multipart.File
+	if err == nil {
+		file, err = header.Open()
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return nil, false
+	}
+
+	return file, true
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/application/service"
+	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
+	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
+)
+
+// PriceHandler handles price database HTTP requests
+type PriceHandler struct {
+	priceService *service.PriceService
+}
+
+// NewPriceHandler creates a new price handler
+func NewPriceHandler(priceService *service.PriceService) *PriceHandler {
+	return &PriceHandler{
+		priceService: priceService,
+	}
+}
+
+// GetPrices returns the price history of a commodity
+func (h *PriceHandler) GetPrices(c *gin.Context) {
+	var startDate, endDate *time.Time
+
+	if startDateStr := c.Query("start_date"); startDateStr != "" {
+		t, err := time.Parse("2006-01-02", startDateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Bad Request",
+				Message: "Invalid start_date format. Use YYYY-MM-DD",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		startDate = &t
+	}
+
+	if endDateStr := c.Query("end_date"); endDateStr != "" {
+		t, err := time.Parse("2006-01-02", endDateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Bad Request",
+				Message: "Invalid end_date format. Use YYYY-MM-DD",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		endDate = &t
+	}
+
+	prices, err := h.priceService.GetHistory(c.Request.Context(), c.Query("commodity"), c.Query("currency"), startDate, endDate)
+	if err != nil {
+		respondServiceError(c, err, "Failed to retrieve prices")
+		return
+	}
+
+	response := dto.PriceListResponse{
+		Prices: make([]dto.PriceResponse, len(prices)),
+		Count:  len(prices),
+	}
+	for i, price := range prices {
+		response.Prices[i] = toPriceResponse(price)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetLatestPrice returns the most recent price of a commodity
+func (h *PriceHandler) GetLatestPrice(c *gin.Context) {
+	price, err := h.priceService.GetLatest(c.Request.Context(), c.Query("commodity"), c.Query("currency"))
+	if err != nil {
+		respondServiceError(c, err, "Failed to retrieve price")
+		return
+	}
+
+	c.JSON(http.StatusOK, toPriceResponse(price))
+}
+
+// GetPriceAsOf returns the most recent price of a commodity on or before a date
+func (h *PriceHandler) GetPriceAsOf(c *gin.Context) {
+	price, err := h.priceService.GetAsOf(c.Request.Context(), c.Query("commodity"), c.Query("currency"), c.Query("date"))
+	if err != nil {
+		respondServiceError(c, err, "Failed to retrieve price")
+		return
+	}
+
+	c.JSON(http.StatusOK, toPriceResponse(price))
+}
+
+// CreatePrice adds a price to the price database
+func (h *PriceHandler) CreatePrice(c *gin.Context) {
+	var req dto.CreatePriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	price, err := h.priceService.CreatePrice(c.Request.Context(), &req)
+	if err != nil {
+		respondServiceError(c, err, "Failed to create price")
+		return
+	}
+
+	c.JSON(http.StatusCreated, toPriceResponse(price))
+}
+
+// toPriceResponse converts an entity.Price to dto.PriceResponse
+func toPriceResponse(price *entity.Price) dto.PriceResponse {
+	return dto.PriceResponse{
+		GUID:          price.GUID,
+		CommodityGUID: price.CommodityGUID,
+		Commodity:     price.CommodityMnemonic,
+		CurrencyGUID:  price.CurrencyGUID,
+		Currency:      price.CurrencyMnemonic,
+		Date:          price.Date,
+		Source:        price.Source,
+		Type:          price.Type,
+		Value:         gnucash.RationalToDecimal(price.ValueNum, price.ValueDenom).String(),
+		ValueNum:      price.ValueNum,
+		ValueDenom:    price.ValueDenom,
+	}
+}
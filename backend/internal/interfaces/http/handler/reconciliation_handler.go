@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/application/service"
+)
+
+// ReconciliationHandler handles account reconciliation HTTP requests
+type ReconciliationHandler struct {
+	reconciliationService *service.ReconciliationService
+}
+
+// NewReconciliationHandler creates a new reconciliation handler
+func NewReconciliationHandler(reconciliationService *service.ReconciliationService) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		reconciliationService: reconciliationService,
+	}
+}
+
+// GetReconcileInfo returns an account's last reconcile date and balance
+func (h *ReconciliationHandler) GetReconcileInfo(c *gin.Context) {
+	info, err := h.reconciliationService.GetReconcileInfo(c.Request.Context(), c.Param("guid"))
+	if err != nil {
+		respondServiceError(c, err, "Failed to retrieve reconcile info")
+		return
+	}
+
+	c.JSON(http.StatusOK, info)
+}
+
+// StartReconciliation begins reconciling an account against a statement
+func (h *ReconciliationHandler) StartReconciliation(c *gin.Context) {
+	var req dto.StartReconciliationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	session, err := h.reconciliationService.StartReconciliation(c.Request.Context(), c.Param("guid"), &req)
+	if err != nil {
+		respondServiceError(c, err, "Failed to start reconciliation")
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// GetSession returns the reconciliation in progress
+func (h *ReconciliationHandler) GetSession(c *gin.Context) {
+	session, err := h.reconciliationService.GetSession(c.Request.Context(), c.Param("guid"))
+	if err != nil {
+		respondServiceError(c, err, "Failed to retrieve reconciliation")
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// ToggleSplits marks splits as cleared or new
+func (h *ReconciliationHandler) ToggleSplits(c *gin.Context) {
+	var req dto.ToggleReconcileSplitsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	session, err := h.reconciliationService.ToggleSplits(c.Request.Context(), c.Param("guid"), &req)
+	if err != nil {
+		respondServiceError(c, err, "Failed to update splits")
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// FinishReconciliation marks the cleared splits as reconciled
+func (h *ReconciliationHandler) FinishReconciliation(c *gin.Context) {
+	info, err := h.reconciliationService.FinishReconciliation(c.Request.Context(), c.Param("guid"))
+	if err != nil {
+		respondServiceError(c, err, "Failed to finish reconciliation")
+		return
+	}
+
+	c.JSON(http.StatusOK, info)
+}
+
+// CancelReconciliation drops the reconciliation in progress
+func (h *ReconciliationHandler) CancelReconciliation(c *gin.Context) {
+	if err := h.reconciliationService.CancelReconciliation(c.Request.Context(), c.Param("guid")); err != nil {
+		respondServiceError(c, err, "Failed to cancel reconciliation")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
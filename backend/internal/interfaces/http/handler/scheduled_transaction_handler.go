@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/application/service"
+)
+
+// defaultUpcomingDays is the look-ahead used when neither until nor days is given
+const defaultUpcomingDays = 30
+
+// ScheduledTransactionHandler handles scheduled transaction HTTP requests
+type ScheduledTransactionHandler struct {
+	sxService *service.ScheduledTransactionService
+}
+
+// NewScheduledTransactionHandler creates a new scheduled transaction handler
+func NewScheduledTransactionHandler(sxService *service.ScheduledTransactionService) *ScheduledTransactionHandler {
+	return &ScheduledTransactionHandler{
+		sxService: sxService,
+	}
+}
+
+// GetScheduledTransactions retrieves all scheduled transactions
+func (h *ScheduledTransactionHandler) GetScheduledTransactions(c *gin.Context) {
+	sxs, err := h.sxService.ListScheduledTransactions(c.Request.Context())
+	if err != nil {
+		respondServiceError(c, err, "Failed to retrieve scheduled transactions")
+		return
+	}
+
+	c.JSON(http.StatusOK, sxs)
+}
+
+// GetScheduledTransaction retrieves a specific scheduled transaction by GUID
+func (h *ScheduledTransactionHandler) GetScheduledTransaction(c *gin.Context) {
+	sx, err := h.sxService.GetScheduledTransaction(c.Request.Context(), c.Param("guid"))
+	if err != nil {
+		respondServiceError(c, err, "Failed to retrieve scheduled transaction")
+		return
+	}
+
+	c.JSON(http.StatusOK, sx)
+}
+
+// GetUpcoming lists upcoming occurrences of all scheduled transactions, or of
+// the one named by the guid path parameter
+func (h *ScheduledTransactionHandler) GetUpcoming(c *gin.Context) {
+	until := time.Now().AddDate(0, 0, defaultUpcomingDays)
+	if untilStr := c.Query("until"); untilStr != "" {
+		t, err := time.Parse("2006-01-02", untilStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Bad Request",
+				Message: "Invalid until format. Use YYYY-MM-DD",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		until = t
+	} else if daysStr := c.Query("days"); daysStr != "" {
+		days, err := strconv.Atoi(daysStr)
+		if err != nil || days < 0 {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Bad Request",
+				Message: "Invalid days",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		until = time.Now().AddDate(0, 0, days)
+	}
+
+	upcoming, err := h.sxService.GetUpcoming(c.Request.Context(), c.Param("guid"), until)
+	if err != nil {
+		respondServiceError(c, err, "Failed to calculate upcoming occurrences")
+		return
+	}
+
+	c.JSON(http.StatusOK, upcoming)
+}
+
+// PostDue posts the due occurrences of all scheduled transactions, or of the
+// one named by the guid path parameter
+func (h *ScheduledTransactionHandler) PostDue(c *gin.Context) {
+	var req dto.PostDueScheduledRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Bad Request",
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+	}
+
+	asOf := time.Now()
+	if req.AsOf != "" {
+		t, err := time.Parse("2006-01-02", req.AsOf)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Bad Request",
+				Message: "Invalid as_of format. Use YYYY-MM-DD",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		asOf = t
+	}
+
+	result, err := h.sxService.PostDue(c.Request.Context(), c.Param("guid"), asOf, false)
+	if err != nil {
+		respondServiceError(c, err, "Failed to post scheduled transactions")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
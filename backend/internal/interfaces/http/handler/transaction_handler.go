@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
 	"github.com/udai-kiran/agentic-cash/internal/application/dto"
+	"github.com/udai-kiran/agentic-cash/internal/application/service"
 	"github.com/udai-kiran/agentic-cash/internal/domain/entity"
 	"github.com/udai-kiran/agentic-cash/internal/domain/repository"
 	"github.com/udai-kiran/agentic-cash/pkg/gnucash"
@@ -14,13 +16,15 @@ import (
 
 // TransactionHandler handles transaction-related HTTP requests
 type TransactionHandler struct {
-	transactionRepo repository.TransactionRepository
+	transactionRepo    repository.TransactionRepository
+	transactionService *service.TransactionService
 }
 
 // NewTransactionHandler creates a new transaction handler
-func NewTransactionHandler(transactionRepo repository.TransactionRepository) *TransactionHandler {
+func NewTransactionHandler(transactionRepo repository.TransactionRepository, transactionService *service.TransactionService) *TransactionHandler {
 	return &TransactionHandler{
-		transactionRepo: transactionRepo,
+		transactionRepo:    transactionRepo,
+		transactionService: transactionService,
 	}
 }
 
@@ -54,6 +58,18 @@ func (h *TransactionHandler) GetTransactions(c *gin.Context) {
 		filter.Description = &description
 	}
 
+	if minAmountStr := c.Query("min_amount"); minAmountStr != "" {
+		if minAmount, err := decimal.NewFromString(minAmountStr); err == nil {
+			filter.MinAmount = &minAmount
+		}
+	}
+
+	if maxAmountStr := c.Query("max_amount"); maxAmountStr != "" {
+		if maxAmount, err := decimal.NewFromString(maxAmountStr); err == nil {
+			filter.MaxAmount = &maxAmount
+		}
+	}
+
 	if limitStr := c.Query("limit"); limitStr != "" {
 		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
 			filter.Limit = limit
@@ -117,9 +133,144 @@ func (h *TransactionHandler) GetTransaction(c *gin.Context) {
 		return
 	}
 
+	setETag(c, transaction.Version())
+	c.JSON(http.StatusOK, h.toTransactionResponse(transaction))
+}
+
+// CreateTransaction creates a new balanced transaction
+func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
+	var req dto.CreateTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	transaction, err := h.transactionService.CreateTransaction(c.Request.Context(), &req)
+	if err != nil {
+		respondServiceError(c, err, "Failed to create transaction")
+		return
+	}
+
+	setETag(c, transaction.Version())
+	c.JSON(http.StatusCreated, h.toTransactionResponse(transaction))
+}
+
+// UpdateTransaction replaces a transaction and its splits. The client must send
+// the version it last read, either in If-Match or in the request body. Removing
+// or changing reconciled splits requires force=true.
+func (h *TransactionHandler) UpdateTransaction(c *gin.Context) {
+	var req dto.UpdateTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	force := c.Query("force") == "true"
+
+	transaction, err := h.transactionService.UpdateTransaction(c.Request.Context(), c.Param("guid"), &req, versionFromIfMatch(c), force)
+	if err != nil {
+		respondServiceError(c, err, "Failed to update transaction")
+		return
+	}
+
+	setETag(c, transaction.Version())
+	c.JSON(http.StatusOK, h.toTransactionResponse(transaction))
+}
+
+// DeleteTransaction deletes the transaction whose version is given in If-Match.
+// Reconciled transactions require force=true.
+func (h *TransactionHandler) DeleteTransaction(c *gin.Context) {
+	force := c.Query("force") == "true"
+
+	err := h.transactionService.DeleteTransaction(c.Request.Context(), c.Param("guid"), versionFromIfMatch(c), force)
+	if err != nil {
+		respondServiceError(c, err, "Failed to delete transaction")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// VoidTransaction voids a transaction, recording the reason
+func (h *TransactionHandler) VoidTransaction(c *gin.Context) {
+	var req dto.VoidTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	version := versionFromIfMatch(c)
+	if version == "" {
+		version = req.Version
+	}
+
+	transaction, err := h.transactionService.VoidTransaction(c.Request.Context(), c.Param("guid"), req.Reason, version)
+	if err != nil {
+		respondServiceError(c, err, "Failed to void transaction")
+		return
+	}
+
+	setETag(c, transaction.Version())
 	c.JSON(http.StatusOK, h.toTransactionResponse(transaction))
 }
 
+// ReverseTransaction creates a reversing transaction for an existing one
+func (h *TransactionHandler) ReverseTransaction(c *gin.Context) {
+	var req dto.ReverseTransactionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "Bad Request",
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+	}
+
+	transaction, err := h.transactionService.ReverseTransaction(c.Request.Context(), c.Param("guid"), req.PostDate)
+	if err != nil {
+		respondServiceError(c, err, "Failed to reverse transaction")
+		return
+	}
+
+	setETag(c, transaction.Version())
+	c.JSON(http.StatusCreated, h.toTransactionResponse(transaction))
+}
+
+// BulkRecategorize moves the matching splits of one account to another
+func (h *TransactionHandler) BulkRecategorize(c *gin.Context) {
+	var req dto.BulkRecategorizeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "Bad Request",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	response, err := h.transactionService.BulkRecategorize(c.Request.Context(), &req)
+	if err != nil {
+		respondServiceError(c, err, "Failed to re-categorize transactions")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // toTransactionResponse converts entity.Transaction to dto.TransactionResponse
 func (h *TransactionHandler) toTransactionResponse(tx *entity.Transaction) dto.TransactionResponse {
 	splits := make([]dto.SplitResponse, len(tx.Splits))
@@ -128,6 +279,7 @@ func (h *TransactionHandler) toTransactionResponse(tx *entity.Transaction) dto.T
 			GUID:           split.GUID,
 			TxGUID:         split.TxGUID,
 			AccountGUID:    split.AccountGUID,
+			LotGUID:        split.LotGUID,
 			Memo:           split.Memo,
 			Action:         split.Action,
 			ReconcileState: split.ReconcileState,
@@ -157,5 +309,10 @@ func (h *TransactionHandler) toTransactionResponse(tx *entity.Transaction) dto.T
 		EnterDate:        tx.EnterDate,
 		Description:      tx.Description,
 		Splits:           splits,
+		Version:          tx.Version(),
+		Voided:           tx.Voided,
+		VoidReason:       tx.VoidReason,
+		ReversedBy:       tx.ReversedByGUID,
+		ReversalOf:       tx.ReversalOfGUID,
 	}
 }
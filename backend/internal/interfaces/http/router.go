@@ -11,13 +11,18 @@ import (
 
 // RouterConfig holds dependencies for router setup
 type RouterConfig struct {
-	AccountHandler     *handler.AccountHandler
-	AuthHandler        *handler.AuthHandler
-	TransactionHandler *handler.TransactionHandler
-	AnalyticsHandler   *handler.AnalyticsHandler
-	CommodityHandler   *handler.CommodityHandler
-	JWTManager         *auth.JWTManager
-	AllowedOrigins     []string
+	AccountHandler              *handler.AccountHandler
+	AuthHandler                 *handler.AuthHandler
+	TransactionHandler          *handler.TransactionHandler
+	AnalyticsHandler            *handler.AnalyticsHandler
+	CommodityHandler            *handler.CommodityHandler
+	PriceHandler                *handler.PriceHandler
+	BudgetHandler               *handler.BudgetHandler
+	ScheduledTransactionHandler *handler.ScheduledTransactionHandler
+	ReconciliationHandler       *handler.ReconciliationHandler
+	ImportHandler               *handler.ImportHandler
+	JWTManager                  *auth.JWTManager
+	AllowedOrigins              []string
 }
 
 // Router sets up the HTTP router
@@ -54,42 +59,142 @@ func Router(cfg *RouterConfig) *gin.Engine {
 			auth.POST("/logout", cfg.AuthHandler.Logout)
 		}
 
-		// Account routes (public for demo, can be protected with middleware)
+		// Account routes (reads are public)
 		accounts := v1.Group("/accounts")
 		{
 			accounts.GET("", cfg.AccountHandler.GetAccounts)
 			accounts.GET("/hierarchy", cfg.AccountHandler.GetAccountHierarchy)
 			accounts.GET("/:guid", cfg.AccountHandler.GetAccount)
 			accounts.GET("/:guid/balance", cfg.AccountHandler.GetAccountBalance)
+			accounts.GET("/:guid/lots", cfg.AccountHandler.GetAccountLots)
+			accounts.GET("/:guid/register", cfg.AccountHandler.GetAccountRegister)
+			accounts.GET("/:guid/reconciliation", cfg.ReconciliationHandler.GetReconcileInfo)
+			accounts.GET("/:guid/reconciliation/session", cfg.ReconciliationHandler.GetSession)
 		}
 
-		// Transaction routes (public for demo, can be protected with middleware)
+		// Transaction routes (reads are public)
 		transactions := v1.Group("/transactions")
 		{
 			transactions.GET("", cfg.TransactionHandler.GetTransactions)
 			transactions.GET("/:guid", cfg.TransactionHandler.GetTransaction)
 		}
 
-		// Commodity routes (public for demo, can be protected with middleware)
+		// Commodity routes (reads are public)
 		commodities := v1.Group("/commodities")
 		{
 			commodities.GET("/currencies", cfg.CommodityHandler.GetCurrencies)
 		}
 
-		// Analytics routes (public for demo, can be protected with middleware)
+		// Price database routes (reads are public)
+		prices := v1.Group("/prices")
+		{
+			prices.GET("", cfg.PriceHandler.GetPrices)
+			prices.GET("/latest", cfg.PriceHandler.GetLatestPrice)
+			prices.GET("/as-of", cfg.PriceHandler.GetPriceAsOf)
+		}
+
+		// Budget routes (reads are public)
+		budgets := v1.Group("/budgets")
+		{
+			budgets.GET("", cfg.BudgetHandler.GetBudgets)
+			budgets.GET("/:guid", cfg.BudgetHandler.GetBudget)
+			budgets.GET("/:guid/amounts", cfg.BudgetHandler.GetBudgetAmounts)
+			budgets.GET("/:guid/report", cfg.BudgetHandler.GetBudgetReport)
+		}
+
+		// Scheduled transaction routes (reads are public)
+		scheduled := v1.Group("/scheduled-transactions")
+		{
+			scheduled.GET("", cfg.ScheduledTransactionHandler.GetScheduledTransactions)
+			scheduled.GET("/upcoming", cfg.ScheduledTransactionHandler.GetUpcoming)
+			scheduled.GET("/:guid", cfg.ScheduledTransactionHandler.GetScheduledTransaction)
+			scheduled.GET("/:guid/upcoming", cfg.ScheduledTransactionHandler.GetUpcoming)
+		}
+
+		// Statement import routes (reads are public)
+		imports := v1.Group("/imports")
+		{
+			imports.GET("/csv/profiles", cfg.ImportHandler.GetCSVProfiles)
+			imports.GET("/csv/profiles/:id", cfg.ImportHandler.GetCSVProfile)
+			imports.GET("/rules", cfg.ImportHandler.GetRules)
+			imports.GET("/rules/suggestions", cfg.ImportHandler.SuggestRules)
+			imports.GET("/rules/:id", cfg.ImportHandler.GetRule)
+		}
+
+		// Analytics routes (reads are public)
 		analytics := v1.Group("/analytics")
 		{
 			analytics.GET("/income-expense", cfg.AnalyticsHandler.GetIncomeExpense)
 			analytics.GET("/category-breakdown", cfg.AnalyticsHandler.GetCategoryBreakdown)
 			analytics.GET("/net-worth", cfg.AnalyticsHandler.GetNetWorth)
+			analytics.GET("/balance-sheet", cfg.AnalyticsHandler.GetBalanceSheet)
+			analytics.GET("/income-statement", cfg.AnalyticsHandler.GetIncomeStatement)
+			analytics.GET("/cash-flow", cfg.AnalyticsHandler.GetCashFlowStatement)
+			analytics.GET("/trial-balance", cfg.AnalyticsHandler.GetTrialBalance)
+			analytics.GET("/general-journal", cfg.AnalyticsHandler.GetGeneralJournal)
+			analytics.GET("/holdings", cfg.AnalyticsHandler.GetHoldings)
+			analytics.GET("/capital-gains", cfg.AnalyticsHandler.GetCapitalGains)
+			analytics.GET("/forecast", cfg.AnalyticsHandler.GetForecast)
 		}
 
-		// Protected routes example
+		// Routes that change the book require an access token
 		protected := v1.Group("")
 		protected.Use(middleware.AuthMiddleware(cfg.JWTManager))
 		{
-			// Add protected routes here in the future
-			// protected.GET("/profile", profileHandler.GetProfile)
+			accountsWrite := protected.Group("/accounts")
+			{
+				accountsWrite.POST("", cfg.AccountHandler.CreateAccount)
+				accountsWrite.PATCH("/:guid", cfg.AccountHandler.UpdateAccount)
+				accountsWrite.DELETE("/:guid", cfg.AccountHandler.DeleteAccount)
+				accountsWrite.POST("/:guid/move", cfg.AccountHandler.MoveAccount)
+				accountsWrite.POST("/:guid/merge", cfg.AccountHandler.MergeAccount)
+				accountsWrite.POST("/:guid/reconciliation", cfg.ReconciliationHandler.StartReconciliation)
+				accountsWrite.DELETE("/:guid/reconciliation", cfg.ReconciliationHandler.CancelReconciliation)
+				accountsWrite.PATCH("/:guid/reconciliation/splits", cfg.ReconciliationHandler.ToggleSplits)
+				accountsWrite.POST("/:guid/reconciliation/finish", cfg.ReconciliationHandler.FinishReconciliation)
+			}
+			transactionsWrite := protected.Group("/transactions")
+			{
+				transactionsWrite.POST("", cfg.TransactionHandler.CreateTransaction)
+				transactionsWrite.POST("/bulk-recategorize", cfg.TransactionHandler.BulkRecategorize)
+				transactionsWrite.PUT("/:guid", cfg.TransactionHandler.UpdateTransaction)
+				transactionsWrite.DELETE("/:guid", cfg.TransactionHandler.DeleteTransaction)
+				transactionsWrite.POST("/:guid/void", cfg.TransactionHandler.VoidTransaction)
+				transactionsWrite.POST("/:guid/reverse", cfg.TransactionHandler.ReverseTransaction)
+			}
+			pricesWrite := protected.Group("/prices")
+			{
+				pricesWrite.POST("", cfg.PriceHandler.CreatePrice)
+			}
+			budgetsWrite := protected.Group("/budgets")
+			{
+				budgetsWrite.POST("", cfg.BudgetHandler.CreateBudget)
+				budgetsWrite.POST("/from-actuals", cfg.BudgetHandler.CreateBudgetFromActuals)
+				budgetsWrite.PATCH("/:guid", cfg.BudgetHandler.UpdateBudget)
+				budgetsWrite.DELETE("/:guid", cfg.BudgetHandler.DeleteBudget)
+				budgetsWrite.PUT("/:guid/amounts", cfg.BudgetHandler.SetBudgetAmounts)
+				budgetsWrite.POST("/:guid/amounts/fill", cfg.BudgetHandler.FillBudgetAmounts)
+			}
+			scheduledWrite := protected.Group("/scheduled-transactions")
+			{
+				scheduledWrite.POST("/post-due", cfg.ScheduledTransactionHandler.PostDue)
+				scheduledWrite.POST("/:guid/post-due", cfg.ScheduledTransactionHandler.PostDue)
+			}
+			importsWrite := protected.Group("/imports")
+			{
+				importsWrite.POST("/ofx/preview", cfg.ImportHandler.PreviewOFX)
+				importsWrite.POST("/csv/preview", cfg.ImportHandler.PreviewCSV)
+				importsWrite.POST("/qif/preview", cfg.ImportHandler.PreviewQIF)
+				importsWrite.POST("/camt053/preview", cfg.ImportHandler.PreviewCAMT053)
+				importsWrite.POST("/mt940/preview", cfg.ImportHandler.PreviewMT940)
+				importsWrite.POST("/csv/profiles", cfg.ImportHandler.CreateCSVProfile)
+				importsWrite.PUT("/csv/profiles/:id", cfg.ImportHandler.UpdateCSVProfile)
+				importsWrite.DELETE("/csv/profiles/:id", cfg.ImportHandler.DeleteCSVProfile)
+				importsWrite.POST("/rules", cfg.ImportHandler.CreateRule)
+				importsWrite.PUT("/rules/:id", cfg.ImportHandler.UpdateRule)
+				importsWrite.DELETE("/rules/:id", cfg.ImportHandler.DeleteRule)
+				importsWrite.POST("/commit", cfg.ImportHandler.Commit)
+			}
 		}
 	}
 
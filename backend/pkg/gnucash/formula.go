@@ -0,0 +1,154 @@
+package gnucash
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// EvalFormula evaluates a scheduled-transaction amount formula. Numbers,
+// + - * /, unary minus and parentheses are supported. Formulas that use
+// variables or functions are rejected, and so are commas that could be a
+// decimal comma: a comma is only read as a thousands separator when the
+// number also has a decimal point or several groups, as in 1,234.56.
+func EvalFormula(formula string) (decimal.Decimal, error) {
+	p := &formulaParser{input: formula}
+	p.skipSpace()
+	if p.pos == len(p.input) {
+		return decimal.Zero, nil
+	}
+
+	value, err := p.expr()
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if p.pos != len(p.input) {
+		return decimal.Zero, fmt.Errorf("unsupported formula %q: unexpected %q", formula, p.input[p.pos:])
+	}
+	return value, nil
+}
+
+// groupedNumber matches a number with comma thousands separators
+var groupedNumber = regexp.MustCompile(`^[0-9]{1,3}(,[0-9]{3})+(\.[0-9]*)?$`)
+
+// formulaParser is a recursive-descent parser over a formula string
+type formulaParser struct {
+	input string
+	pos   int
+}
+
+func (p *formulaParser) skipSpace() {
+	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *formulaParser) peek() byte {
+	p.skipSpace()
+	if p.pos < len(p.input) {
+		return p.input[p.pos]
+	}
+	return 0
+}
+
+// expr := term (('+' | '-') term)*
+func (p *formulaParser) expr() (decimal.Decimal, error) {
+	left, err := p.term()
+	if err != nil {
+		return decimal.Zero, err
+	}
+	for {
+		op := p.peek()
+		if op != '+' && op != '-' {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.term()
+		if err != nil {
+			return decimal.Zero, err
+		}
+		if op == '+' {
+			left = left.Add(right)
+		} else {
+			left = left.Sub(right)
+		}
+	}
+}
+
+// term := factor (('*' | '/') factor)*
+func (p *formulaParser) term() (decimal.Decimal, error) {
+	left, err := p.factor()
+	if err != nil {
+		return decimal.Zero, err
+	}
+	for {
+		op := p.peek()
+		if op != '*' && op != '/' {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.factor()
+		if err != nil {
+			return decimal.Zero, err
+		}
+		if op == '*' {
+			left = left.Mul(right)
+		} else {
+			if right.IsZero() {
+				return decimal.Zero, fmt.Errorf("division by zero in formula")
+			}
+			left = left.Div(right)
+		}
+	}
+}
+
+// factor := '-' factor | '+' factor | '(' expr ')' | number
+func (p *formulaParser) factor() (decimal.Decimal, error) {
+	switch p.peek() {
+	case '-':
+		p.pos++
+		value, err := p.factor()
+		return value.Neg(), err
+	case '+':
+		p.pos++
+		return p.factor()
+	case '(':
+		p.pos++
+		value, err := p.expr()
+		if err != nil {
+			return decimal.Zero, err
+		}
+		if p.peek() != ')' {
+			return decimal.Zero, fmt.Errorf("unsupported formula: missing closing parenthesis")
+		}
+		p.pos++
+		return value, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.input) && (p.input[p.pos] >= '0' && p.input[p.pos] <= '9' || p.input[p.pos] == '.' || p.input[p.pos] == ',') {
+		p.pos++
+	}
+	if start == p.pos {
+		if p.pos < len(p.input) {
+			return decimal.Zero, fmt.Errorf("unsupported formula: unexpected %q", p.input[p.pos:])
+		}
+		return decimal.Zero, fmt.Errorf("unsupported formula: unexpected end")
+	}
+
+	number := p.input[start:p.pos]
+	if strings.Contains(number, ",") {
+		if !groupedNumber.MatchString(number) || !strings.Contains(number, ".") && strings.Count(number, ",") == 1 {
+			return decimal.Zero, fmt.Errorf("unsupported formula: ambiguous comma in %q", number)
+		}
+		number = strings.ReplaceAll(number, ",", "")
+	}
+
+	value, err := decimal.NewFromString(number)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("unsupported formula: invalid number %q", number)
+	}
+	return value, nil
+}
@@ -0,0 +1,37 @@
+package gnucash
+
+import "testing"
+
+func TestEvalFormula(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "", want: "0"},
+		{in: "12.50", want: "12.5"},
+		{in: " 100 + 20 * 3 ", want: "160"},
+		{in: "-(10 - 4) / 4", want: "-1.5"},
+		{in: "1,234.56", want: "1234.56"},
+		{in: "1,234,567", want: "1234567"},
+		{in: "12,50", wantErr: true},
+		{in: "1,234", wantErr: true},
+		{in: "1,23.45", wantErr: true},
+		{in: "rent*1.02", wantErr: true},
+		{in: "1/0", wantErr: true},
+		{in: "(1+2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := EvalFormula(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("EvalFormula(%q) = %s, want an error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || got.String() != tt.want {
+			t.Errorf("EvalFormula(%q) = %s, %v; want %s", tt.in, got, err, tt.want)
+		}
+	}
+}
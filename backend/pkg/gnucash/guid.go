@@ -0,0 +1,19 @@
+package gnucash
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NewGUID generates a GnuCash-style GUID (32 lowercase hex characters, no dashes)
+func NewGUID() string {
+	return strings.ReplaceAll(uuid.New().String(), "-", "")
+}
+
+// NeutralTime returns the given date at 10:59 UTC, the time GnuCash uses for
+// post dates so that the day stays the same in every timezone
+func NeutralTime(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 10, 59, 0, 0, time.UTC)
+}
@@ -1,6 +1,8 @@
 package gnucash
 
 import (
+	"math/big"
+
 	"github.com/shopspring/decimal"
 )
 
@@ -24,6 +26,20 @@ func FormatAmount(numerator, denominator int64) string {
 	return d.StringFixed(2)
 }
 
+// FormatExact formats a rational amount without rounding. A power-of-ten
+// denominator gives that many decimals; any other denominator gives the
+// decimal expansion of the fraction.
+func FormatExact(numerator, denominator int64) string {
+	places := int32(0)
+	for d := denominator; d > 1 && d%10 == 0; d /= 10 {
+		places++
+	}
+	if denominator > 0 && decimal.New(1, places).IntPart() == denominator {
+		return decimal.New(numerator, -places).StringFixed(places)
+	}
+	return RationalToDecimal(numerator, denominator).String()
+}
+
 // NormalizeSign adjusts the sign for GnuCash's accounting conventions
 // Assets and Expenses are positive when debited
 // Liabilities, Equity, and Income are positive when credited
@@ -33,3 +49,111 @@ func NormalizeSign(value int64, isDebitAccount bool) int64 {
 	}
 	return -value
 }
+
+// DecimalToRationalExact converts a decimal to a numerator over the given denominator.
+// It reports false if the value has more precision than the denominator can represent.
+func DecimalToRationalExact(d decimal.Decimal, denominator int64) (int64, bool) {
+	scaled := d.Mul(decimal.NewFromInt(denominator))
+	if !scaled.IsInteger() {
+		return 0, false
+	}
+	return scaled.IntPart(), true
+}
+
+// RationalSum adds GnuCash amounts exactly. The sum is kept over the least
+// common multiple of the denominators added so far, so amounts that share a
+// denominator keep it. The zero value is an empty sum.
+type RationalSum struct {
+	num   big.Int
+	denom big.Int
+}
+
+// Add adds numerator/denominator to the sum. Zero denominators are ignored.
+func (r *RationalSum) Add(numerator, denominator int64) {
+	if denominator == 0 {
+		return
+	}
+	if r.denom.Sign() == 0 {
+		r.denom.SetInt64(1)
+	}
+
+	d := big.NewInt(denominator)
+	n := big.NewInt(numerator)
+	if d.Sign() < 0 {
+		d.Neg(d)
+		n.Neg(n)
+	}
+
+	var gcd, lcm, scale big.Int
+	gcd.GCD(nil, nil, &r.denom, d)
+	lcm.Mul(&r.denom, d)
+	lcm.Quo(&lcm, &gcd)
+
+	scale.Quo(&lcm, &r.denom)
+	r.num.Mul(&r.num, &scale)
+	scale.Quo(&lcm, d)
+	n.Mul(n, &scale)
+	r.num.Add(&r.num, n)
+	r.denom.Set(&lcm)
+}
+
+// AddSum adds another sum to this one
+func (r *RationalSum) AddSum(other *RationalSum) {
+	if other.denom.Sign() == 0 {
+		return
+	}
+	if r.denom.Sign() == 0 {
+		r.denom.SetInt64(1)
+	}
+
+	var gcd, lcm, scale, n big.Int
+	gcd.GCD(nil, nil, &r.denom, &other.denom)
+	lcm.Mul(&r.denom, &other.denom)
+	lcm.Quo(&lcm, &gcd)
+
+	scale.Quo(&lcm, &r.denom)
+	r.num.Mul(&r.num, &scale)
+	scale.Quo(&lcm, &other.denom)
+	n.Mul(&other.num, &scale)
+	r.num.Add(&r.num, &n)
+	r.denom.Set(&lcm)
+}
+
+// IsZero reports whether the sum is zero
+func (r *RationalSum) IsZero() bool {
+	return r.num.Sign() == 0
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of the sum
+func (r *RationalSum) Sign() int {
+	return r.num.Sign()
+}
+
+// Rational returns the sum as a numerator and denominator, with 0/1 for an
+// empty sum. It reports false if either does not fit in an int64.
+func (r *RationalSum) Rational() (int64, int64, bool) {
+	if r.denom.Sign() == 0 {
+		return 0, 1, true
+	}
+	if !r.num.IsInt64() || !r.denom.IsInt64() {
+		return 0, 0, false
+	}
+	return r.num.Int64(), r.denom.Int64(), true
+}
+
+// String formats the sum exactly, as FormatExact does
+func (r *RationalSum) String() string {
+	num, denom, ok := r.Rational()
+	if !ok {
+		return r.Decimal().String()
+	}
+	return FormatExact(num, denom)
+}
+
+// Decimal returns the sum as a decimal
+func (r *RationalSum) Decimal() decimal.Decimal {
+	if r.denom.Sign() == 0 {
+		return decimal.Zero
+	}
+	return decimal.NewFromBigInt(&r.num, 0).Div(decimal.NewFromBigInt(&r.denom, 0))
+}
@@ -0,0 +1,362 @@
+package importer
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// camt.053 element names are matched without their namespace, so every
+// version of the message (001.02 to 001.13) is read with the same structs
+
+type camtDocument struct {
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStatement struct {
+	ID       string        `xml:"Id"`
+	From     string        `xml:"FrToDt>FrDtTm"`
+	To       string        `xml:"FrToDt>ToDtTm"`
+	Account  camtAccount   `xml:"Acct"`
+	Balances []camtBalance `xml:"Bal"`
+	Entries  []camtEntry   `xml:"Ntry"`
+}
+
+type camtAccount struct {
+	IBAN     string `xml:"Id>IBAN"`
+	Other    string `xml:"Id>Othr>Id"`
+	Currency string `xml:"Ccy"`
+}
+
+type camtAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+type camtBalance struct {
+	Code      string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount    camtAmount `xml:"Amt"`
+	Indicator string     `xml:"CdtDbtInd"`
+	Date      camtDate   `xml:"Dt"`
+}
+
+// camtStatus is the entry status: text in 001.02 to 001.07, a code after
+type camtStatus struct {
+	Text string `xml:",chardata"`
+	Code string `xml:"Cd"`
+}
+
+type camtBankCode struct {
+	Domain      string `xml:"Domn>Cd"`
+	Family      string `xml:"Domn>Fmly>Cd"`
+	SubFamily   string `xml:"Domn>Fmly>SubFmlyCd"`
+	Proprietary string `xml:"Prtry>Cd"`
+}
+
+type camtEntry struct {
+	Reference      string          `xml:"NtryRef"`
+	Amount         camtAmount      `xml:"Amt"`
+	Indicator      string          `xml:"CdtDbtInd"`
+	Status         camtStatus      `xml:"Sts"`
+	BookingDate    camtDate        `xml:"BookgDt"`
+	ValueDate      camtDate        `xml:"ValDt"`
+	ServicerRef    string          `xml:"AcctSvcrRef"`
+	BankCode       camtBankCode    `xml:"BkTxCd"`
+	Details        []camtTxDetails `xml:"NtryDtls>TxDtls"`
+	AdditionalInfo string          `xml:"AddtlNtryInf"`
+}
+
+// camtParty is a related party: the name is directly below it up to 001.07
+// and below Pty after
+type camtParty struct {
+	Name      string `xml:"Nm"`
+	PartyName string `xml:"Pty>Nm"`
+}
+
+func (p camtParty) name() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return p.PartyName
+}
+
+type camtTxDetails struct {
+	ServicerRef     string        `xml:"Refs>AcctSvcrRef"`
+	EndToEndID      string        `xml:"Refs>EndToEndId"`
+	Amount          *camtAmount   `xml:"Amt"`
+	TxAmount        *camtAmount   `xml:"AmtDtls>TxAmt>Amt"`
+	Indicator       string        `xml:"CdtDbtInd"`
+	Debtor          camtParty     `xml:"RltdPties>Dbtr"`
+	DebtorAccount   camtAccount   `xml:"RltdPties>DbtrAcct"`
+	Creditor        camtParty     `xml:"RltdPties>Cdtr"`
+	CreditorAccount camtAccount   `xml:"RltdPties>CdtrAcct"`
+	Unstructured    []string      `xml:"RmtInf>Ustrd"`
+	CreditorRefs    []string      `xml:"RmtInf>Strd>CdtrRefInf>Ref"`
+	AdditionalInfo  string        `xml:"AddtlTxInf"`
+	BankCode        *camtBankCode `xml:"BkTxCd"`
+}
+
+// amount returns the details' own amount, if they carry one
+func (d *camtTxDetails) amount() *camtAmount {
+	if d.Amount != nil {
+		return d.Amount
+	}
+	return d.TxAmount
+}
+
+// ParseCAMT053 reads the statements of an ISO 20022 camt.053 bank-to-customer
+// statement. Only booked entries are read. A batch entry whose transaction
+// details each carry an amount becomes one line per transaction.
+func ParseCAMT053(r io.Reader) ([]*Statement, error) {
+	var doc camtDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid camt.053 XML: %w", err)
+	}
+	if len(doc.Statements) == 0 {
+		return nil, errors.New("no statement found in camt.053 document")
+	}
+
+	statements := make([]*Statement, 0, len(doc.Statements))
+	for _, stmt := range doc.Statements {
+		statement, err := camtToStatement(&stmt)
+		if err != nil {
+			return nil, fmt.Errorf("statement %s: %w", stmt.ID, err)
+		}
+		statements = append(statements, statement)
+	}
+
+	return mergeStatements(statements), nil
+}
+
+// camtToStatement converts one Stmt element
+func camtToStatement(stmt *camtStatement) (*Statement, error) {
+	statement := &Statement{
+		Format:    FormatCAMT053,
+		AccountID: stmt.Account.IBAN,
+		Currency:  stmt.Account.Currency,
+	}
+	if statement.AccountID == "" {
+		statement.AccountID = stmt.Account.Other
+	}
+
+	var err error
+	if statement.StartDate, err = optionalCAMTDate(stmt.From); err != nil {
+		return nil, err
+	}
+	if statement.EndDate, err = optionalCAMTDate(stmt.To); err != nil {
+		return nil, err
+	}
+
+	for _, bal := range stmt.Balances {
+		date, err := bal.Date.parse()
+		if err != nil {
+			return nil, err
+		}
+		if statement.Currency == "" {
+			statement.Currency = bal.Amount.Currency
+		}
+		switch bal.Code {
+		case "OPBD":
+			if statement.StartDate == nil && date != nil {
+				statement.StartDate = date
+			}
+		case "CLBD":
+			amount, err := camtSignedAmount(bal.Amount.Value, bal.Indicator)
+			if err != nil {
+				return nil, err
+			}
+			statement.LedgerBalance = &amount
+			statement.BalanceDate = date
+			if statement.EndDate == nil {
+				statement.EndDate = date
+			}
+		}
+	}
+
+	for _, entry := range stmt.Entries {
+		status := strings.TrimSpace(entry.Status.Code)
+		if status == "" {
+			status = strings.TrimSpace(entry.Status.Text)
+		}
+		if status != "" && status != "BOOK" {
+			continue
+		}
+
+		lines, err := camtEntryLines(&entry)
+		if err != nil {
+			return nil, fmt.Errorf("entry %s: %w", entry.Reference, err)
+		}
+		statement.Transactions = append(statement.Transactions, lines...)
+	}
+
+	return statement, nil
+}
+
+// camtEntryLines converts an entry into one line, or one line per
+// transaction of a batch
+func camtEntryLines(entry *camtEntry) ([]*Transaction, error) {
+	booked, err := entry.BookingDate.parse()
+	if err != nil {
+		return nil, err
+	}
+	value, err := entry.ValueDate.parse()
+	if err != nil {
+		return nil, err
+	}
+	if booked == nil {
+		booked = value
+	}
+	if booked == nil {
+		return nil, errors.New("entry has no booking date")
+	}
+
+	id := entry.ServicerRef
+	if id == "" {
+		id = entry.Reference
+	}
+
+	split := len(entry.Details) > 1
+	for i := range entry.Details {
+		if entry.Details[i].amount() == nil {
+			split = false
+		}
+	}
+
+	if !split {
+		amount, err := camtSignedAmount(entry.Amount.Value, entry.Indicator)
+		if err != nil {
+			return nil, err
+		}
+		tx := &Transaction{
+			ID:        id,
+			Type:      entry.BankCode.String(),
+			Date:      *booked,
+			ValueDate: value,
+			Amount:    amount,
+			Currency:  entry.Amount.Currency,
+			Memo:      entry.AdditionalInfo,
+		}
+		if len(entry.Details) == 1 {
+			camtApplyDetails(tx, &entry.Details[0])
+			if tx.ID == "" {
+				tx.ID = entry.Details[0].ServicerRef
+			}
+		}
+		return []*Transaction{tx}, nil
+	}
+
+	lines := make([]*Transaction, 0, len(entry.Details))
+	for i := range entry.Details {
+		details := &entry.Details[i]
+		indicator := details.Indicator
+		if indicator == "" {
+			indicator = entry.Indicator
+		}
+		amount, err := camtSignedAmount(details.amount().Value, indicator)
+		if err != nil {
+			return nil, err
+		}
+
+		tx := &Transaction{
+			ID:        details.ServicerRef,
+			Type:      entry.BankCode.String(),
+			Date:      *booked,
+			ValueDate: value,
+			Amount:    amount,
+			Currency:  details.amount().Currency,
+		}
+		if tx.ID == "" && id != "" {
+			tx.ID = id + "/" + strconv.Itoa(i+1)
+		}
+		if details.BankCode != nil {
+			tx.Type = details.BankCode.String()
+		}
+		camtApplyDetails(tx, details)
+		lines = append(lines, tx)
+	}
+	return lines, nil
+}
+
+// camtApplyDetails fills the counterparty and remittance information. The
+// counterparty is the debtor of money in and the creditor of money out.
+func camtApplyDetails(tx *Transaction, details *camtTxDetails) {
+	party, account := details.Creditor, details.CreditorAccount
+	if tx.Amount.IsPositive() {
+		party, account = details.Debtor, details.DebtorAccount
+	}
+	tx.Payee = strings.TrimSpace(party.name())
+	tx.CounterpartyIBAN = NormalizeIBAN(account.IBAN)
+
+	remittance := make([]string, 0, len(details.Unstructured))
+	for _, text := range details.Unstructured {
+		if text = strings.TrimSpace(text); text != "" {
+			remittance = append(remittance, text)
+		}
+	}
+	if len(remittance) == 0 {
+		remittance = details.CreditorRefs
+	}
+	if len(remittance) > 0 {
+		tx.Memo = strings.Join(remittance, " ")
+	} else if details.AdditionalInfo != "" {
+		tx.Memo = details.AdditionalInfo
+	}
+}
+
+// String returns the ISO domain/family/sub-family code, or the bank's own code
+func (c camtBankCode) String() string {
+	if c.Domain != "" {
+		return strings.Join([]string{c.Domain, c.Family, c.SubFamily}, "/")
+	}
+	return c.Proprietary
+}
+
+// parse returns the date of a Dt or DtTm element, or nil when it is empty
+func (d camtDate) parse() (*time.Time, error) {
+	if d.Date != "" {
+		return optionalCAMTDate(d.Date)
+	}
+	return optionalCAMTDate(d.DateTime)
+}
+
+// optionalCAMTDate reads the date part of an ISO date or date-time
+func optionalCAMTDate(s string) (*time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	if len(s) > 10 {
+		s = s[:10]
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q", s)
+	}
+	return &t, nil
+}
+
+// camtSignedAmount signs an amount by its credit/debit indicator
+func camtSignedAmount(value, indicator string) (decimal.Decimal, error) {
+	amount, err := parseAmount(value)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	switch strings.TrimSpace(indicator) {
+	case "CRDT":
+		return amount.Abs(), nil
+	case "DBIT":
+		return amount.Abs().Neg(), nil
+	}
+	return decimal.Zero, fmt.Errorf("invalid credit/debit indicator %q", indicator)
+}
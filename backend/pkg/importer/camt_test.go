@@ -0,0 +1,254 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+)
+
+const camtDocumentV8 = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Id>S-1</Id>
+      <Acct><Id><IBAN>DE89370400440532013000</IBAN></Id><Ccy>EUR</Ccy></Acct>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>OPBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="EUR">100.00</Amt><CdtDbtInd>CRDT</CdtDbtInd>
+        <Dt><Dt>2024-03-01</Dt></Dt>
+      </Bal>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="EUR">25.00</Amt><CdtDbtInd>DBIT</CdtDbtInd>
+        <Dt><Dt>2024-03-01</Dt></Dt>
+      </Bal>
+      <Ntry>
+        <NtryRef>E1</NtryRef>
+        <Amt Ccy="EUR">80.00</Amt><CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><Dt>2024-03-01</Dt></BookgDt>
+        <ValDt><Dt>2024-03-02</Dt></ValDt>
+        <AcctSvcrRef>REF-1</AcctSvcrRef>
+        <BkTxCd><Domn><Cd>PMNT</Cd><Fmly><Cd>ICDT</Cd><SubFmlyCd>ESCT</SubFmlyCd></Fmly></Domn></BkTxCd>
+        <NtryDtls><TxDtls>
+          <RltdPties>
+            <Dbtr><Pty><Nm>Me</Nm></Pty></Dbtr>
+            <Cdtr><Pty><Nm> Landlord </Nm></Pty></Cdtr>
+            <CdtrAcct><Id><IBAN>GB82 WEST 1234 5698 7654 32</IBAN></Id></CdtrAcct>
+          </RltdPties>
+          <RmtInf><Ustrd>Rent</Ustrd><Ustrd>March</Ustrd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <NtryRef>E2</NtryRef>
+        <Amt Ccy="EUR">5.00</Amt><CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>PDNG</Cd></Sts>
+        <BookgDt><Dt>2024-03-01</Dt></BookgDt>
+      </Ntry>
+      <Ntry>
+        <NtryRef>E3</NtryRef>
+        <Amt Ccy="EUR">30.00</Amt><CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><DtTm>2024-03-01T10:00:00+01:00</DtTm></BookgDt>
+        <AcctSvcrRef>BATCH</AcctSvcrRef>
+        <NtryDtls>
+          <TxDtls>
+            <Refs><AcctSvcrRef>B-1</AcctSvcrRef></Refs>
+            <Amt Ccy="EUR">10.00</Amt>
+            <RltdPties><Dbtr><Pty><Nm>Alice</Nm></Pty></Dbtr><DbtrAcct><Id><IBAN>DE89370400440532013001</IBAN></Id></DbtrAcct></RltdPties>
+            <RmtInf><Strd><CdtrRefInf><Ref>RF18539007547034</Ref></CdtrRefInf></Strd></RmtInf>
+          </TxDtls>
+          <TxDtls>
+            <AmtDtls><TxAmt><Amt Ccy="EUR">25.00</Amt></TxAmt></AmtDtls>
+            <CdtDbtInd>CRDT</CdtDbtInd>
+            <BkTxCd><Prtry><Cd>166</Cd></Prtry></BkTxCd>
+            <RltdPties><Dbtr><Pty><Nm>Bob</Nm></Pty></Dbtr></RltdPties>
+            <AddtlTxInf>Gift</AddtlTxInf>
+          </TxDtls>
+          <TxDtls>
+            <Refs><AcctSvcrRef>B-3</AcctSvcrRef></Refs>
+            <Amt Ccy="EUR">5.00</Amt>
+            <CdtDbtInd>DBIT</CdtDbtInd>
+            <RltdPties><Cdtr><Pty><Nm>Bank</Nm></Pty></Cdtr></RltdPties>
+          </TxDtls>
+        </NtryDtls>
+      </Ntry>
+    </Stmt>
+    <Stmt>
+      <Id>S-2</Id>
+      <Acct><Id><IBAN>DE89370400440532013000</IBAN></Id></Acct>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="EUR">40.00</Amt><CdtDbtInd>CRDT</CdtDbtInd>
+        <Dt><Dt>2024-03-04</Dt></Dt>
+      </Bal>
+      <Ntry>
+        <NtryRef>E4</NtryRef>
+        <Amt Ccy="EUR">65.00</Amt><CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><Dt>2024-03-04</Dt></BookgDt>
+        <NtryDtls>
+          <TxDtls><Amt Ccy="EUR">60.00</Amt></TxDtls>
+          <TxDtls><RmtInf><Ustrd>no amount</Ustrd></RmtInf></TxDtls>
+        </NtryDtls>
+        <AddtlNtryInf>Collected payments</AddtlNtryInf>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>
+`
+
+const camtDocumentV2 = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Id>OLD</Id>
+      <FrToDt><FrDtTm>2024-01-01T00:00:00</FrDtTm><ToDtTm>2024-01-31T23:59:59</ToDtTm></FrToDt>
+      <Acct><Id><Othr><Id>123456</Id></Othr></Id></Acct>
+      <Ntry>
+        <Amt Ccy="CHF">12.00</Amt><CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <ValDt><Dt>2024-01-15</Dt></ValDt>
+        <NtryDtls><TxDtls>
+          <Refs><AcctSvcrRef>D-1</AcctSvcrRef></Refs>
+          <RltdPties><Dbtr><Nm>Old Style</Nm></Dbtr></RltdPties>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="CHF">1.00</Amt><CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts>INFO</Sts>
+        <BookgDt><Dt>2024-01-16</Dt></BookgDt>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>
+`
+
+func TestParseCAMT053(t *testing.T) {
+	statements, err := ParseCAMT053(strings.NewReader(camtDocumentV8))
+	if err != nil {
+		t.Fatalf("ParseCAMT053: %v", err)
+	}
+	if len(statements) != 1 {
+		t.Fatalf("got %d statements, want the two Stmt merged into 1", len(statements))
+	}
+	s := statements[0]
+
+	if s.Format != FormatCAMT053 || s.AccountID != "DE89370400440532013000" || s.Currency != "EUR" {
+		t.Errorf("statement header = %+v", s)
+	}
+	if s.StartDate == nil || !s.StartDate.Equal(date(2024, 3, 1)) {
+		t.Errorf("StartDate = %v, want the opening balance date", s.StartDate)
+	}
+	if s.EndDate == nil || !s.EndDate.Equal(date(2024, 3, 4)) {
+		t.Errorf("EndDate = %v, want the later statement's closing date", s.EndDate)
+	}
+	if s.LedgerBalance == nil || s.LedgerBalance.String() != "40" {
+		t.Errorf("LedgerBalance = %v, want the latest closing balance 40", s.LedgerBalance)
+	}
+
+	want := []struct {
+		id, typ, date, amount, payee, iban, memo string
+	}{
+		{"REF-1", "PMNT/ICDT/ESCT", "2024-03-01", "-80", "Landlord", "GB82WEST12345698765432", "Rent March"},
+		{"B-1", "", "2024-03-01", "10", "Alice", "", "RF18539007547034"},
+		{"BATCH/2", "166", "2024-03-01", "25", "Bob", "", "Gift"},
+		{"B-3", "", "2024-03-01", "-5", "Bank", "", ""},
+		{"E4", "", "2024-03-04", "65", "", "", "Collected payments"},
+	}
+	if len(s.Transactions) != len(want) {
+		t.Fatalf("got %d transactions, want %d", len(s.Transactions), len(want))
+	}
+	for i, w := range want {
+		tx := s.Transactions[i]
+		got := []string{tx.ID, tx.Type, tx.Date.Format("2006-01-02"), tx.Amount.String(), tx.Payee, tx.CounterpartyIBAN, tx.Memo}
+		exp := []string{w.id, w.typ, w.date, w.amount, w.payee, w.iban, w.memo}
+		if strings.Join(got, "|") != strings.Join(exp, "|") {
+			t.Errorf("transaction %d = %q, want %q", i, got, exp)
+		}
+	}
+	if vd := s.Transactions[0].ValueDate; vd == nil || !vd.Equal(date(2024, 3, 2)) {
+		t.Errorf("ValueDate = %v, want 2024-03-02", vd)
+	}
+}
+
+func TestParseCAMT053OldVersion(t *testing.T) {
+	statements, err := ParseCAMT053(strings.NewReader(camtDocumentV2))
+	if err != nil {
+		t.Fatalf("ParseCAMT053: %v", err)
+	}
+	s := statements[0]
+
+	if s.AccountID != "123456" || s.Currency != "" {
+		t.Errorf("AccountID, Currency = %q, %q", s.AccountID, s.Currency)
+	}
+	if s.StartDate == nil || !s.StartDate.Equal(date(2024, 1, 1)) || s.EndDate == nil || !s.EndDate.Equal(date(2024, 1, 31)) {
+		t.Errorf("StartDate, EndDate = %v, %v", s.StartDate, s.EndDate)
+	}
+	if len(s.Transactions) != 1 {
+		t.Fatalf("got %d transactions, want only the booked entry", len(s.Transactions))
+	}
+	tx := s.Transactions[0]
+	if tx.ID != "D-1" || tx.Payee != "Old Style" || tx.Currency != "CHF" || !tx.Date.Equal(date(2024, 1, 15)) {
+		t.Errorf("transaction = %+v, want the value date, details reference and debtor name", tx)
+	}
+}
+
+func TestParseCAMT053Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr string
+	}{
+		{name: "not XML", doc: "MT940", wantErr: "invalid camt.053 XML"},
+		{name: "no statement", doc: "<Document><BkToCstmrStmt/></Document>", wantErr: "no statement found"},
+		{
+			name:    "bad indicator",
+			doc:     `<Document><BkToCstmrStmt><Stmt><Id>X</Id><Ntry><Amt>1</Amt><CdtDbtInd>CR</CdtDbtInd><BookgDt><Dt>2024-01-01</Dt></BookgDt></Ntry></Stmt></BkToCstmrStmt></Document>`,
+			wantErr: `invalid credit/debit indicator "CR"`,
+		},
+		{
+			name:    "no booking date",
+			doc:     `<Document><BkToCstmrStmt><Stmt><Id>X</Id><Ntry><Amt>1</Amt><CdtDbtInd>CRDT</CdtDbtInd></Ntry></Stmt></BkToCstmrStmt></Document>`,
+			wantErr: "entry has no booking date",
+		},
+		{
+			name:    "bad date",
+			doc:     `<Document><BkToCstmrStmt><Stmt><Id>X</Id><FrToDt><FrDtTm>01.01.2024</FrDtTm></FrToDt></Stmt></BkToCstmrStmt></Document>`,
+			wantErr: "invalid date",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCAMT053(strings.NewReader(tt.doc))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseCAMT053 error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNormalizeIBAN(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "DE89370400440532013000", want: "DE89370400440532013000"},
+		{in: " de89 3704 0044 0532 0130 00 ", want: "DE89370400440532013000"},
+		{in: "GB82WEST12345698765432", want: "GB82WEST12345698765432"},
+		{in: "NO9386011117947", want: "NO9386011117947"},
+		{in: "DE89370400440532013001", want: ""},
+		{in: "GB82WEST1234569876543", want: ""},
+		{in: "NO938601111794", want: ""},
+		{in: "1289370400440532013000", want: ""},
+		{in: "DEAB370400440532013000", want: ""},
+		{in: "DE89-3704-0044-0532-0130-00", want: ""},
+		{in: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeIBAN(tt.in); got != tt.want {
+			t.Errorf("NormalizeIBAN(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// CSVMapping describes how to read a bank's CSV export. Columns are header
+// names, or 1-based column numbers for files without a header row. Either
+// AmountColumn or at least one of DebitColumn (money out) and CreditColumn
+// (money in) is set.
+type CSVMapping struct {
+	Delimiter         rune
+	HasHeader         bool
+	SkipRows          int
+	DateColumn        string
+	DateFormat        string
+	AmountColumn      string
+	DebitColumn       string
+	CreditColumn      string
+	InvertSign        bool
+	DecimalComma      bool
+	DescriptionColumn string
+	MemoColumn        string
+}
+
+// RowError reports a CSV row that could not be read. Reading continues with
+// the next row.
+type RowError struct {
+	Line    int
+	Message string
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// csvColumns holds the resolved 0-based column indexes; -1 when unused
+type csvColumns struct {
+	date, amount, debit, credit, description, memo int
+}
+
+// CSVReader reads statement lines from a CSV file one row at a time
+type CSVReader struct {
+	csv     *csv.Reader
+	mapping *CSVMapping
+	layout  string
+	columns *csvColumns
+	line    int
+}
+
+// NewCSVReader returns a reader of the rows of r as described by mapping
+func NewCSVReader(r io.Reader, mapping *CSVMapping) *CSVReader {
+	br := bufio.NewReader(r)
+	if bom, err := br.Peek(3); err == nil && bytes.Equal(bom, []byte{0xEF, 0xBB, 0xBF}) {
+		br.Discard(3)
+	}
+
+	cr := csv.NewReader(br)
+	if mapping.Delimiter != 0 {
+		cr.Comma = mapping.Delimiter
+	}
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	cr.TrimLeadingSpace = true
+
+	return &CSVReader{
+		csv:     cr,
+		mapping: mapping,
+		layout:  DateLayout(mapping.DateFormat),
+	}
+}
+
+// Read returns the next statement line. It returns a *RowError for a row
+// that cannot be read, io.EOF at the end of the file, and any other error
+// when the file cannot be read further.
+func (r *CSVReader) Read() (*Transaction, error) {
+	if r.columns == nil {
+		if err := r.start(); err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+		record, err := r.csv.Read()
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return nil, &RowError{Line: parseErr.Line, Message: parseErr.Err.Error()}
+			}
+			return nil, err
+		}
+		r.line, _ = r.csv.FieldPos(0)
+		if blankRecord(record) {
+			continue
+		}
+		return r.transaction(record)
+	}
+}
+
+// Line returns the line number of the row last read
+func (r *CSVReader) Line() int {
+	return r.line
+}
+
+// start skips the leading rows and resolves the mapped columns, from the
+// header row when there is one
+func (r *CSVReader) start() error {
+	for i := 0; i < r.mapping.SkipRows; i++ {
+		if _, err := r.csv.Read(); err != nil {
+			return fmt.Errorf("failed to skip row %d: %w", i+1, err)
+		}
+	}
+
+	var header []string
+	if r.mapping.HasHeader {
+		record, err := r.csv.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return errors.New("the file has no header row")
+			}
+			return fmt.Errorf("failed to read header row: %w", err)
+		}
+		header = append(header, record...)
+	}
+
+	columns := &csvColumns{}
+	resolved := []struct {
+		ref      string
+		required bool
+		index    *int
+	}{
+		{r.mapping.DateColumn, true, &columns.date},
+		{r.mapping.AmountColumn, false, &columns.amount},
+		{r.mapping.DebitColumn, false, &columns.debit},
+		{r.mapping.CreditColumn, false, &columns.credit},
+		{r.mapping.DescriptionColumn, false, &columns.description},
+		{r.mapping.MemoColumn, false, &columns.memo},
+	}
+	for _, col := range resolved {
+		index, err := columnIndex(header, col.ref)
+		if err != nil {
+			return err
+		}
+		if index < 0 && col.required {
+			return errors.New("the date column is not mapped")
+		}
+		*col.index = index
+	}
+	if columns.amount < 0 && columns.debit < 0 && columns.credit < 0 {
+		return errors.New("neither an amount column nor debit/credit columns are mapped")
+	}
+
+	r.columns = columns
+	return nil
+}
+
+// transaction converts one row
+func (r *CSVReader) transaction(record []string) (*Transaction, error) {
+	field := func(index int) string {
+		if index < 0 || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+	rowError := func(format string, args ...any) error {
+		return &RowError{Line: r.line, Message: fmt.Sprintf(format, args...)}
+	}
+
+	dateText := field(r.columns.date)
+	date, err := time.Parse(r.layout, dateText)
+	if err != nil {
+		return nil, rowError("invalid date %q for format %s", dateText, r.mapping.DateFormat)
+	}
+
+	var amount decimal.Decimal
+	if r.columns.amount >= 0 {
+		amount, err = parseCSVAmount(field(r.columns.amount), r.mapping.DecimalComma)
+		if err != nil {
+			return nil, rowError("invalid amount: %v", err)
+		}
+	} else {
+		debit, credit := field(r.columns.debit), field(r.columns.credit)
+		if debit == "" && credit == "" {
+			return nil, rowError("both debit and credit are empty")
+		}
+		if debit != "" {
+			value, err := parseCSVAmount(debit, r.mapping.DecimalComma)
+			if err != nil {
+				return nil, rowError("invalid debit: %v", err)
+			}
+			amount = amount.Sub(value.Abs())
+		}
+		if credit != "" {
+			value, err := parseCSVAmount(credit, r.mapping.DecimalComma)
+			if err != nil {
+				return nil, rowError("invalid credit: %v", err)
+			}
+			amount = amount.Add(value.Abs())
+		}
+	}
+	if r.mapping.InvertSign {
+		amount = amount.Neg()
+	}
+
+	return &Transaction{
+		Date:   date,
+		Amount: amount,
+		Payee:  field(r.columns.description),
+		Memo:   field(r.columns.memo),
+	}, nil
+}
+
+// columnIndex resolves a column reference against the header row. A
+// reference that names no header column is read as a 1-based column number.
+func columnIndex(header []string, ref string) (int, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return -1, nil
+	}
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), ref) {
+			return i, nil
+		}
+	}
+	n, err := strconv.Atoi(ref)
+	if err != nil || n < 1 {
+		if header != nil {
+			return -1, fmt.Errorf("column %q not found in the header row", ref)
+		}
+		return -1, fmt.Errorf("column %q must be a column number as the file has no header row", ref)
+	}
+	return n - 1, nil
+}
+
+// DateLayout converts a date format written with YYYY, YY, MMM, MM, M, DD
+// and D into a Go time layout. Formats that already are Go layouts are
+// returned unchanged; an empty format means YYYY-MM-DD.
+func DateLayout(format string) string {
+	if format == "" {
+		return "2006-01-02"
+	}
+	if strings.Contains(format, "2006") {
+		return format
+	}
+	return strings.NewReplacer(
+		"YYYY", "2006",
+		"YY", "06",
+		"MMM", "Jan",
+		"MM", "01",
+		"M", "1",
+		"DD", "02",
+		"D", "2",
+	).Replace(format)
+}
+
+// parseCSVAmount reads an amount as banks export it: with currency symbols,
+// thousands separators, and negatives written as -1.00, 1.00- or (1.00)
+func parseCSVAmount(s string, decimalComma bool) (decimal.Decimal, error) {
+	text := strings.TrimSpace(s)
+	if text == "" {
+		return decimal.Zero, errors.New("empty value")
+	}
+
+	negative := strings.Contains(text, "-") ||
+		(strings.HasPrefix(text, "(") && strings.HasSuffix(text, ")"))
+
+	separator := '.'
+	if decimalComma {
+		separator = ','
+	}
+	var digits strings.Builder
+	for _, c := range text {
+		switch {
+		case c >= '0' && c <= '9':
+			digits.WriteRune(c)
+		case c == separator:
+			digits.WriteRune('.')
+		}
+	}
+
+	value, err := decimal.NewFromString(digits.String())
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("%q is not a number", s)
+	}
+	if negative {
+		value = value.Neg()
+	}
+	return value, nil
+}
+
+// blankRecord reports whether every field of a row is empty
+func blankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,231 @@
+package importer
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestParseCSVAmount(t *testing.T) {
+	tests := []struct {
+		in           string
+		decimalComma bool
+		want         string
+		wantErr      bool
+	}{
+		{in: "12.50", want: "12.5"},
+		{in: "-12.50", want: "-12.5"},
+		{in: "12.50-", want: "-12.5"},
+		{in: "(12.50)", want: "-12.5"},
+		{in: "+12.50", want: "12.5"},
+		{in: "$1,234.56", want: "1234.56"},
+		{in: "-$1,234.56", want: "-1234.56"},
+		{in: "1.234,56", decimalComma: true, want: "1234.56"},
+		{in: "-1.234,56 €", decimalComma: true, want: "-1234.56"},
+		{in: "1 234,56", decimalComma: true, want: "1234.56"},
+		{in: " 7 ", want: "7"},
+		{in: "(12.50", want: "12.5"},
+		{in: "", wantErr: true},
+		{in: "n/a", wantErr: true},
+		{in: "1.2.3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseCSVAmount(tt.in, tt.decimalComma)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseCSVAmount(%q) = %s, want an error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || got.String() != tt.want {
+			t.Errorf("parseCSVAmount(%q, %v) = %s, %v; want %s", tt.in, tt.decimalComma, got, err, tt.want)
+		}
+	}
+}
+
+func TestDateLayout(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: "", want: "2006-01-02"},
+		{format: "DD/MM/YYYY", want: "02/01/2006"},
+		{format: "M/D/YY", want: "1/2/06"},
+		{format: "DD-MMM-YYYY", want: "02-Jan-2006"},
+		{format: "02.01.2006", want: "02.01.2006"},
+	}
+
+	for _, tt := range tests {
+		if got := DateLayout(tt.format); got != tt.want {
+			t.Errorf("DateLayout(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestColumnIndex(t *testing.T) {
+	header := []string{"Date", " Amount ", "Payee"}
+	tests := []struct {
+		header  []string
+		ref     string
+		want    int
+		wantErr bool
+	}{
+		{header: header, ref: "", want: -1},
+		{header: header, ref: "amount", want: 1},
+		{header: header, ref: "3", want: 2},
+		{header: header, ref: "Memo", wantErr: true},
+		{header: nil, ref: "2", want: 1},
+		{header: nil, ref: "0", wantErr: true},
+		{header: nil, ref: "Date", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := columnIndex(tt.header, tt.ref)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("columnIndex(%v, %q) = %d, want an error", tt.header, tt.ref, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("columnIndex(%v, %q) = %d, %v; want %d", tt.header, tt.ref, got, err, tt.want)
+		}
+	}
+}
+
+func TestCSVReader(t *testing.T) {
+	data := "\xEF\xBB\xBFExported 2024-02-01\n" +
+		"Date;Payee;Out;In;Note\n" +
+		"05.01.2024;Bakery;12,50;;card\n" +
+		";;;;\n" +
+		"06.01.2024;Employer;;1.000,00;\n" +
+		"32.01.2024;Broken;1,00;;\n" +
+		"07.01.2024;Nothing;;;\n"
+	mapping := &CSVMapping{
+		Delimiter:         ';',
+		HasHeader:         true,
+		SkipRows:          1,
+		DateColumn:        "Date",
+		DateFormat:        "DD.MM.YYYY",
+		DebitColumn:       "Out",
+		CreditColumn:      "In",
+		DecimalComma:      true,
+		DescriptionColumn: "Payee",
+		MemoColumn:        "5",
+	}
+
+	type row struct {
+		line   int
+		date   string
+		amount string
+		payee  string
+		memo   string
+		err    string
+	}
+	want := []row{
+		{line: 3, date: "2024-01-05", amount: "-12.5", payee: "Bakery", memo: "card"},
+		{line: 5, date: "2024-01-06", amount: "1000", payee: "Employer"},
+		{line: 6, err: "invalid date"},
+		{line: 7, err: "both debit and credit are empty"},
+	}
+
+	r := NewCSVReader(strings.NewReader(data), mapping)
+	var got []row
+	for {
+		tx, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var rowErr *RowError
+		switch {
+		case errors.As(err, &rowErr):
+			got = append(got, row{line: rowErr.Line, err: rowErr.Message})
+		case err != nil:
+			t.Fatalf("Read: %v", err)
+		default:
+			got = append(got, row{
+				line:   r.Line(),
+				date:   tx.Date.Format("2006-01-02"),
+				amount: tx.Amount.String(),
+				payee:  tx.Payee,
+				memo:   tx.Memo,
+			})
+		}
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d rows %+v, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if w.err != "" {
+			if g.line != w.line || !strings.Contains(g.err, w.err) {
+				t.Errorf("row %d = %+v, want error %q on line %d", i, g, w.err, w.line)
+			}
+			continue
+		}
+		if g != w {
+			t.Errorf("row %d = %+v, want %+v", i, g, w)
+		}
+	}
+}
+
+func TestCSVReaderInvertSign(t *testing.T) {
+	r := NewCSVReader(strings.NewReader("1,2024-03-01,25.00\n"), &CSVMapping{
+		DateColumn:   "2",
+		AmountColumn: "3",
+		InvertSign:   true,
+	})
+	tx, err := r.Read()
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if tx.Amount.String() != "-25" {
+		t.Errorf("Amount = %s, want -25", tx.Amount)
+	}
+}
+
+func TestCSVReaderMapping(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		mapping *CSVMapping
+		wantErr string
+	}{
+		{
+			name:    "missing header row",
+			data:    "",
+			mapping: &CSVMapping{HasHeader: true, DateColumn: "Date", AmountColumn: "Amount"},
+			wantErr: "no header row",
+		},
+		{
+			name:    "date column not mapped",
+			data:    "2024-01-01,1\n",
+			mapping: &CSVMapping{AmountColumn: "2"},
+			wantErr: "date column is not mapped",
+		},
+		{
+			name:    "no amount columns",
+			data:    "2024-01-01,1\n",
+			mapping: &CSVMapping{DateColumn: "1"},
+			wantErr: "neither an amount column",
+		},
+		{
+			name:    "unknown header column",
+			data:    "Date,Amount\n",
+			mapping: &CSVMapping{HasHeader: true, DateColumn: "Date", AmountColumn: "Value"},
+			wantErr: `column "Value" not found`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCSVReader(strings.NewReader(tt.data), tt.mapping).Read()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Read error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
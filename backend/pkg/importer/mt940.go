@@ -0,0 +1,336 @@
+package importer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// mt940Tag matches the start of a field such as :61: or :60F:
+var mt940Tag = regexp.MustCompile(`^:(\d{2}[A-Z]?):`)
+
+// mt940Line matches the first line of a :61: statement line: value date,
+// optional entry date, debit/credit mark, optional funds code, amount,
+// transaction type, customer reference and optional bank reference
+var mt940Line = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)([A-Z])?(\d+,\d*)([NFS][A-Z0-9]{3})?(.*?)(?://(.*))?$`)
+
+// mt940Balance matches a balance field: debit/credit mark, date, currency, amount
+var mt940Balance = regexp.MustCompile(`^(C|D)(\d{6})([A-Z]{3})(\d+,\d*)`)
+
+// mt940Codes are the keys of the slash-coded :86: format used by Dutch and
+// other SEPA banks, as in /NAME/J. Jansen/IBAN/NL91ABNA0417164300/REMI/...
+var mt940Codes = map[string]bool{
+	"TRTP": true, "IBAN": true, "BIC": true, "NAME": true, "REMI": true,
+	"EREF": true, "CNTP": true, "MARF": true, "CSID": true, "ORDP": true,
+	"BENM": true, "ADDR": true, "PURP": true, "RTRN": true, "ULTC": true,
+	"ULTD": true, "ULTB": true, "SVCL": true, "ISDT": true, "PREF": true,
+	"RTRE": true, "ID": true, "CDTRREF": true, "CDTRREFTP": true,
+}
+
+// mt940Field is one field of a statement with its continuation lines
+type mt940Field struct {
+	tag   string
+	lines []string
+}
+
+// ParseMT940 reads the statements of a SWIFT MT940 file. Statements of the
+// same account are combined. The :86: information is read in the German
+// ?-coded layout, the slash-coded SEPA layout, or as free text.
+func ParseMT940(r io.Reader) ([]*Statement, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read MT940: %w", err)
+	}
+
+	var fields []*mt940Field
+	scanner := bufio.NewScanner(strings.NewReader(toUTF8(data)))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \r")
+		// Skip the SWIFT header blocks and the message terminator
+		if line == "" || line == "-" || line == "-}" || strings.HasPrefix(line, "{") {
+			if i := strings.Index(line, "{4:"); i >= 0 {
+				line = line[i+3:]
+			} else {
+				continue
+			}
+		}
+		if m := mt940Tag.FindStringSubmatch(line); m != nil {
+			fields = append(fields, &mt940Field{tag: m[1], lines: []string{line[len(m[0]):]}})
+			continue
+		}
+		if len(fields) > 0 {
+			last := fields[len(fields)-1]
+			last.lines = append(last.lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read MT940: %w", err)
+	}
+
+	var statements []*Statement
+	var statement *Statement
+	var last *Transaction
+	for _, f := range fields {
+		switch f.tag {
+		case "20":
+			statement = &Statement{Format: FormatMT940}
+			statements = append(statements, statement)
+			last = nil
+		case "25":
+			if statement == nil {
+				return nil, errors.New("field :25: before :20:")
+			}
+			statement.AccountID = strings.TrimSpace(f.lines[0])
+		case "60F", "60M":
+			if statement == nil {
+				return nil, errors.New("field :60F: before :20:")
+			}
+			_, date, currency, err := parseMT940Balance(f.lines[0])
+			if err != nil {
+				return nil, err
+			}
+			statement.Currency = currency
+			statement.StartDate = &date
+		case "62F", "62M":
+			if statement == nil {
+				return nil, errors.New("field :62F: before :20:")
+			}
+			amount, date, _, err := parseMT940Balance(f.lines[0])
+			if err != nil {
+				return nil, err
+			}
+			statement.EndDate = &date
+			if f.tag == "62F" {
+				statement.LedgerBalance = &amount
+				statement.BalanceDate = &date
+			}
+		case "61":
+			if statement == nil {
+				return nil, errors.New("field :61: before :20:")
+			}
+			tx, err := parseMT940Line(f.lines)
+			if err != nil {
+				return nil, err
+			}
+			tx.Currency = statement.Currency
+			statement.Transactions = append(statement.Transactions, tx)
+			last = tx
+		case "86":
+			// Information for the account owner after the last :61: belongs
+			// to the statement, not a line
+			if last != nil {
+				applyMT940Info(last, f.lines)
+				last = nil
+			}
+		}
+	}
+	if len(statements) == 0 {
+		return nil, errors.New("not an MT940 file: no :20: field found")
+	}
+
+	return mergeStatements(statements), nil
+}
+
+// parseMT940Line reads a :61: field
+func parseMT940Line(lines []string) (*Transaction, error) {
+	m := mt940Line.FindStringSubmatch(lines[0])
+	if m == nil {
+		return nil, fmt.Errorf("invalid statement line %q", lines[0])
+	}
+
+	value, err := parseMT940Date(m[1])
+	if err != nil {
+		return nil, err
+	}
+	booked := value
+	if m[2] != "" {
+		// The entry date has no year: take the one that puts it closest to
+		// the value date
+		month, _ := strconv.Atoi(m[2][:2])
+		day, _ := strconv.Atoi(m[2][2:])
+		booked = time.Date(value.Year(), time.Month(month), day, 0, 0, 0, 0, time.UTC)
+		if booked.Sub(value) > 180*24*time.Hour {
+			booked = booked.AddDate(-1, 0, 0)
+		} else if value.Sub(booked) > 180*24*time.Hour {
+			booked = booked.AddDate(1, 0, 0)
+		}
+		if int(booked.Month()) != month || booked.Day() != day {
+			return nil, fmt.Errorf("invalid entry date %q", m[2])
+		}
+	}
+
+	amount, err := parseAmount(m[5])
+	if err != nil {
+		return nil, err
+	}
+	// RC reverses a credit and RD a debit
+	if m[3] == "D" || m[3] == "RC" {
+		amount = amount.Neg()
+	}
+
+	tx := &Transaction{
+		ID:        strings.TrimSpace(m[8]),
+		Type:      m[6],
+		Date:      booked,
+		ValueDate: &value,
+		Amount:    amount,
+	}
+	if len(lines) > 1 {
+		tx.Memo = strings.TrimSpace(strings.Join(lines[1:], " "))
+	}
+	return tx, nil
+}
+
+// applyMT940Info reads the counterparty and remittance information of a
+// :86: field. The supplementary details of the :61: field remain the memo
+// when the field has no remittance text.
+func applyMT940Info(tx *Transaction, lines []string) {
+	details := tx.Memo
+	defer func() {
+		if tx.Memo == "" {
+			tx.Memo = details
+		}
+	}()
+
+	joined := strings.Join(lines, "")
+	switch {
+	case len(joined) > 4 && joined[3] == '?' && isDigits(joined[:3]):
+		applyMT940German(tx, joined)
+	case strings.HasPrefix(joined, "/"):
+		applyMT940Coded(tx, joined)
+	default:
+		tx.Memo = strings.TrimSpace(strings.Join(lines, " "))
+	}
+}
+
+// applyMT940German reads the ?-coded layout of German banks: ?20 to ?29 and
+// ?60 to ?63 hold the remittance text, ?31 the account and ?32/?33 the name
+func applyMT940German(tx *Transaction, info string) {
+	var remittance, name strings.Builder
+	for _, part := range strings.Split(info[3:], "?")[1:] {
+		if len(part) < 2 {
+			continue
+		}
+		code, value := part[:2], part[2:]
+		switch {
+		case code >= "20" && code <= "29", code >= "60" && code <= "63":
+			remittance.WriteString(value)
+		case code == "31":
+			tx.CounterpartyIBAN = NormalizeIBAN(value)
+		case code == "32", code == "33":
+			name.WriteString(value)
+		}
+	}
+
+	text := remittance.String()
+	// SEPA payments tag the remittance text as SVWZ+ among other fields
+	if i := strings.Index(text, "SVWZ+"); i >= 0 {
+		text = text[i+len("SVWZ+"):]
+		for _, tag := range []string{"ABWA+", "ABWE+", "KREF+", "EREF+", "MREF+", "CRED+", "DEBT+"} {
+			if j := strings.Index(text, tag); j >= 0 {
+				text = text[:j]
+			}
+		}
+	}
+	tx.Memo = strings.TrimSpace(text)
+	tx.Payee = strings.TrimSpace(name.String())
+}
+
+// applyMT940Coded reads the slash-coded layout. /CNTP/ carries the
+// counterparty as account/BIC/name/city.
+func applyMT940Coded(tx *Transaction, info string) {
+	values := make(map[string][]string)
+	var code string
+	for _, token := range strings.Split(info, "/") {
+		if mt940Codes[token] {
+			code = token
+			values[code] = []string{}
+			continue
+		}
+		if code != "" {
+			values[code] = append(values[code], token)
+		}
+	}
+
+	first := func(code string) string {
+		for _, v := range values[code] {
+			if v = strings.TrimSpace(v); v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+
+	if cntp := values["CNTP"]; len(cntp) > 0 {
+		tx.CounterpartyIBAN = NormalizeIBAN(cntp[0])
+		if len(cntp) > 2 {
+			tx.Payee = strings.TrimSpace(cntp[2])
+		}
+	}
+	if iban := NormalizeIBAN(first("IBAN")); iban != "" {
+		tx.CounterpartyIBAN = iban
+	}
+	if name := first("NAME"); name != "" {
+		tx.Payee = name
+	}
+
+	// /REMI/ is the text itself or USTD//text and STRD/CUR/reference
+	remi := values["REMI"]
+	if len(remi) > 0 && (remi[0] == "USTD" || remi[0] == "STRD") {
+		remi = remi[1:]
+	}
+	var parts []string
+	for _, v := range remi {
+		if v = strings.TrimSpace(v); v != "" && v != "CUR" && v != "ISO" {
+			parts = append(parts, v)
+		}
+	}
+	tx.Memo = strings.Join(parts, " ")
+}
+
+// parseMT940Balance reads a balance field
+func parseMT940Balance(s string) (decimal.Decimal, time.Time, string, error) {
+	m := mt940Balance.FindStringSubmatch(s)
+	if m == nil {
+		return decimal.Zero, time.Time{}, "", fmt.Errorf("invalid balance %q", s)
+	}
+	date, err := parseMT940Date(m[2])
+	if err != nil {
+		return decimal.Zero, time.Time{}, "", err
+	}
+	amount, err := parseAmount(m[4])
+	if err != nil {
+		return decimal.Zero, time.Time{}, "", err
+	}
+	if m[1] == "D" {
+		amount = amount.Neg()
+	}
+	return amount, date, m[3], nil
+}
+
+// parseMT940Date reads a YYMMDD date
+func parseMT940Date(s string) (time.Time, error) {
+	t, err := time.Parse("060102", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+	return t, nil
+}
+
+// isDigits reports whether s is made of ASCII digits only
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
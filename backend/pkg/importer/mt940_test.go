@@ -0,0 +1,230 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const mt940File = `{1:F01BANKDEFFXXXX0000000000}{2:O9401200240105BANKDEFFXXXX00000000002401051200N}{4:
+:20:STMT1
+:25:DE89370400440532013000
+:28C:1/1
+:60F:C240104EUR1000,00
+:61:2401050105D12,50NTRFNONREF//B-1
+:86:166?00SEPA-UEBERWEISUNG?20EREF+123?21SVWZ+Rent January?22ABWA+X
+?31GB82WEST12345698765432?32Landlord?33 GmbH
+:61:240106C200,00NMSCREF2
+EXTRA DETAILS
+:86:/CNTP/NL91ABNA0417164300/ABNANL2A/J. Jansen/Amsterdam/REMI/USTD//Invoi
+ce 42/EREF/NOTPROVIDED
+:61:240107D5,NCHGNONREF
+:86:Account fee
+:61:240107C1,00NTRFNONREF//B-4
+SUPPLEMENTARY
+:86:/NAME/Someone
+:62M:C240107EUR1182,50
+:86:Statement information
+-}
+{1:F01BANKDEFFXXXX0000000000}{2:O9401200240108BANKDEFFXXXX00000000002401081200N}{4:
+:20:STMT2
+:25:DE89370400440532013000
+:60M:C240107EUR1182,50
+:61:2312311230C10,00NTRFNONREF
+:62F:D240108EUR5,00
+-}
+`
+
+func TestParseMT940(t *testing.T) {
+	statements, err := ParseMT940(strings.NewReader(mt940File))
+	if err != nil {
+		t.Fatalf("ParseMT940: %v", err)
+	}
+	if len(statements) != 1 {
+		t.Fatalf("got %d statements, want the two messages merged into 1", len(statements))
+	}
+	s := statements[0]
+
+	if s.Format != FormatMT940 || s.AccountID != "DE89370400440532013000" || s.Currency != "EUR" {
+		t.Errorf("statement header = %+v", s)
+	}
+	if s.StartDate == nil || !s.StartDate.Equal(date(2024, 1, 4)) {
+		t.Errorf("StartDate = %v, want 2024-01-04", s.StartDate)
+	}
+	if s.EndDate == nil || !s.EndDate.Equal(date(2024, 1, 8)) {
+		t.Errorf("EndDate = %v, want 2024-01-08", s.EndDate)
+	}
+	if s.LedgerBalance == nil || s.LedgerBalance.String() != "-5" {
+		t.Errorf("LedgerBalance = %v, want the final -5 and not the intermediate balance", s.LedgerBalance)
+	}
+
+	want := []struct {
+		id, typ, date, amount, payee, iban, memo string
+	}{
+		{"B-1", "NTRF", "2024-01-05", "-12.5", "Landlord GmbH", "GB82WEST12345698765432", "Rent January"},
+		{"", "NMSC", "2024-01-06", "200", "J. Jansen", "NL91ABNA0417164300", "Invoice 42"},
+		{"", "NCHG", "2024-01-07", "-5", "", "", "Account fee"},
+		{"B-4", "NTRF", "2024-01-07", "1", "Someone", "", "SUPPLEMENTARY"},
+		{"", "NTRF", "2023-12-30", "10", "", "", ""},
+	}
+	if len(s.Transactions) != len(want) {
+		t.Fatalf("got %d transactions, want %d", len(s.Transactions), len(want))
+	}
+	for i, w := range want {
+		tx := s.Transactions[i]
+		got := []string{tx.ID, tx.Type, tx.Date.Format("2006-01-02"), tx.Amount.String(), tx.Payee, tx.CounterpartyIBAN, tx.Memo}
+		exp := []string{w.id, w.typ, w.date, w.amount, w.payee, w.iban, w.memo}
+		if strings.Join(got, "|") != strings.Join(exp, "|") {
+			t.Errorf("transaction %d = %q, want %q", i, got, exp)
+		}
+		if tx.Currency != "EUR" {
+			t.Errorf("transaction %d Currency = %q, want EUR", i, tx.Currency)
+		}
+	}
+}
+
+func TestParseMT940Line(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		value   time.Time
+		booked  time.Time
+		amount  string
+		typ     string
+		id      string
+		wantErr bool
+	}{
+		{
+			name:   "value date only",
+			line:   "240105C12,50NTRFNONREF",
+			value:  date(2024, 1, 5),
+			booked: date(2024, 1, 5),
+			amount: "12.5",
+			typ:    "NTRF",
+		},
+		{
+			name:   "entry date in the same year",
+			line:   "2401050108D12,50NTRFNONREF//B-1",
+			value:  date(2024, 1, 5),
+			booked: date(2024, 1, 8),
+			amount: "-12.5",
+			typ:    "NTRF",
+			id:     "B-1",
+		},
+		{
+			name:   "entry date in the next year",
+			line:   "2312310102D1,00NDDTNONREF",
+			value:  date(2023, 12, 31),
+			booked: date(2024, 1, 2),
+			amount: "-1",
+			typ:    "NDDT",
+		},
+		{
+			name:   "entry date in the previous year",
+			line:   "2401021231C1,00NTRFNONREF",
+			value:  date(2024, 1, 2),
+			booked: date(2023, 12, 31),
+			amount: "1",
+			typ:    "NTRF",
+		},
+		{
+			name:   "entry date on a leap day",
+			line:   "2403010229C1,00NTRFNONREF",
+			value:  date(2024, 3, 1),
+			booked: date(2024, 2, 29),
+			amount: "1",
+			typ:    "NTRF",
+		},
+		{
+			name:   "reversal of a credit",
+			line:   "240105RC5,00NTRFNONREF",
+			value:  date(2024, 1, 5),
+			booked: date(2024, 1, 5),
+			amount: "-5",
+			typ:    "NTRF",
+		},
+		{
+			name:   "reversal of a debit",
+			line:   "240105RD5,00NTRFNONREF",
+			value:  date(2024, 1, 5),
+			booked: date(2024, 1, 5),
+			amount: "5",
+			typ:    "NTRF",
+		},
+		{
+			name:   "funds code and no decimals",
+			line:   "240105CR1000,FCHKREF//BANK REF ",
+			value:  date(2024, 1, 5),
+			booked: date(2024, 1, 5),
+			amount: "1000",
+			typ:    "FCHK",
+			id:     "BANK REF",
+		},
+		{
+			name:    "invalid value date",
+			line:    "241305C1,00NTRFNONREF",
+			wantErr: true,
+		},
+		{
+			name:    "invalid entry date",
+			line:    "2402010231C1,00NTRFNONREF",
+			wantErr: true,
+		},
+		{
+			name:    "missing mark",
+			line:    "2401051,00NTRFNONREF",
+			wantErr: true,
+		},
+		{
+			name:    "amount without comma",
+			line:    "240105C100NTRFNONREF",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, err := parseMT940Line([]string{tt.line})
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseMT940Line(%q) = %+v, want an error", tt.line, tx)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseMT940Line(%q): %v", tt.line, err)
+			}
+			if tx.ValueDate == nil || !tx.ValueDate.Equal(tt.value) {
+				t.Errorf("ValueDate = %v, want %v", tx.ValueDate, tt.value)
+			}
+			if !tx.Date.Equal(tt.booked) {
+				t.Errorf("Date = %v, want %v", tx.Date, tt.booked)
+			}
+			if tx.Amount.String() != tt.amount || tx.Type != tt.typ || tx.ID != tt.id {
+				t.Errorf("Amount, Type, ID = %s, %q, %q; want %s, %q, %q", tx.Amount, tx.Type, tx.ID, tt.amount, tt.typ, tt.id)
+			}
+		})
+	}
+}
+
+func TestParseMT940Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "no statement", data: "hello\n", wantErr: "no :20: field found"},
+		{name: "line before statement", data: ":61:240105C1,00NTRFNONREF\n", wantErr: ":61: before :20:"},
+		{name: "invalid balance", data: ":20:X\n:60F:X240104EUR1,00\n", wantErr: "invalid balance"},
+		{name: "invalid line", data: ":20:X\n:61:garbage\n", wantErr: "invalid statement line"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseMT940(strings.NewReader(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseMT940 error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+)
+
+// ofxNode is an element of an OFX document. Leaf elements carry a value;
+// aggregates carry children.
+type ofxNode struct {
+	name     string
+	value    string
+	children []*ofxNode
+}
+
+// ParseOFX reads the bank and credit card statements of an OFX 1.x (SGML)
+// or 2.x (XML) file. QFX files are OFX with extra Quicken elements and are
+// read the same way.
+func ParseOFX(r io.Reader) ([]*Statement, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OFX: %w", err)
+	}
+
+	root, err := parseOFXTree(toUTF8(data))
+	if err != nil {
+		return nil, err
+	}
+
+	var statements []*Statement
+	for _, rs := range root.descendants("STMTRS", "CCSTMTRS") {
+		statement, err := ofxStatement(rs)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, statement)
+	}
+	if len(statements) == 0 {
+		return nil, errors.New("no bank or credit card statement found in OFX")
+	}
+
+	return statements, nil
+}
+
+// parseOFXTree builds the element tree of the document body. SGML leaf
+// elements have no end tag, so an element followed by text is a leaf and an
+// element followed by another tag opens an aggregate. End tags close the
+// nearest open aggregate of the same name; end tags of XML leaves match no
+// open aggregate and are ignored.
+func parseOFXTree(doc string) (*ofxNode, error) {
+	start := strings.Index(strings.ToUpper(doc), "<OFX>")
+	if start < 0 {
+		return nil, errors.New("not an OFX file: <OFX> element not found")
+	}
+	body := doc[start:]
+
+	root := &ofxNode{}
+	stack := []*ofxNode{root}
+	for pos := 0; pos < len(body); {
+		lt := strings.IndexByte(body[pos:], '<')
+		if lt < 0 {
+			break
+		}
+		lt += pos
+		gt := strings.IndexByte(body[lt:], '>')
+		if gt < 0 {
+			return nil, errors.New("invalid OFX: unterminated tag")
+		}
+		gt += lt
+
+		end := len(body)
+		if next := strings.IndexByte(body[gt+1:], '<'); next >= 0 {
+			end = gt + 1 + next
+		}
+		tag := strings.TrimSpace(body[lt+1 : gt])
+		text := strings.TrimSpace(body[gt+1 : end])
+		pos = end
+
+		switch {
+		case tag == "" || tag[0] == '?' || tag[0] == '!':
+			// Processing instructions and comments
+		case tag[0] == '/':
+			name := strings.ToUpper(strings.TrimSpace(tag[1:]))
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].name == name {
+					stack = stack[:i]
+					break
+				}
+			}
+		default:
+			selfClosing := strings.HasSuffix(tag, "/")
+			name := strings.ToUpper(strings.Fields(strings.TrimSuffix(tag, "/"))[0])
+			node := &ofxNode{name: name}
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, node)
+			if text != "" {
+				node.value = html.UnescapeString(text)
+			} else if !selfClosing {
+				stack = append(stack, node)
+			}
+		}
+	}
+
+	return root, nil
+}
+
+// child returns the first direct child with the given name
+func (n *ofxNode) child(name string) *ofxNode {
+	for _, c := range n.children {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// text returns the value of the first descendant with the given name. A
+// descendant search also finds leaves that follow an empty SGML element,
+// which the tree builder nests inside it.
+func (n *ofxNode) text(name string) string {
+	for _, c := range n.children {
+		if c.name == name && c.value != "" {
+			return c.value
+		}
+	}
+	for _, c := range n.children {
+		if v := c.text(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// descendants returns the descendants with any of the given names, in
+// document order, without looking inside the matches
+func (n *ofxNode) descendants(names ...string) []*ofxNode {
+	var found []*ofxNode
+	for _, c := range n.children {
+		matched := false
+		for _, name := range names {
+			if c.name == name {
+				matched = true
+				break
+			}
+		}
+		if matched {
+			found = append(found, c)
+		} else {
+			found = append(found, c.descendants(names...)...)
+		}
+	}
+	return found
+}
+
+// ofxStatement converts a STMTRS or CCSTMTRS aggregate
+func ofxStatement(rs *ofxNode) (*Statement, error) {
+	statement := &Statement{
+		Format:   FormatOFX,
+		Currency: rs.text("CURDEF"),
+	}
+
+	from := rs.child("BANKACCTFROM")
+	if from == nil {
+		from = rs.child("CCACCTFROM")
+	}
+	if from != nil {
+		statement.BankID = from.text("BANKID")
+		statement.AccountID = from.text("ACCTID")
+		statement.AccountType = from.text("ACCTTYPE")
+	}
+	if rs.name == "CCSTMTRS" && statement.AccountType == "" {
+		statement.AccountType = "CREDITCARD"
+	}
+
+	if balance := rs.child("LEDGERBAL"); balance != nil {
+		if amount := balance.text("BALAMT"); amount != "" {
+			value, err := parseAmount(amount)
+			if err != nil {
+				return nil, fmt.Errorf("LEDGERBAL: %w", err)
+			}
+			statement.LedgerBalance = &value
+		}
+		statement.BalanceDate = optionalOFXDate(balance.text("DTASOF"))
+	}
+
+	list := rs.child("BANKTRANLIST")
+	if list == nil {
+		return statement, nil
+	}
+	statement.StartDate = optionalOFXDate(list.text("DTSTART"))
+	statement.EndDate = optionalOFXDate(list.text("DTEND"))
+
+	for i, trn := range list.descendants("STMTTRN") {
+		tx, err := ofxTransaction(trn)
+		if err != nil {
+			return nil, fmt.Errorf("STMTTRN %d: %w", i+1, err)
+		}
+		if tx.Currency == "" {
+			tx.Currency = statement.Currency
+		}
+		statement.Transactions = append(statement.Transactions, tx)
+	}
+
+	return statement, nil
+}
+
+// ofxTransaction converts a STMTTRN aggregate
+func ofxTransaction(trn *ofxNode) (*Transaction, error) {
+	date, err := parseOFXDate(trn.text("DTPOSTED"))
+	if err != nil {
+		return nil, fmt.Errorf("DTPOSTED: %w", err)
+	}
+	amount, err := parseAmount(trn.text("TRNAMT"))
+	if err != nil {
+		return nil, fmt.Errorf("TRNAMT: %w", err)
+	}
+
+	tx := &Transaction{
+		ID:       trn.text("FITID"),
+		Type:     trn.text("TRNTYPE"),
+		Date:     date,
+		Amount:   amount,
+		Payee:    trn.text("NAME"),
+		Memo:     trn.text("MEMO"),
+		CheckNum: trn.text("CHECKNUM"),
+	}
+	if currency := trn.child("CURRENCY"); currency != nil {
+		tx.Currency = currency.text("CURSYM")
+	} else if currency := trn.child("ORIGCURRENCY"); currency != nil {
+		tx.Currency = currency.text("CURSYM")
+	}
+
+	return tx, nil
+}
+
+// parseOFXDate reads the calendar date of an OFX datetime
+// (YYYYMMDD[HHMMSS[.XXX]][[offset:TZ]]); the time of day is ignored as
+// statement lines are booked on a day
+func parseOFXDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 8 {
+		return time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+	t, err := time.Parse("20060102", s[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+	return t, nil
+}
+
+// optionalOFXDate parses an OFX datetime, returning nil when it is missing
+// or malformed
+func optionalOFXDate(s string) *time.Time {
+	if s == "" {
+		return nil
+	}
+	t, err := parseOFXDate(s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,279 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const sgmlStatement = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0<SEVERITY>INFO</STATUS></SONRS></SIGNONMSGSRSV1>
+<BANKMSGSRSV1>
+<STMTTRNRS>
+<STMTRS>
+<CURDEF>EUR
+<BANKACCTFROM>
+<BANKID>12345678
+<ACCTID>0001234567
+<ACCTTYPE>CHECKING
+</BANKACCTFROM>
+<BANKTRANLIST>
+<DTSTART>20240101
+<DTEND>20240131235959.000[+1:CET]
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20240105120000
+<TRNAMT>-12,50
+<FITID>A-1
+<NAME>Bakery &amp; Sons
+<MEMO>Card payment
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20240110
+<TRNAMT>+1000.00
+<FITID>A-2
+<NAME>Employer
+<CURRENCY><CURRATE>1.1<CURSYM>USD</CURRENCY>
+</STMTTRN>
+</BANKTRANLIST>
+<LEDGERBAL>
+<BALAMT>987.50
+<DTASOF>20240131
+</LEDGERBAL>
+</STMTRS>
+</STMTTRNRS>
+</BANKMSGSRSV1>
+</OFX>
+`
+
+const xmlStatement = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220"?>
+<OFX>
+  <CREDITCARDMSGSRSV1>
+    <CCSTMTTRNRS>
+      <CCSTMTRS>
+        <CURDEF>USD</CURDEF>
+        <CCACCTFROM>
+          <ACCTID>4111</ACCTID>
+        </CCACCTFROM>
+        <BANKTRANLIST>
+          <DTSTART>20240201</DTSTART>
+          <DTEND>20240229</DTEND>
+          <STMTTRN>
+            <TRNTYPE>DEBIT</TRNTYPE>
+            <DTPOSTED>20240203000000.000[-5:EST]</DTPOSTED>
+            <TRNAMT>-42.00</TRNAMT>
+            <FITID>CC-1</FITID>
+            <NAME/>
+            <MEMO>Fuel</MEMO>
+          </STMTTRN>
+        </BANKTRANLIST>
+      </CCSTMTRS>
+    </CCSTMTTRNRS>
+  </CREDITCARDMSGSRSV1>
+</OFX>
+`
+
+func TestParseOFXSGML(t *testing.T) {
+	statements, err := ParseOFX(strings.NewReader(sgmlStatement))
+	if err != nil {
+		t.Fatalf("ParseOFX: %v", err)
+	}
+	if len(statements) != 1 {
+		t.Fatalf("got %d statements, want 1", len(statements))
+	}
+	s := statements[0]
+
+	if s.Format != FormatOFX || s.Currency != "EUR" || s.BankID != "12345678" || s.AccountID != "0001234567" || s.AccountType != "CHECKING" {
+		t.Errorf("statement header = %+v", s)
+	}
+	if s.StartDate == nil || !s.StartDate.Equal(date(2024, 1, 1)) {
+		t.Errorf("StartDate = %v, want 2024-01-01", s.StartDate)
+	}
+	if s.EndDate == nil || !s.EndDate.Equal(date(2024, 1, 31)) {
+		t.Errorf("EndDate = %v, want 2024-01-31", s.EndDate)
+	}
+	if s.LedgerBalance == nil || s.LedgerBalance.String() != "987.5" {
+		t.Errorf("LedgerBalance = %v, want 987.5", s.LedgerBalance)
+	}
+	if len(s.Transactions) != 2 {
+		t.Fatalf("got %d transactions, want 2", len(s.Transactions))
+	}
+
+	first := s.Transactions[0]
+	if first.ID != "A-1" || first.Type != "DEBIT" || first.Amount.String() != "-12.5" {
+		t.Errorf("first transaction = %+v", first)
+	}
+	if !first.Date.Equal(date(2024, 1, 5)) {
+		t.Errorf("first Date = %v, want 2024-01-05", first.Date)
+	}
+	if first.Payee != "Bakery & Sons" || first.Memo != "Card payment" {
+		t.Errorf("first Payee, Memo = %q, %q", first.Payee, first.Memo)
+	}
+	if first.Currency != "EUR" {
+		t.Errorf("first Currency = %q, want the statement's EUR", first.Currency)
+	}
+
+	second := s.Transactions[1]
+	if second.Amount.String() != "1000" || second.Currency != "USD" {
+		t.Errorf("second Amount, Currency = %s, %q", second.Amount, second.Currency)
+	}
+}
+
+func TestParseOFXXML(t *testing.T) {
+	statements, err := ParseOFX(strings.NewReader(xmlStatement))
+	if err != nil {
+		t.Fatalf("ParseOFX: %v", err)
+	}
+	if len(statements) != 1 {
+		t.Fatalf("got %d statements, want 1", len(statements))
+	}
+	s := statements[0]
+
+	if s.AccountID != "4111" || s.AccountType != "CREDITCARD" || s.Currency != "USD" {
+		t.Errorf("statement header = %+v", s)
+	}
+	if len(s.Transactions) != 1 {
+		t.Fatalf("got %d transactions, want 1", len(s.Transactions))
+	}
+	tx := s.Transactions[0]
+	if tx.ID != "CC-1" || tx.Amount.String() != "-42" || !tx.Date.Equal(date(2024, 2, 3)) {
+		t.Errorf("transaction = %+v", tx)
+	}
+	if tx.Payee != "" || tx.Description() != "Fuel" {
+		t.Errorf("Payee, Description() = %q, %q; want empty payee and the memo", tx.Payee, tx.Description())
+	}
+}
+
+func TestParseOFXTree(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{
+			name: "SGML leaves have no end tag",
+			doc:  "<OFX><A><B>1<C>2</A></OFX>",
+			want: "OFX(A(B=1 C=2))",
+		},
+		{
+			name: "XML end tags of leaves are ignored",
+			doc:  "<OFX><A><B>1</B><C>2</C></A></OFX>",
+			want: "OFX(A(B=1 C=2))",
+		},
+		{
+			name: "end tag closes the nearest open aggregate of its name",
+			doc:  "<OFX><A><B><C>1</A><D>2</OFX>",
+			want: "OFX(A(B(C=1)) D=2)",
+		},
+		{
+			name: "empty SGML element nests the following leaves",
+			doc:  "<OFX><A><NAME><MEMO>x</A></OFX>",
+			want: "OFX(A(NAME(MEMO=x)))",
+		},
+		{
+			name: "self-closing element is an empty leaf",
+			doc:  "<OFX><A><NAME/><MEMO>x</A></OFX>",
+			want: "OFX(A(NAME MEMO=x))",
+		},
+		{
+			name: "names are upper-cased and attributes dropped",
+			doc:  `<ofx><a kind="x"><b>1</a></ofx>`,
+			want: "OFX(A(B=1))",
+		},
+		{
+			name: "comments and processing instructions are skipped",
+			doc:  "<OFX><!-- note --><?pi?><A>1</OFX>",
+			want: "OFX(A=1)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := parseOFXTree(tt.doc)
+			if err != nil {
+				t.Fatalf("parseOFXTree: %v", err)
+			}
+			if got := dumpOFX(root.children); got != tt.want {
+				t.Errorf("tree = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOFXTreeErrors(t *testing.T) {
+	for _, doc := range []string{"OFXHEADER:100\n<FOO>", "<OFX><A"} {
+		if _, err := parseOFXTree(doc); err == nil {
+			t.Errorf("parseOFXTree(%q) succeeded, want an error", doc)
+		}
+	}
+}
+
+func TestOFXNodeText(t *testing.T) {
+	root, err := parseOFXTree("<OFX><STMTTRN><NAME><MEMO>inside</STMTTRN></OFX>")
+	if err != nil {
+		t.Fatalf("parseOFXTree: %v", err)
+	}
+	trn := root.descendants("STMTTRN")[0]
+	if got := trn.text("MEMO"); got != "inside" {
+		t.Errorf("text(MEMO) = %q, want the leaf nested in the empty NAME", got)
+	}
+	if got := trn.text("NAME"); got != "" {
+		t.Errorf("text(NAME) = %q, want empty", got)
+	}
+}
+
+func TestParseOFXDate(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{in: "20240229", want: date(2024, 2, 29)},
+		{in: "20240229235959", want: date(2024, 2, 29)},
+		{in: "20240229235959.123[-5:EST]", want: date(2024, 2, 29)},
+		{in: " 20240101 ", want: date(2024, 1, 1)},
+		{in: "2024011", wantErr: true},
+		{in: "20241301", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseOFXDate(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseOFXDate(%q) = %v, want an error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseOFXDate(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+// dumpOFX renders nodes as NAME=value for leaves and NAME(children) for
+// aggregates
+func dumpOFX(nodes []*ofxNode) string {
+	parts := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		switch {
+		case n.value != "":
+			parts = append(parts, n.name+"="+n.value)
+		case len(n.children) > 0:
+			parts = append(parts, n.name+"("+dumpOFX(n.children)+")")
+		default:
+			parts = append(parts, n.name)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,465 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// OpeningBalanceCategory is the category given to a Quicken opening balance,
+// which QIF writes as a transfer from the account to itself
+const OpeningBalanceCategory = "Opening Balances"
+
+// qifTransactionTypes are the !Type sections that hold transactions
+var qifTransactionTypes = map[string]bool{
+	"bank":  true,
+	"cash":  true,
+	"ccard": true,
+	"invst": true,
+	"oth a": true,
+	"oth l": true,
+}
+
+// qifIncomeCategories names the category of investment income actions that
+// do not name one themselves
+var qifIncomeCategories = map[string]string{
+	"div":     "Dividends",
+	"intinc":  "Interest",
+	"cglong":  "Capital Gains",
+	"cgmid":   "Capital Gains",
+	"cgshort": "Capital Gains",
+	"miscinc": "Miscellaneous Income",
+	"rtrncap": "Return of Capital",
+	"miscexp": "Miscellaneous Expense",
+}
+
+// QIFOptions controls how ambiguous QIF fields are read
+type QIFOptions struct {
+	// DayFirst reads dates as day/month/year instead of Quicken's month/day/year
+	DayFirst bool
+}
+
+// qifRecord collects the fields of one record in file order
+type qifRecord struct {
+	line   int
+	fields []qifField
+}
+
+type qifField struct {
+	code  byte
+	value string
+}
+
+// ParseQIF reads the transactions of a QIF file, one statement per account.
+// Bank, Cash, CCard, Invst and other asset/liability sections are read;
+// category, class, memorized and security lists are skipped. Transactions
+// before any !Account record belong to a statement with an empty AccountID.
+// Records that cannot be converted are reported as row errors and skipped.
+func ParseQIF(r io.Reader, opts QIFOptions) ([]*Statement, []*RowError, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read QIF: %w", err)
+	}
+
+	p := &qifParser{opts: opts, byAccount: make(map[string]*Statement)}
+	scanner := bufio.NewScanner(strings.NewReader(toUTF8(data)))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	line := 0
+	var record *qifRecord
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if text == "" {
+			continue
+		}
+
+		if text[0] == '!' {
+			p.header(text)
+			record = nil
+			continue
+		}
+		if text[0] == '^' {
+			if record != nil {
+				p.end(record)
+			}
+			record = nil
+			continue
+		}
+		if record == nil {
+			record = &qifRecord{line: line}
+		}
+		record.fields = append(record.fields, qifField{code: text[0], value: strings.TrimSpace(text[1:])})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read QIF: %w", err)
+	}
+	if record != nil {
+		p.end(record)
+	}
+
+	if !p.sawHeader {
+		return nil, nil, fmt.Errorf("not a QIF file: no !Type or !Account header found")
+	}
+	return p.statements, p.errors, nil
+}
+
+// qifParser tracks the section and account being read
+type qifParser struct {
+	opts       QIFOptions
+	section    string
+	autoSwitch bool
+	sawHeader  bool
+	account    string
+	accountTyp string
+	byAccount  map[string]*Statement
+	statements []*Statement
+	errors     []*RowError
+}
+
+// header switches section on a ! line
+func (p *qifParser) header(text string) {
+	lower := strings.ToLower(strings.TrimSpace(text))
+	switch {
+	case lower == "!account":
+		p.section = "account"
+		p.sawHeader = true
+	case strings.HasPrefix(lower, "!type:"):
+		p.section = strings.TrimSpace(strings.TrimPrefix(lower, "!type:"))
+		p.sawHeader = true
+	case lower == "!option:autoswitch":
+		p.autoSwitch = true
+	case lower == "!clear:autoswitch":
+		p.autoSwitch = false
+	}
+}
+
+// end handles a record terminated by ^
+func (p *qifParser) end(record *qifRecord) {
+	if p.section == "account" {
+		// Inside !Option:AutoSwitch the records are an account list, not a switch
+		if !p.autoSwitch {
+			p.account = record.get('N')
+			p.accountTyp = record.get('T')
+		}
+		return
+	}
+	if !qifTransactionTypes[p.section] {
+		return
+	}
+
+	tx, err := p.transaction(record)
+	if err != nil {
+		p.errors = append(p.errors, &RowError{Line: record.line, Message: err.Error()})
+		return
+	}
+	p.statement().Transactions = append(p.statement().Transactions, tx)
+}
+
+// statement returns the statement of the current account
+func (p *qifParser) statement() *Statement {
+	if s, ok := p.byAccount[p.account]; ok {
+		return s
+	}
+	accountType := p.accountTyp
+	if accountType == "" {
+		accountType = p.section
+	}
+	s := &Statement{Format: FormatQIF, AccountID: p.account, AccountType: accountType}
+	p.byAccount[p.account] = s
+	p.statements = append(p.statements, s)
+	return s
+}
+
+// transaction converts a transaction record
+func (p *qifParser) transaction(record *qifRecord) (*Transaction, error) {
+	date, err := parseQIFDate(record.get('D'), p.opts.DayFirst)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &Transaction{
+		Date:  date,
+		Payee: record.get('P'),
+		Memo:  record.get('M'),
+	}
+
+	if p.section == "invst" {
+		if err := p.investment(record, tx); err != nil {
+			return nil, err
+		}
+		return tx, nil
+	}
+
+	tx.CheckNum = record.get('N')
+	if tx.Amount, err = record.amount(); err != nil {
+		return nil, err
+	}
+
+	var split *Split
+	for _, f := range record.fields {
+		switch f.code {
+		case 'L':
+			tx.Category, tx.Transfer = p.category(f.value)
+		case 'S':
+			split = &Split{}
+			split.Category, split.Transfer = p.category(f.value)
+			tx.Splits = append(tx.Splits, split)
+		case 'E':
+			if split != nil {
+				split.Memo = f.value
+			}
+		case '$':
+			if split != nil {
+				if split.Amount, err = parseQIFAmount(f.value); err != nil {
+					return nil, fmt.Errorf("invalid split amount: %w", err)
+				}
+			}
+		}
+	}
+
+	if len(tx.Splits) > 0 {
+		total := decimal.Zero
+		for _, s := range tx.Splits {
+			total = total.Add(s.Amount)
+		}
+		if !total.Equal(tx.Amount) {
+			return nil, fmt.Errorf("splits add up to %s but the transaction amount is %s", total, tx.Amount)
+		}
+	}
+
+	return tx, nil
+}
+
+// investment converts an investment record into its effect on the account's
+// cash and the legs that balance it
+func (p *qifParser) investment(record *qifRecord, tx *Transaction) error {
+	tx.Action = record.get('N')
+	action := strings.ToLower(tx.Action)
+	security := record.get('Y')
+
+	var err error
+	total := decimal.Zero
+	if record.has('T') || record.has('U') {
+		if total, err = record.amount(); err != nil {
+			return err
+		}
+	}
+	quantity := decimal.Zero
+	if q := record.get('Q'); q != "" {
+		if quantity, err = parseQIFAmount(q); err != nil {
+			return fmt.Errorf("invalid quantity: %w", err)
+		}
+	}
+	category, transfer := p.category(record.get('L'))
+
+	if tx.Payee == "" {
+		tx.Payee = strings.TrimSpace(tx.Action + " " + security)
+	}
+
+	base := action
+	moved := false
+	if strings.HasSuffix(action, "x") && action != "cashx" {
+		base = strings.TrimSuffix(action, "x")
+		moved = true
+	}
+
+	amount := total.Abs()
+	switch base {
+	case "buy":
+		if security == "" {
+			return fmt.Errorf("%s has no security", tx.Action)
+		}
+		tx.Splits = []*Split{{Category: security, Security: true, Amount: amount.Neg(), Quantity: quantity.Abs()}}
+	case "sell":
+		if security == "" {
+			return fmt.Errorf("%s has no security", tx.Action)
+		}
+		tx.Splits = []*Split{{Category: security, Security: true, Amount: amount, Quantity: quantity.Abs().Neg()}}
+	case "div", "intinc", "cglong", "cgmid", "cgshort", "miscinc", "rtrncap":
+		income := qifIncomeCategories[base]
+		if !moved && category != "" && !transfer {
+			income = category
+		}
+		tx.Splits = []*Split{{Category: income, Amount: amount}}
+	case "miscexp":
+		expense := qifIncomeCategories[base]
+		if !moved && category != "" && !transfer {
+			expense = category
+		}
+		tx.Splits = []*Split{{Category: expense, Amount: amount.Neg()}}
+	case "reinvdiv", "reinvint", "reinvlg", "reinvmd", "reinvsh":
+		if security == "" {
+			return fmt.Errorf("%s has no security", tx.Action)
+		}
+		income := qifIncomeCategories[map[string]string{
+			"reinvdiv": "div", "reinvint": "intinc", "reinvlg": "cglong", "reinvmd": "cgmid", "reinvsh": "cgshort",
+		}[base]]
+		tx.Splits = []*Split{
+			{Category: income, Amount: amount},
+			{Category: security, Security: true, Amount: amount.Neg(), Quantity: quantity.Abs()},
+		}
+		moved = false
+	case "xin", "xout", "cash", "contrib", "withdrw":
+		if category == "" {
+			return fmt.Errorf("%s has no transfer account", tx.Action)
+		}
+		signed := total
+		switch base {
+		case "xin", "contrib":
+			signed = amount
+		case "xout", "withdrw":
+			signed = amount.Neg()
+		}
+		tx.Splits = []*Split{{Category: category, Transfer: transfer, Amount: signed}}
+		moved = false
+	default:
+		return fmt.Errorf("investment action %q is not imported", tx.Action)
+	}
+
+	// X actions move the cash to or from another account instead of leaving
+	// it in this one
+	if moved {
+		if category == "" {
+			return fmt.Errorf("%s has no transfer account", tx.Action)
+		}
+		cash := decimal.Zero
+		for _, s := range tx.Splits {
+			cash = cash.Add(s.Amount)
+		}
+		if moved := record.get('$'); moved != "" {
+			value, err := parseQIFAmount(moved)
+			if err != nil {
+				return fmt.Errorf("invalid transfer amount: %w", err)
+			}
+			if cash.IsNegative() {
+				value = value.Abs().Neg()
+			} else {
+				value = value.Abs()
+			}
+			if !value.Equal(cash) {
+				return fmt.Errorf("transfer amount %s does not match %s", value, cash)
+			}
+		}
+		tx.Splits = append(tx.Splits, &Split{Category: category, Transfer: true, Amount: cash.Neg()})
+	}
+
+	for _, s := range tx.Splits {
+		tx.Amount = tx.Amount.Add(s.Amount)
+	}
+	return nil
+}
+
+// category splits an L or S field into its category or transfer account,
+// dropping any /class suffix. A transfer to the account itself is Quicken's
+// opening balance.
+func (p *qifParser) category(value string) (string, bool) {
+	if i := strings.LastIndex(value, "/"); i >= 0 && !strings.Contains(value[i:], "]") {
+		value = value[:i]
+	}
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
+		name := strings.TrimSpace(value[1 : len(value)-1])
+		if p.account != "" && name == p.account {
+			return OpeningBalanceCategory, false
+		}
+		return name, true
+	}
+	return value, false
+}
+
+// get returns the value of the first field with the given code
+func (r *qifRecord) get(code byte) string {
+	for _, f := range r.fields {
+		if f.code == code {
+			return f.value
+		}
+	}
+	return ""
+}
+
+// has reports whether the record has a field with the given code
+func (r *qifRecord) has(code byte) bool {
+	for _, f := range r.fields {
+		if f.code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// amount reads the transaction amount from T, or U when T is missing
+func (r *qifRecord) amount() (decimal.Decimal, error) {
+	text := r.get('T')
+	if text == "" {
+		text = r.get('U')
+	}
+	value, err := parseQIFAmount(text)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid amount: %w", err)
+	}
+	return value, nil
+}
+
+// parseQIFAmount reads an amount, ignoring thousands separators
+func parseQIFAmount(s string) (decimal.Decimal, error) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), ",", "")
+	if s == "" {
+		return decimal.Zero, fmt.Errorf("empty value")
+	}
+	return parseAmount(s)
+}
+
+// parseQIFDate reads a Quicken date such as 1/31/2024, 1/31'24 or 1/31/98.
+// Two-digit years after an apostrophe are in the 2000s; otherwise years below
+// 50 are in the 2000s and the rest in the 1900s.
+func parseQIFDate(s string, dayFirst bool) (time.Time, error) {
+	text := strings.ReplaceAll(strings.TrimSpace(s), " ", "")
+	apostrophe := strings.Contains(text, "'")
+	text = strings.NewReplacer("'", "/", "-", "/", ".", "/").Replace(text)
+
+	parts := strings.Split(text, "/")
+	if len(parts) != 3 {
+		return time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+	nums := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q", s)
+		}
+		nums[i] = n
+	}
+
+	var year, month, day int
+	switch {
+	case len(parts[0]) == 4:
+		year, month, day = nums[0], nums[1], nums[2]
+	case dayFirst:
+		day, month, year = nums[0], nums[1], nums[2]
+	default:
+		month, day, year = nums[0], nums[1], nums[2]
+	}
+	if len(parts[2]) <= 2 && len(parts[0]) != 4 {
+		switch {
+		case apostrophe || year < 50:
+			year += 2000
+		default:
+			year += 1900
+		}
+	}
+
+	t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if t.Year() != year || int(t.Month()) != month || t.Day() != day {
+		return time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+	return t, nil
+}
@@ -0,0 +1,272 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseQIFBank(t *testing.T) {
+	data := "\ufeff!Account\nNChecking\nTBank\n^\n" +
+		"!Type:Bank\n" +
+		"D1/31'24\nT-1,234.56\nPLandlord\nN1001\nLHousing:Rent/Home\n^\n" +
+		"D2/1'24\nT-100.00\nPMarket\nSGroceries\nEFood\n$-60.00\nS[Savings]\n$-40.00\n^\n" +
+		"D2/2'24\nT500.00\nL[Checking]\n^\n" +
+		"D2/3'24\nT-10.00\nSFees\n$-5.00\n^\n" +
+		"D13/13/2024\nT1.00\n^\n"
+
+	statements, rowErrors, err := ParseQIF(strings.NewReader(data), QIFOptions{})
+	if err != nil {
+		t.Fatalf("ParseQIF: %v", err)
+	}
+	if len(statements) != 1 {
+		t.Fatalf("got %d statements, want 1", len(statements))
+	}
+	s := statements[0]
+	if s.Format != FormatQIF || s.AccountID != "Checking" || s.AccountType != "Bank" {
+		t.Errorf("statement header = %+v", s)
+	}
+	if len(s.Transactions) != 3 {
+		t.Fatalf("got %d transactions, want 3", len(s.Transactions))
+	}
+
+	rent := s.Transactions[0]
+	if !rent.Date.Equal(date(2024, 1, 31)) || rent.Amount.String() != "-1234.56" || rent.CheckNum != "1001" {
+		t.Errorf("rent = %+v", rent)
+	}
+	if rent.Category != "Housing:Rent" || rent.Transfer {
+		t.Errorf("rent category = %q transfer %v, want Housing:Rent without the class", rent.Category, rent.Transfer)
+	}
+
+	market := s.Transactions[1]
+	if got, want := dumpSplits(market.Splits), "Groceries|-60|0|category|Food Savings|-40|0|transfer|"; got != want {
+		t.Errorf("market splits = %s, want %s", got, want)
+	}
+
+	opening := s.Transactions[2]
+	if opening.Category != OpeningBalanceCategory || opening.Transfer {
+		t.Errorf("transfer to itself = %q transfer %v, want the opening balance category", opening.Category, opening.Transfer)
+	}
+
+	if len(rowErrors) != 2 {
+		t.Fatalf("got %d row errors %v, want 2", len(rowErrors), rowErrors)
+	}
+	if rowErrors[0].Line != 25 || !strings.Contains(rowErrors[0].Message, "splits add up to -5") {
+		t.Errorf("first row error = %v, want the split total mismatch on line 25", rowErrors[0])
+	}
+	if rowErrors[1].Line != 30 || !strings.Contains(rowErrors[1].Message, "invalid date") {
+		t.Errorf("second row error = %v, want the invalid date on line 30", rowErrors[1])
+	}
+}
+
+func TestParseQIFAccounts(t *testing.T) {
+	data := "!Option:AutoSwitch\n!Account\nNListed\nTBank\n^\n!Clear:AutoSwitch\n" +
+		"!Type:Cash\nD1/1/24\nT-1\n^\n" +
+		"!Account\nNWallet\nTCash\n^\n!Type:Cash\nD1/2/24\nT-2\n^\n" +
+		"!Type:Cat\nNFood\n^\n" +
+		"!Account\nNVisa\nTCCard\n^\n!Type:CCard\nD1/3/24\nT-3\n^\n"
+
+	statements, rowErrors, err := ParseQIF(strings.NewReader(data), QIFOptions{})
+	if err != nil {
+		t.Fatalf("ParseQIF: %v", err)
+	}
+	if len(rowErrors) != 0 {
+		t.Errorf("row errors = %v", rowErrors)
+	}
+
+	var got []string
+	for _, s := range statements {
+		got = append(got, fmt.Sprintf("%s:%s:%d", s.AccountID, s.AccountType, len(s.Transactions)))
+	}
+	if want := ":cash:1 Wallet:Cash:1 Visa:CCard:1"; strings.Join(got, " ") != want {
+		t.Errorf("statements = %s, want %s", strings.Join(got, " "), want)
+	}
+}
+
+func TestParseQIFNotQIF(t *testing.T) {
+	if _, _, err := ParseQIF(strings.NewReader("D1/1/24\nT1\n^\n"), QIFOptions{}); err == nil {
+		t.Error("ParseQIF without a header succeeded, want an error")
+	}
+}
+
+func TestParseQIFInvestment(t *testing.T) {
+	tests := []struct {
+		name    string
+		record  string
+		amount  string
+		splits  string
+		wantErr string
+	}{
+		{
+			name:   "buy pays cash for shares",
+			record: "NBuy\nYACME\nI100\nQ10\nT1,000.00",
+			amount: "-1000",
+			splits: "ACME|-1000|10|security|",
+		},
+		{
+			name:   "sell receives cash for shares",
+			record: "NSell\nYACME\nQ5\nT500.00",
+			amount: "500",
+			splits: "ACME|500|-5|security|",
+		},
+		{
+			name:   "dividend to a named category",
+			record: "NDiv\nYACME\nT50.00\nLIncome:Dividends",
+			amount: "50",
+			splits: "Income:Dividends|50|0|category|",
+		},
+		{
+			name:   "dividend to the default category",
+			record: "NDiv\nYACME\nT50.00",
+			amount: "50",
+			splits: "Dividends|50|0|category|",
+		},
+		{
+			name:   "interest income",
+			record: "NIntInc\nT2.50",
+			amount: "2.5",
+			splits: "Interest|2.5|0|category|",
+		},
+		{
+			name:   "miscellaneous expense",
+			record: "NMiscExp\nT15.00\nLFees",
+			amount: "-15",
+			splits: "Fees|-15|0|category|",
+		},
+		{
+			name:   "buy with cash from another account",
+			record: "NBuyX\nYACME\nQ10\nT1,000.00\nL[Checking]\n$1,000.00",
+			amount: "0",
+			splits: "ACME|-1000|10|security| Checking|1000|0|transfer|",
+		},
+		{
+			name:   "dividend moved to another account ignores its category",
+			record: "NDivX\nYACME\nT50.00\nL[Checking]",
+			amount: "0",
+			splits: "Dividends|50|0|category| Checking|-50|0|transfer|",
+		},
+		{
+			name:   "reinvested dividend leaves cash unchanged",
+			record: "NReinvDiv\nYACME\nQ2\nT100.00",
+			amount: "0",
+			splits: "Dividends|100|0|category| ACME|-100|2|security|",
+		},
+		{
+			name:   "cash transferred in",
+			record: "NXIn\nT200.00\nL[Checking]",
+			amount: "200",
+			splits: "Checking|200|0|transfer|",
+		},
+		{
+			name:   "cash transferred out",
+			record: "NXOut\nT200.00\nL[Checking]",
+			amount: "-200",
+			splits: "Checking|-200|0|transfer|",
+		},
+		{
+			name:   "cash keeps the record's sign",
+			record: "NCash\nT-30.00\nLFees",
+			amount: "-30",
+			splits: "Fees|-30|0|category|",
+		},
+		{
+			name:    "buy without a security",
+			record:  "NBuy\nT100.00",
+			wantErr: "has no security",
+		},
+		{
+			name:    "transfer without an account",
+			record:  "NXIn\nT100.00",
+			wantErr: "has no transfer account",
+		},
+		{
+			name:    "moved cash that does not match",
+			record:  "NSellX\nYACME\nQ1\nT100.00\nL[Checking]\n$90.00",
+			wantErr: "transfer amount 90 does not match 100",
+		},
+		{
+			name:    "unsupported action",
+			record:  "NShrsIn\nYACME\nQ1",
+			wantErr: `"ShrsIn" is not imported`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := "!Account\nNBrokerage\nTInvst\n^\n!Type:Invst\nD3/1/24\n" + tt.record + "\n^\n"
+			statements, rowErrors, err := ParseQIF(strings.NewReader(data), QIFOptions{})
+			if err != nil {
+				t.Fatalf("ParseQIF: %v", err)
+			}
+
+			if tt.wantErr != "" {
+				if len(rowErrors) != 1 || !strings.Contains(rowErrors[0].Message, tt.wantErr) {
+					t.Errorf("row errors = %v, want %q", rowErrors, tt.wantErr)
+				}
+				return
+			}
+			if len(rowErrors) != 0 {
+				t.Fatalf("row errors = %v", rowErrors)
+			}
+			tx := statements[0].Transactions[0]
+			if tx.Amount.String() != tt.amount {
+				t.Errorf("Amount = %s, want %s", tx.Amount, tt.amount)
+			}
+			if got := dumpSplits(tx.Splits); got != tt.splits {
+				t.Errorf("splits = %s, want %s", got, tt.splits)
+			}
+		})
+	}
+}
+
+func TestParseQIFDate(t *testing.T) {
+	tests := []struct {
+		in       string
+		dayFirst bool
+		want     time.Time
+		wantErr  bool
+	}{
+		{in: "1/31/2024", want: date(2024, 1, 31)},
+		{in: "1/31'24", want: date(2024, 1, 31)},
+		{in: " 1/ 5'99", want: date(2099, 1, 5)},
+		{in: "1/31/98", want: date(1998, 1, 31)},
+		{in: "1/31/49", want: date(2049, 1, 31)},
+		{in: "2024-01-31", want: date(2024, 1, 31)},
+		{in: "31.01.2024", dayFirst: true, want: date(2024, 1, 31)},
+		{in: "31/1/24", dayFirst: true, want: date(2024, 1, 31)},
+		{in: "31/1/24", wantErr: true},
+		{in: "2/30/2024", wantErr: true},
+		{in: "1/31", wantErr: true},
+		{in: "Jan 31 2024", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseQIFDate(tt.in, tt.dayFirst)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseQIFDate(%q, %v) = %v, want an error", tt.in, tt.dayFirst, got)
+			}
+			continue
+		}
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseQIFDate(%q, %v) = %v, %v; want %v", tt.in, tt.dayFirst, got, err, tt.want)
+		}
+	}
+}
+
+// dumpSplits renders splits as category|amount|quantity|kind|memo
+func dumpSplits(splits []*Split) string {
+	parts := make([]string, 0, len(splits))
+	for _, s := range splits {
+		kind := "category"
+		switch {
+		case s.Security:
+			kind = "security"
+		case s.Transfer:
+			kind = "transfer"
+		}
+		parts = append(parts, fmt.Sprintf("%s|%s|%s|%s|%s", s.Category, s.Amount, s.Quantity, kind, s.Memo))
+	}
+	return strings.Join(parts, " ")
+}
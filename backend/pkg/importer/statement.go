@@ -0,0 +1,177 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+)
+
+// Statement formats
+const (
+	FormatOFX     = "ofx"
+	FormatCSV     = "csv"
+	FormatQIF     = "qif"
+	FormatCAMT053 = "camt.053"
+	FormatMT940   = "mt940"
+)
+
+// Statement is one account's statement as read from a file
+type Statement struct {
+	Format        string
+	BankID        string
+	AccountID     string
+	AccountType   string
+	Currency      string
+	StartDate     *time.Time
+	EndDate       *time.Time
+	LedgerBalance *decimal.Decimal
+	BalanceDate   *time.Time
+	Transactions  []*Transaction
+}
+
+// Transaction is one statement line. Amount is signed from the account
+// holder's point of view: positive amounts are money into the account. Date
+// is the booking date; Payee is the counterparty's name and Memo the
+// remittance information.
+type Transaction struct {
+	// ID is the bank's identifier for the line (the OFX FITID), stored by
+	// GnuCash in the online_id slot of the imported split
+	ID        string
+	Type      string
+	Date      time.Time
+	ValueDate *time.Time
+	Amount    decimal.Decimal
+	Payee     string
+	Memo      string
+	CheckNum  string
+	Currency  string
+
+	// CounterpartyIBAN is the account of the other party, when the bank
+	// reports it
+	CounterpartyIBAN string
+
+	// Category names the account the whole amount is booked against, such as
+	// a QIF category; Transfer marks it as the name of another account
+	Category string
+	Transfer bool
+	// Action is the investment action of a QIF Invst line
+	Action string
+	// Splits break the amount down over several categories; their amounts add
+	// up to Amount
+	Splits []*Split
+}
+
+// Split is one category leg of a transaction. Amount is signed like the
+// transaction's: it is the part of the account's change booked against the
+// category. Security legs name a security and carry the change in shares.
+type Split struct {
+	Category string
+	Transfer bool
+	Security bool
+	Memo     string
+	Amount   decimal.Decimal
+	Quantity decimal.Decimal
+}
+
+// Description returns the text to use as the transaction description: the
+// payee, or the memo when the bank sent no payee
+func (t *Transaction) Description() string {
+	if t.Payee != "" {
+		return t.Payee
+	}
+	return t.Memo
+}
+
+// NormalizeIBAN returns an IBAN without spaces in upper case, or "" when s
+// is not a valid IBAN
+func NormalizeIBAN(s string) string {
+	iban := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(s), " ", ""))
+	if len(iban) < 15 || len(iban) > 34 {
+		return ""
+	}
+	for i, r := range iban {
+		switch {
+		case i < 2 && (r < 'A' || r > 'Z'):
+			return ""
+		case i >= 2 && i < 4 && (r < '0' || r > '9'):
+			return ""
+		case (r < 'A' || r > 'Z') && (r < '0' || r > '9'):
+			return ""
+		}
+	}
+
+	// ISO 13616 check: move the first four characters to the end, replace
+	// letters with 10..35 and the result mod 97 must be 1
+	remainder := 0
+	for _, r := range iban[4:] + iban[:4] {
+		if r >= 'A' {
+			remainder = (remainder*100 + int(r-'A') + 10) % 97
+		} else {
+			remainder = (remainder*10 + int(r-'0')) % 97
+		}
+	}
+	if remainder != 1 {
+		return ""
+	}
+	return iban
+}
+
+// mergeStatements combines statements of the same account, as banks send
+// one per day, keeping the earliest start and the latest balance
+func mergeStatements(statements []*Statement) []*Statement {
+	var merged []*Statement
+	byAccount := make(map[string]*Statement)
+	for _, s := range statements {
+		into, ok := byAccount[s.AccountID]
+		if !ok {
+			byAccount[s.AccountID] = s
+			merged = append(merged, s)
+			continue
+		}
+		into.Transactions = append(into.Transactions, s.Transactions...)
+		if s.StartDate != nil && (into.StartDate == nil || s.StartDate.Before(*into.StartDate)) {
+			into.StartDate = s.StartDate
+		}
+		if s.EndDate != nil && (into.EndDate == nil || s.EndDate.After(*into.EndDate)) {
+			into.EndDate = s.EndDate
+		}
+		if s.LedgerBalance != nil && (into.BalanceDate == nil || (s.BalanceDate != nil && !s.BalanceDate.Before(*into.BalanceDate))) {
+			into.LedgerBalance = s.LedgerBalance
+			into.BalanceDate = s.BalanceDate
+		}
+		if into.Currency == "" {
+			into.Currency = s.Currency
+		}
+	}
+	return merged
+}
+
+// parseAmount reads a statement amount, accepting a leading plus sign and a
+// decimal comma
+func parseAmount(s string) (decimal.Decimal, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "+")
+	if !strings.Contains(s, ".") {
+		s = strings.Replace(s, ",", ".", 1)
+	}
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid amount %q", s)
+	}
+	return d, nil
+}
+
+// toUTF8 returns data as UTF-8, reading it as Latin-1 when it is not
+// already valid UTF-8 (older statements are often in a Windows code page)
+func toUTF8(data []byte) string {
+	if utf8.Valid(data) {
+		return string(data)
+	}
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+	return string(runes)
+}
@@ -38,7 +38,11 @@ export const NetWorthSummary: React.FC<NetWorthSummaryProps> = ({ data }) => {
               data.assets.slice(0, 5).map((item, index) => (
                 <div key={index} className="flex justify-between text-sm">
                   <span className="text-gray-600 truncate">{item.account_name}</span>
-                  <span className="font-medium text-green-600">{formatCurrency(item.balance, data.currency_mnemonic)}</span>
+                  <span className="font-medium text-green-600">
+                    {item.price_missing
+                      ? `${item.native_balance} ${item.commodity} (no price)`
+                      : formatCurrency(item.balance ?? '0', data.currency_mnemonic)}
+                  </span>
                 </div>
               ))
             ) : (
@@ -54,7 +58,11 @@ export const NetWorthSummary: React.FC<NetWorthSummaryProps> = ({ data }) => {
               data.liabilities.slice(0, 5).map((item, index) => (
                 <div key={index} className="flex justify-between text-sm">
                   <span className="text-gray-600 truncate">{item.account_name}</span>
-                  <span className="font-medium text-red-600">{formatCurrency(item.balance, data.currency_mnemonic)}</span>
+                  <span className="font-medium text-red-600">
+                    {item.price_missing
+                      ? `${item.native_balance} ${item.commodity} (no price)`
+                      : formatCurrency(item.balance ?? '0', data.currency_mnemonic)}
+                  </span>
                 </div>
               ))
             ) : (
@@ -26,9 +26,17 @@ export interface CategoryBreakdownResponse {
 }
 
 export interface NetWorthItem {
+  account_guid: string;
   account_name: string;
   account_type: string;
-  balance: string;
+  commodity: string;
+  native_balance: string;
+  rate?: string;
+  rate_method?: string;
+  rate_via?: string;
+  rate_date?: string;
+  balance?: string;
+  price_missing: boolean;
 }
 
 export interface NetWorthResponse {
@@ -38,4 +46,6 @@ export interface NetWorthResponse {
   total_liabilities: string;
   net_worth: string;
   currency_mnemonic?: string;
+  as_of: string;
+  missing_prices: number;
 }